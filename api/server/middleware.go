@@ -22,3 +22,24 @@ func (s *Server) handlerWithGlobalMiddlewares(handler httputils.APIFunc) httputi
 
 	return next
 }
+
+// handlerWithListenerMiddlewares wraps the handler function for a request with
+// the middlewares of the listener it was received on, if the listener has a
+// configuration. The read-only check is evaluated first, so that the other
+// middlewares, like authorization plugins, only see the allowed requests.
+func handlerWithListenerMiddlewares(handler httputils.APIFunc, cfg *ListenerConfig) httputils.APIFunc {
+	if cfg == nil {
+		return handler
+	}
+	next := handler
+
+	for _, m := range cfg.Middlewares {
+		next = m.WrapHandler(next)
+	}
+
+	if cfg.ReadOnly {
+		next = middleware.ReadOnlyMiddleware(next)
+	}
+
+	return next
+}
@@ -0,0 +1,245 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/server/httputils"
+	"github.com/docker/docker/pkg/ioutils"
+	"golang.org/x/net/context"
+)
+
+// maxAuditBodySize is the size above which request bodies are not recorded
+// in the audit log.
+const maxAuditBodySize = 64 * 1024 // 64KB
+
+// DefaultAuditRedactKeys are the keys of the request bodies whose values are
+// always redacted in the audit log.
+var DefaultAuditRedactKeys = []string{"password", "secret", "jointoken", "unlockkey", "authconfig", "identitytoken", "registrytoken"}
+
+// AuditRecord is the record written to the audit log for every mutating
+// API request.
+type AuditRecord struct {
+	Time   time.Time   `json:"time"`
+	User   string      `json:"user,omitempty"`
+	Remote string      `json:"remote,omitempty"`
+	Method string      `json:"method"`
+	Route  string      `json:"route"`
+	Query  string      `json:"query,omitempty"`
+	Object string      `json:"object,omitempty"`
+	Status int         `json:"status"`
+	Error  string      `json:"error,omitempty"`
+	Body   interface{} `json:"body,omitempty"`
+}
+
+// AuditMiddleware writes an AuditRecord for every API request that is
+// neither a GET nor a HEAD request.
+type AuditMiddleware struct {
+	w      io.Writer
+	redact []string
+}
+
+// NewAuditMiddleware creates a new AuditMiddleware writing JSON records to w.
+// The values of redactKeys, in addition to DefaultAuditRedactKeys, are
+// masked in the recorded request bodies.
+func NewAuditMiddleware(w io.Writer, redactKeys []string) AuditMiddleware {
+	return AuditMiddleware{
+		w:      w,
+		redact: append(append([]string{}, DefaultAuditRedactKeys...), redactKeys...),
+	}
+}
+
+// WrapHandler returns a new handler function wrapping the previous one in the request chain.
+func (a AuditMiddleware) WrapHandler(handler func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error) func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+		if r.Method == "GET" || r.Method == "HEAD" {
+			return handler(ctx, w, r, vars)
+		}
+
+		route := strings.TrimPrefix(r.URL.Path, "/v"+vars["version"])
+		record := AuditRecord{
+			Time:   time.Now().UTC(),
+			Remote: r.RemoteAddr,
+			Method: r.Method,
+			Route:  route,
+			Query:  r.URL.RawQuery,
+			Object: auditObject(vars),
+		}
+		if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+			record.User = r.TLS.PeerCertificates[0].Subject.CommonName
+		}
+		// the bodies of the secret requests hold the secrets themselves
+		if !strings.HasPrefix(route, "/secrets") {
+			record.Body = a.requestBody(r)
+		}
+
+		rec := &auditResponseRecorder{ResponseWriter: w, capture: record.Object == "" && strings.HasSuffix(route, "/create")}
+		err := handler(ctx, rec, r, vars)
+
+		record.Status = rec.statusCode()
+		if err != nil {
+			record.Status = httputils.GetHTTPErrorStatusCode(err)
+			record.Error = err.Error()
+		}
+		if rec.capture {
+			record.Object = createdObject(rec.body.Bytes())
+		}
+
+		a.write(record)
+		return err
+	}
+}
+
+func (a AuditMiddleware) write(record AuditRecord) {
+	b, err := json.Marshal(record)
+	if err != nil {
+		logrus.Errorf("Error encoding audit record for %s %s: %v", record.Method, record.Route, err)
+		return
+	}
+	if _, err := a.w.Write(append(b, '\n')); err != nil {
+		logrus.Errorf("Error writing audit record for %s %s: %v", record.Method, record.Route, err)
+	}
+}
+
+// requestBody returns the redacted JSON body of r, or nil if r doesn't have
+// a JSON body or if it is too large.
+func (a AuditMiddleware) requestBody(r *http.Request) interface{} {
+	if r.Body == nil || r.ContentLength > maxAuditBodySize {
+		return nil
+	}
+	if err := httputils.CheckForJSON(r); err != nil {
+		return nil
+	}
+
+	body := r.Body
+	bufReader := bufio.NewReaderSize(body, maxAuditBodySize)
+	r.Body = ioutils.NewReadCloserWrapper(bufReader, func() error { return body.Close() })
+
+	b, err := bufReader.Peek(maxAuditBodySize)
+	if err != io.EOF {
+		// either there was an error reading, or the buffer is full (in which case the request is too large)
+		return nil
+	}
+
+	var form interface{}
+	if err := json.Unmarshal(b, &form); err != nil {
+		return nil
+	}
+	maskEnvValues(form)
+	maskKeys(form, a.redact)
+	return form
+}
+
+// maskEnvValues replaces the environment variables of the Env lists found in
+// inp with their names, as their values often hold credentials.
+func maskEnvValues(inp interface{}) {
+	if arr, ok := inp.([]interface{}); ok {
+		for _, f := range arr {
+			maskEnvValues(f)
+		}
+		return
+	}
+	if form, ok := inp.(map[string]interface{}); ok {
+		for k, v := range form {
+			if env, ok := v.([]interface{}); ok && strings.EqualFold(k, "env") {
+				for i, e := range env {
+					if s, ok := e.(string); ok {
+						env[i] = strings.SplitN(s, "=", 2)[0]
+					}
+				}
+				continue
+			}
+			maskEnvValues(v)
+		}
+	}
+}
+
+// auditObject returns the name or ID of the object a request is made on,
+// from the variables of its route.
+func auditObject(vars map[string]string) string {
+	for _, key := range []string{"id", "name"} {
+		if v, ok := vars[key]; ok {
+			return v
+		}
+	}
+	return ""
+}
+
+// createdObject returns the ID, or the name, of the object created by a
+// request from the body of its response.
+func createdObject(body []byte) string {
+	var created struct {
+		ID   string
+		Name string
+	}
+	if err := json.Unmarshal(body, &created); err != nil {
+		return ""
+	}
+	if created.ID != "" {
+		return created.ID
+	}
+	return created.Name
+}
+
+// auditResponseRecorder records the status code of a response and, if
+// capture is set, the beginning of its body.
+type auditResponseRecorder struct {
+	http.ResponseWriter
+	status  int
+	capture bool
+	body    bytes.Buffer
+}
+
+func (r *auditResponseRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *auditResponseRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	if r.capture && r.body.Len() < maxAuditBodySize {
+		r.body.Write(b)
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *auditResponseRecorder) statusCode() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
+
+func (r *auditResponseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("Internal response writer doesn't support the Hijacker interface")
+	}
+	return hijacker.Hijack()
+}
+
+func (r *auditResponseRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (r *auditResponseRecorder) CloseNotify() <-chan bool {
+	if notifier, ok := r.ResponseWriter.(http.CloseNotifier); ok {
+		return notifier.CloseNotify()
+	}
+	logrus.Error("Internal response writer doesn't support the CloseNotifier interface")
+	return nil
+}
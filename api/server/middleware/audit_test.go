@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/server/httputils"
+	"golang.org/x/net/context"
+)
+
+func TestAuditMiddleware(t *testing.T) {
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Expected the request body to be readable by the handler: %v", err)
+		}
+		return httputils.WriteJSON(w, http.StatusCreated, map[string]string{"Id": "c0ffee"})
+	}
+
+	var buf bytes.Buffer
+	m := NewAuditMiddleware(&buf, nil)
+	h := m.WrapHandler(handler)
+
+	body := `{"Image":"busybox","Env":["TOKEN=s3cr3t","DEBUG"],"HostConfig":{"Binds":["/etc:/host/etc"]},"AuthConfig":{"password":"p4ss"}}`
+	req, _ := http.NewRequest("POST", "/v1.26/containers/create?name=web", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if err := h(context.Background(), httptest.NewRecorder(), req, map[string]string{"version": "1.26"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var record AuditRecord
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatal(err)
+	}
+	if record.Method != "POST" || record.Route != "/containers/create" || record.Query != "name=web" {
+		t.Fatalf("Unexpected request in audit record: %+v", record)
+	}
+	if record.Object != "c0ffee" {
+		t.Fatalf("Expected object c0ffee, got %q", record.Object)
+	}
+	if record.Status != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d", http.StatusCreated, record.Status)
+	}
+
+	recorded, _ := json.Marshal(record.Body)
+	if !strings.Contains(string(recorded), "/etc:/host/etc") {
+		t.Fatalf("Expected mounts to be recorded, got %s", recorded)
+	}
+	if !strings.Contains(string(recorded), `"Env":["TOKEN","DEBUG"]`) {
+		t.Fatalf("Expected the names of the environment variables to be recorded, got %s", recorded)
+	}
+	for _, secret := range []string{"s3cr3t", "p4ss"} {
+		if strings.Contains(string(recorded), secret) {
+			t.Fatalf("Expected %s to be redacted, got %s", secret, recorded)
+		}
+	}
+}
+
+func TestAuditMiddlewareErrors(t *testing.T) {
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+		return errors.New("No such container: web")
+	}
+
+	var buf bytes.Buffer
+	m := NewAuditMiddleware(&buf, nil)
+	h := m.WrapHandler(handler)
+
+	req, _ := http.NewRequest("DELETE", "/containers/web", nil)
+	if err := h(context.Background(), httptest.NewRecorder(), req, map[string]string{"name": "web"}); err == nil {
+		t.Fatal("Expected the handler error to be returned")
+	}
+
+	var record AuditRecord
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatal(err)
+	}
+	if record.Object != "web" || record.Status != http.StatusNotFound || record.Error == "" {
+		t.Fatalf("Unexpected audit record: %+v", record)
+	}
+}
+
+func TestAuditMiddlewareSkipsReads(t *testing.T) {
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	m := NewAuditMiddleware(&buf, nil)
+	h := m.WrapHandler(handler)
+
+	req, _ := http.NewRequest("GET", "/containers/json", nil)
+	if err := h(context.Background(), httptest.NewRecorder(), req, map[string]string{}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("Expected no audit record, got %s", buf.String())
+	}
+}
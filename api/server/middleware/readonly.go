@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/docker/docker/api/errors"
+	"golang.org/x/net/context"
+)
+
+// ReadOnlyMiddleware rejects the requests that can modify the state of the
+// daemon. Only GET and HEAD requests are allowed, except for the ones that
+// upgrade the connection, like attaching to a container with a websocket.
+func ReadOnlyMiddleware(handler func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error) func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+		if (r.Method != "GET" && r.Method != "HEAD") || r.Header.Get("Upgrade") != "" {
+			return errors.NewRequestForbiddenError(fmt.Errorf("%s %s is not allowed on a read-only listener", r.Method, r.URL.Path))
+		}
+		return handler(ctx, w, r, vars)
+	}
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/docker/docker/api/server/httputils"
+	"golang.org/x/net/context"
+)
+
+func TestReadOnlyMiddleware(t *testing.T) {
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+		return nil
+	}
+	h := ReadOnlyMiddleware(handler)
+
+	cases := []struct {
+		method  string
+		path    string
+		upgrade string
+		allowed bool
+	}{
+		{"GET", "/containers/json", "", true},
+		{"HEAD", "/containers/foo/archive", "", true},
+		{"POST", "/containers/create", "", false},
+		{"DELETE", "/containers/foo", "", false},
+		{"PUT", "/containers/foo/archive", "", false},
+		{"GET", "/containers/foo/attach/ws", "websocket", false},
+	}
+
+	for _, c := range cases {
+		req, _ := http.NewRequest(c.method, c.path, nil)
+		if c.upgrade != "" {
+			req.Header.Set("Upgrade", c.upgrade)
+		}
+		resp := httptest.NewRecorder()
+		err := h(context.Background(), resp, req, map[string]string{})
+		if c.allowed && err != nil {
+			t.Fatalf("Expected %s %s to be allowed, got %v", c.method, c.path, err)
+		}
+		if !c.allowed {
+			if err == nil {
+				t.Fatalf("Expected %s %s to be rejected", c.method, c.path)
+			}
+			if code := httputils.GetHTTPErrorStatusCode(err); code != http.StatusForbidden {
+				t.Fatalf("Expected status code %d, got %d", http.StatusForbidden, code)
+			}
+		}
+	}
+}
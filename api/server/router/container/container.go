@@ -41,18 +41,21 @@ func (r *containerRouter) initRoutes() {
 		// HEAD
 		router.NewHeadRoute("/containers/{name:.*}/archive", r.headContainersArchive),
 		// GET
-		router.NewGetRoute("/containers/json", r.getContainersJSON),
+		router.Cancellable(router.NewGetRoute("/containers/json", r.getContainersJSON)),
 		router.NewGetRoute("/containers/{name:.*}/export", r.getContainersExport),
 		router.NewGetRoute("/containers/{name:.*}/changes", r.getContainersChanges),
+		router.NewGetRoute("/containers/{name:.*}/export-changes", r.getContainersExportChanges),
 		router.NewGetRoute("/containers/{name:.*}/json", r.getContainersByName),
 		router.NewGetRoute("/containers/{name:.*}/top", r.getContainersTop),
 		router.Cancellable(router.NewGetRoute("/containers/{name:.*}/logs", r.getContainersLogs)),
 		router.Cancellable(router.NewGetRoute("/containers/{name:.*}/stats", r.getContainersStats)),
 		router.NewGetRoute("/containers/{name:.*}/attach/ws", r.wsContainersAttach),
+		router.NewGetRoute("/containers/{name:.*}/execs", r.getContainersExecs),
 		router.NewGetRoute("/exec/{id:.*}/json", r.getExecByID),
 		router.NewGetRoute("/containers/{name:.*}/archive", r.getContainersArchive),
 		// POST
 		router.NewPostRoute("/containers/create", r.postContainersCreate),
+		router.NewPostRoute("/containers/import", r.postContainersImport),
 		router.NewPostRoute("/containers/{name:.*}/kill", r.postContainersKill),
 		router.NewPostRoute("/containers/{name:.*}/pause", r.postContainersPause),
 		router.NewPostRoute("/containers/{name:.*}/unpause", r.postContainersUnpause),
@@ -66,9 +69,11 @@ func (r *containerRouter) initRoutes() {
 		router.NewPostRoute("/containers/{name:.*}/exec", r.postContainerExecCreate),
 		router.NewPostRoute("/exec/{name:.*}/start", r.postContainerExecStart),
 		router.NewPostRoute("/exec/{name:.*}/resize", r.postContainerExecResize),
+		router.NewPostRoute("/exec/{name:.*}/kill", r.postContainerExecKill),
 		router.NewPostRoute("/containers/{name:.*}/rename", r.postContainerRename),
 		router.NewPostRoute("/containers/{name:.*}/update", r.postContainerUpdate),
 		router.NewPostRoute("/containers/prune", r.postContainersPrune),
+		router.NewPostRoute("/containers/{name:.*}/copy-from", r.postContainersCopyFrom),
 		// PUT
 		router.NewPutRoute("/containers/{name:.*}/archive", r.putContainersArchive),
 		// DELETE
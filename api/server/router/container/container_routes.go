@@ -47,6 +47,14 @@ func (s *containerRouter) getContainersJSON(ctx context.Context, w http.Response
 		config.Limit = limit
 	}
 
+	if httputils.BoolValue(r, "watch") {
+		w.Header().Set("Content-Type", "application/json")
+		return s.backend.ContainersWatch(ctx, &backend.ContainersWatchConfig{
+			ListOptions: config,
+			OutStream:   w,
+		})
+	}
+
 	containers, err := s.backend.Containers(config)
 	if err != nil {
 		return err
@@ -120,7 +128,28 @@ func (s *containerRouter) getContainersLogs(ctx context.Context, w http.Response
 }
 
 func (s *containerRouter) getContainersExport(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
-	return s.backend.ContainerExport(vars["name"], w)
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+	return s.backend.ContainerExport(vars["name"], httputils.BoolValue(r, "full"), httputils.BoolValue(r, "volumes"), w)
+}
+
+func (s *containerRouter) postContainersImport(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	ccr, err := s.backend.ContainerImport(r.Form.Get("name"), r.Body)
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusCreated, ccr)
+}
+
+func (s *containerRouter) getContainersExportChanges(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	w.Header().Set("Content-Type", "application/x-tar")
+	return s.backend.ContainerExportChanges(vars["name"], w)
 }
 
 func (s *containerRouter) postContainersStart(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
@@ -288,7 +317,11 @@ func (s *containerRouter) postContainersWait(ctx context.Context, w http.Respons
 }
 
 func (s *containerRouter) getContainersChanges(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
-	changes, err := s.backend.ContainerChanges(vars["name"])
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	changes, err := s.backend.ContainerChanges(vars["name"], httputils.BoolValue(r, "size"))
 	if err != nil {
 		return err
 	}
@@ -558,7 +591,7 @@ func (s *containerRouter) postContainersPrune(ctx context.Context, w http.Respon
 		return err
 	}
 
-	pruneReport, err := s.backend.ContainersPrune(pruneFilters)
+	pruneReport, err := s.backend.ContainersPrune(pruneFilters, httputils.BoolValue(r, "dryrun"))
 	if err != nil {
 		return err
 	}
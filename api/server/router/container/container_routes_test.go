@@ -0,0 +1,65 @@
+package container
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/backend"
+	"golang.org/x/net/context"
+)
+
+type watchBackend struct {
+	Backend
+	options *types.ContainerListOptions
+	listed  bool
+}
+
+func (b *watchBackend) Containers(config *types.ContainerListOptions) ([]*types.Container, error) {
+	b.options, b.listed = config, true
+	return []*types.Container{}, nil
+}
+
+func (b *watchBackend) ContainersWatch(ctx context.Context, config *backend.ContainersWatchConfig) error {
+	b.options = config.ListOptions
+	return json.NewEncoder(config.OutStream).Encode(&types.ContainerWatchEvent{Type: types.ContainerWatchSync})
+}
+
+func TestGetContainersJSONWatch(t *testing.T) {
+	b := &watchBackend{}
+	r := &containerRouter{backend: b}
+
+	req, _ := http.NewRequest("GET", `/containers/json?watch=1&all=1&filters={"label":{"team=web":true}}`, nil)
+	w := httptest.NewRecorder()
+	if err := r.getContainersJSON(context.Background(), w, req, map[string]string{}); err != nil {
+		t.Fatal(err)
+	}
+	if b.listed {
+		t.Fatal("expected the containers to be watched, not listed")
+	}
+	if !b.options.All || !b.options.Filters.ExactMatch("label", "team=web") {
+		t.Fatalf("expected the list options to be passed to the watch, got %+v", b.options)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("expected a JSON stream, got %q", ct)
+	}
+	if body := strings.TrimSpace(w.Body.String()); body != `{"Type":"sync"}` {
+		t.Fatalf("unexpected stream: %s", body)
+	}
+}
+
+func TestGetContainersJSONNoWatch(t *testing.T) {
+	b := &watchBackend{}
+	r := &containerRouter{backend: b}
+
+	req, _ := http.NewRequest("GET", "/containers/json?watch=0", nil)
+	if err := r.getContainersJSON(context.Background(), httptest.NewRecorder(), req, map[string]string{}); err != nil {
+		t.Fatal(err)
+	}
+	if !b.listed {
+		t.Fatal("expected the containers to be listed")
+	}
+}
@@ -11,7 +11,10 @@ import (
 
 	"github.com/docker/docker/api/server/httputils"
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/backend"
 	"github.com/docker/docker/api/types/versions"
+	"github.com/docker/docker/pkg/ioutils"
+	"github.com/docker/docker/pkg/streamformatter"
 	"golang.org/x/net/context"
 )
 
@@ -112,5 +115,44 @@ func (s *containerRouter) putContainersArchive(ctx context.Context, w http.Respo
 	}
 
 	noOverwriteDirNonDir := httputils.BoolValue(r, "noOverwriteDirNonDir")
-	return s.backend.ContainerExtractToDir(v.Name, v.Path, noOverwriteDirNonDir, r.Body)
+	copyUIDGID := httputils.BoolValue(r, "copyUIDGID")
+	return s.backend.ContainerExtractToDir(v.Name, v.Path, copyUIDGID, noOverwriteDirNonDir, r.Body)
+}
+
+func (s *containerRouter) postContainersCopyFrom(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	v, err := httputils.ArchiveFormValues(r, vars)
+	if err != nil {
+		return err
+	}
+
+	// the source is only taken from the query, where authorization plugins
+	// look for it
+	query := r.URL.Query()
+	srcContainer, srcPath := query.Get("srcContainer"), query.Get("srcPath")
+	if srcContainer == "" || srcPath == "" {
+		return fmt.Errorf("srcContainer and srcPath are required")
+	}
+
+	output := ioutils.NewWriteFlusher(w)
+	defer output.Close()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	config := &backend.ContainerCopyConfig{
+		SrcContainer:         srcContainer,
+		SrcPath:              srcPath,
+		DstPath:              v.Path,
+		FollowLink:           httputils.BoolValue(r, "followLink"),
+		CopyUIDGID:           httputils.BoolValue(r, "copyUIDGID"),
+		NoOverwriteDirNonDir: httputils.BoolValue(r, "noOverwriteDirNonDir"),
+		OutStream:            output,
+	}
+	if err := s.backend.ContainerCopyBetween(v.Name, config); err != nil {
+		if !output.Flushed() {
+			return err
+		}
+		sf := streamformatter.NewJSONStreamFormatter()
+		output.Write(sf.FormatError(err))
+	}
+	return nil
 }
@@ -0,0 +1,61 @@
+package container
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"syscall"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+type execKillBackend struct {
+	Backend
+	name string
+	sig  uint64
+}
+
+func (b *execKillBackend) ContainerExecKill(name string, sig uint64) error {
+	b.name, b.sig = name, sig
+	return nil
+}
+
+func TestPostContainerExecKill(t *testing.T) {
+	b := &execKillBackend{}
+	r := &containerRouter{backend: b}
+
+	req, _ := http.NewRequest("POST", "/exec/exec_id/kill?signal=TERM", strings.NewReader(""))
+	w := httptest.NewRecorder()
+	if err := r.postContainerExecKill(context.Background(), w, req, map[string]string{"name": "exec_id"}); err != nil {
+		t.Fatal(err)
+	}
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d", http.StatusNoContent, w.Code)
+	}
+	if b.name != "exec_id" || b.sig != uint64(syscall.SIGTERM) {
+		t.Fatalf("unexpected kill of %q with signal %d", b.name, b.sig)
+	}
+
+	// the backend picks the default signal
+	req, _ = http.NewRequest("POST", "/exec/exec_id/kill", strings.NewReader(""))
+	if err := r.postContainerExecKill(context.Background(), httptest.NewRecorder(), req, map[string]string{"name": "exec_id"}); err != nil {
+		t.Fatal(err)
+	}
+	if b.sig != 0 {
+		t.Fatalf("expected no signal to be given, got %d", b.sig)
+	}
+}
+
+func TestPostContainerExecKillInvalidSignal(t *testing.T) {
+	b := &execKillBackend{}
+	r := &containerRouter{backend: b}
+
+	req, _ := http.NewRequest("POST", "/exec/exec_id/kill?signal=NOPE", strings.NewReader(""))
+	if err := r.postContainerExecKill(context.Background(), httptest.NewRecorder(), req, map[string]string{"name": "exec_id"}); err == nil {
+		t.Fatal("expected an error for an invalid signal")
+	}
+	if b.name != "" {
+		t.Fatal("expected the backend not to be called")
+	}
+}
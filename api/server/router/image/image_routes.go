@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/docker/docker/api/errors"
 	"github.com/docker/docker/api/server/httputils"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/backend"
@@ -18,6 +19,7 @@ import (
 	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/pkg/streamformatter"
 	"github.com/docker/docker/registry"
+	"github.com/docker/libtrust"
 	"golang.org/x/net/context"
 )
 
@@ -155,12 +157,21 @@ func (s *imageRouter) postImagesPush(ctx context.Context, w http.ResponseWriter,
 	image := vars["name"]
 	tag := r.Form.Get("tag")
 
+	var compressionLevel *int
+	if tmpLevel := r.Form.Get("compressionlevel"); tmpLevel != "" {
+		level, err := strconv.Atoi(tmpLevel)
+		if err != nil {
+			return err
+		}
+		compressionLevel = &level
+	}
+
 	output := ioutils.NewWriteFlusher(w)
 	defer output.Close()
 
 	w.Header().Set("Content-Type", "application/json")
 
-	if err := s.backend.PushImage(ctx, image, tag, metaHeaders, authConfig, output); err != nil {
+	if err := s.backend.PushImage(ctx, image, tag, compressionLevel, metaHeaders, authConfig, output); err != nil {
 		if !output.Flushed() {
 			return err
 		}
@@ -202,11 +213,19 @@ func (s *imageRouter) postImagesLoad(ctx context.Context, w http.ResponseWriter,
 	}
 	quiet := httputils.BoolValueOrDefault(r, "quiet", true)
 
+	var verifyKey libtrust.PublicKey
+	if key := r.Form.Get("verifykey"); key != "" {
+		var err error
+		if verifyKey, err = libtrust.UnmarshalPublicKeyPEM([]byte(key)); err != nil {
+			return errors.NewBadRequestError(fmt.Errorf("invalid verify key: %v", err))
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 
 	output := ioutils.NewWriteFlusher(w)
 	defer output.Close()
-	if err := s.backend.LoadImage(r.Body, output, quiet); err != nil {
+	if err := s.backend.LoadImage(r.Body, output, quiet, verifyKey); err != nil {
 		output.Write(streamformatter.NewJSONStreamFormatter().FormatError(err))
 	}
 	return nil
@@ -225,8 +244,9 @@ func (s *imageRouter) deleteImages(ctx context.Context, w http.ResponseWriter, r
 
 	force := httputils.BoolValue(r, "force")
 	prune := !httputils.BoolValue(r, "noprune")
+	dryRun := httputils.BoolValue(r, "dryrun")
 
-	list, err := s.backend.ImageDelete(name, force, prune)
+	list, err := s.backend.ImageDelete(name, force, prune, dryRun)
 	if err != nil {
 		return err
 	}
@@ -336,7 +356,7 @@ func (s *imageRouter) postImagesPrune(ctx context.Context, w http.ResponseWriter
 		return err
 	}
 
-	pruneReport, err := s.backend.ImagesPrune(pruneFilters)
+	pruneReport, err := s.backend.ImagesPrune(pruneFilters, httputils.BoolValue(r, "dryrun"))
 	if err != nil {
 		return err
 	}
@@ -212,7 +212,12 @@ func (sr *swarmRouter) removeService(ctx context.Context, w http.ResponseWriter,
 	return nil
 }
 
-func (sr *swarmRouter) getServiceLogs(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+// logsFunc streams the logs of a swarm object to the OutStream of the config.
+type logsFunc func(context.Context, string, *backend.ContainerLogsConfig, chan struct{}) error
+
+// swarmLogs handles the logs endpoints of services and tasks, which share
+// their parameters and streaming behavior.
+func (sr *swarmRouter) swarmLogs(ctx context.Context, w http.ResponseWriter, r *http.Request, input, kind string, logs logsFunc) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
 	}
@@ -227,7 +232,6 @@ func (sr *swarmRouter) getServiceLogs(ctx context.Context, w http.ResponseWriter
 		return fmt.Errorf("Bad parameters: you must choose at least one stream")
 	}
 
-	serviceName := vars["id"]
 	logsConfig := &backend.ContainerLogsConfig{
 		ContainerLogsOptions: basictypes.ContainerLogsOptions{
 			Follow:     httputils.BoolValue(r, "follow"),
@@ -246,13 +250,13 @@ func (sr *swarmRouter) getServiceLogs(ctx context.Context, w http.ResponseWriter
 	}
 
 	chStarted := make(chan struct{})
-	if err := sr.backend.ServiceLogs(ctx, serviceName, logsConfig, chStarted); err != nil {
+	if err := logs(ctx, input, logsConfig, chStarted); err != nil {
 		select {
 		case <-chStarted:
 			// The client may be expecting all of the data we're sending to
 			// be multiplexed, so send it through OutStream, which will
 			// have been set up to handle that if needed.
-			fmt.Fprintf(logsConfig.OutStream, "Error grabbing service logs: %v\n", err)
+			fmt.Fprintf(logsConfig.OutStream, "Error grabbing %s logs: %v\n", kind, err)
 		default:
 			return err
 		}
@@ -261,6 +265,10 @@ func (sr *swarmRouter) getServiceLogs(ctx context.Context, w http.ResponseWriter
 	return nil
 }
 
+func (sr *swarmRouter) getServiceLogs(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	return sr.swarmLogs(ctx, w, r, vars["id"], "service", sr.backend.ServiceLogs)
+}
+
 func (sr *swarmRouter) getNodes(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
@@ -351,6 +359,10 @@ func (sr *swarmRouter) getTask(ctx context.Context, w http.ResponseWriter, r *ht
 	return httputils.WriteJSON(w, http.StatusOK, task)
 }
 
+func (sr *swarmRouter) getTaskLogs(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	return sr.swarmLogs(ctx, w, r, vars["id"], "task", sr.backend.TaskLogs)
+}
+
 func (sr *swarmRouter) getSecrets(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
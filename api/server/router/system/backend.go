@@ -15,7 +15,8 @@ type Backend interface {
 	SystemInfo() (*types.Info, error)
 	SystemVersion() types.Version
 	SystemDiskUsage() (*types.DiskUsage, error)
-	SubscribeToEvents(since, until time.Time, ef filters.Args) ([]events.Message, chan interface{})
+	SystemFsck(repair bool) (*types.FsckReport, error)
+	ReplayEvents(since, until time.Time, ef filters.Args, fn func(events.Message) error) (chan interface{}, error)
 	UnsubscribeFromEvents(chan interface{})
 	AuthenticateToRegistry(ctx context.Context, authConfig *types.AuthConfig) (string, string, error)
 }
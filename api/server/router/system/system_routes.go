@@ -79,6 +79,19 @@ func (s *systemRouter) getDiskUsage(ctx context.Context, w http.ResponseWriter,
 	return httputils.WriteJSON(w, http.StatusOK, du)
 }
 
+func (s *systemRouter) postSystemFsck(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	report, err := s.backend.SystemFsck(httputils.BoolValue(r, "repair"))
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusOK, report)
+}
+
 func (s *systemRouter) getEvents(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
@@ -124,14 +137,13 @@ func (s *systemRouter) getEvents(ctx context.Context, w http.ResponseWriter, r *
 
 	enc := json.NewEncoder(output)
 
-	buffered, l := s.backend.SubscribeToEvents(since, until, ef)
-	defer s.backend.UnsubscribeFromEvents(l)
-
-	for _, ev := range buffered {
-		if err := enc.Encode(ev); err != nil {
-			return err
-		}
+	l, err := s.backend.ReplayEvents(since, until, ef, func(ev events.Message) error {
+		return enc.Encode(ev)
+	})
+	if err != nil {
+		return err
 	}
+	defer s.backend.UnsubscribeFromEvents(l)
 
 	if onlyPastEvents {
 		return nil
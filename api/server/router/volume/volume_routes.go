@@ -72,7 +72,7 @@ func (v *volumeRouter) postVolumesPrune(ctx context.Context, w http.ResponseWrit
 		return err
 	}
 
-	pruneReport, err := v.backend.VolumesPrune(filters.Args{})
+	pruneReport, err := v.backend.VolumesPrune(filters.Args{}, httputils.BoolValue(r, "dryrun"))
 	if err != nil {
 		return err
 	}
@@ -31,6 +31,17 @@ type Config struct {
 	TLSConfig   *tls.Config
 }
 
+// ListenerConfig provides the configuration of a listener that overrides
+// parts of the configuration of the API server.
+type ListenerConfig struct {
+	// ReadOnly rejects the requests that can modify the state of the daemon.
+	ReadOnly bool
+	// Middlewares are used for the requests received on the listener, after
+	// the server's global middlewares. They need to be set before the API
+	// routes are configured.
+	Middlewares []middleware.Middleware
+}
+
 // Server contains instance details for the server
 type Server struct {
 	cfg           *Config
@@ -56,12 +67,19 @@ func (s *Server) UseMiddleware(m middleware.Middleware) {
 
 // Accept sets a listener the server accepts connections into.
 func (s *Server) Accept(addr string, listeners ...net.Listener) {
+	s.AcceptWithConfig(addr, nil, listeners...)
+}
+
+// AcceptWithConfig sets a listener the server accepts connections into,
+// with its own configuration. A nil configuration is the same as Accept.
+func (s *Server) AcceptWithConfig(addr string, cfg *ListenerConfig, listeners ...net.Listener) {
 	for _, listener := range listeners {
 		httpServer := &HTTPServer{
 			srv: &http.Server{
 				Addr: addr,
 			},
-			l: listener,
+			l:   listener,
+			cfg: cfg,
 		}
 		s.servers = append(s.servers, httpServer)
 	}
@@ -82,6 +100,9 @@ func (s *Server) serveAPI() error {
 	var chErrors = make(chan error, len(s.servers))
 	for _, srv := range s.servers {
 		srv.srv.Handler = s.routerSwapper
+		if srv.routerSwapper != nil {
+			srv.srv.Handler = srv.routerSwapper
+		}
 		go func(srv *HTTPServer) {
 			var err error
 			logrus.Infof("API listen on %s", srv.l.Addr())
@@ -105,9 +126,12 @@ func (s *Server) serveAPI() error {
 // HTTPServer contains an instance of http server and the listener.
 // srv *http.Server, contains configuration to create an http server and a mux router with all api end points.
 // l   net.Listener, is a TCP or Socket listener that dispatches incoming request to the router.
+// cfg *ListenerConfig, is the configuration of the listener, if it has one.
 type HTTPServer struct {
-	srv *http.Server
-	l   net.Listener
+	srv           *http.Server
+	l             net.Listener
+	cfg           *ListenerConfig
+	routerSwapper *routerSwapper
 }
 
 // Serve starts listening for inbound requests.
@@ -120,7 +144,7 @@ func (s *HTTPServer) Close() error {
 	return s.l.Close()
 }
 
-func (s *Server) makeHTTPHandler(handler httputils.APIFunc) http.HandlerFunc {
+func (s *Server) makeHTTPHandler(handler httputils.APIFunc, cfg *ListenerConfig) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Define the context that we'll pass around to share info
 		// like the docker-request-id.
@@ -130,7 +154,7 @@ func (s *Server) makeHTTPHandler(handler httputils.APIFunc) http.HandlerFunc {
 		// immediate function being called should still be passed
 		// as 'args' on the function call.
 		ctx := context.WithValue(context.Background(), dockerversion.UAStringKey, r.Header.Get("User-Agent"))
-		handlerFunc := s.handlerWithGlobalMiddlewares(handler)
+		handlerFunc := s.handlerWithGlobalMiddlewares(handlerWithListenerMiddlewares(handler, cfg))
 
 		vars := mux.Vars(r)
 		if vars == nil {
@@ -149,23 +173,38 @@ func (s *Server) makeHTTPHandler(handler httputils.APIFunc) http.HandlerFunc {
 func (s *Server) InitRouter(enableProfiler bool, routers ...router.Router) {
 	s.routers = append(s.routers, routers...)
 
-	m := s.createMux()
-	if enableProfiler {
-		profilerSetup(m)
-	}
 	s.routerSwapper = &routerSwapper{
-		router: m,
+		router: s.createMux(nil, enableProfiler),
+	}
+	for _, srv := range s.servers {
+		if srv.cfg != nil {
+			srv.routerSwapper = &routerSwapper{
+				router: s.createMux(srv.cfg, enableProfiler),
+			}
+		}
+	}
+}
+
+// swapRouters reloads the routers of the server and of the listeners that
+// have their own configuration.
+func (s *Server) swapRouters(enableProfiler bool) {
+	s.routerSwapper.Swap(s.createMux(nil, enableProfiler))
+	for _, srv := range s.servers {
+		if srv.routerSwapper != nil {
+			srv.routerSwapper.Swap(s.createMux(srv.cfg, enableProfiler))
+		}
 	}
 }
 
-// createMux initializes the main router the server uses.
-func (s *Server) createMux() *mux.Router {
+// createMux initializes the router used by the listeners with the given
+// configuration, or by the ones without configuration if cfg is nil.
+func (s *Server) createMux(cfg *ListenerConfig, enableProfiler bool) *mux.Router {
 	m := mux.NewRouter()
 
 	logrus.Debug("Registering routers")
 	for _, apiRouter := range s.routers {
 		for _, r := range apiRouter.Routes() {
-			f := s.makeHTTPHandler(r.Handler())
+			f := s.makeHTTPHandler(r.Handler(), cfg)
 
 			logrus.Debugf("Registering %s, %s", r.Method(), r.Path())
 			m.Path(versionMatcher + r.Path()).Methods(r.Method()).Handler(f)
@@ -178,6 +217,9 @@ func (s *Server) createMux() *mux.Router {
 	m.HandleFunc(versionMatcher+"/{path:.*}", notFoundHandler)
 	m.NotFoundHandler = notFoundHandler
 
+	if enableProfiler {
+		profilerSetup(m)
+	}
 	return m
 }
 
@@ -195,12 +237,10 @@ func (s *Server) Wait(waitChan chan error) {
 
 // DisableProfiler reloads the server mux without adding the profiler routes.
 func (s *Server) DisableProfiler() {
-	s.routerSwapper.Swap(s.createMux())
+	s.swapRouters(false)
 }
 
 // EnableProfiler reloads the server mux adding the profiler routes.
 func (s *Server) EnableProfiler() {
-	m := s.createMux()
-	profilerSetup(m)
-	s.routerSwapper.Swap(m)
+	s.swapRouters(true)
 }
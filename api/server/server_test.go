@@ -44,3 +44,60 @@ func TestMiddlewares(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestListenerMiddlewares(t *testing.T) {
+	srv := &Server{
+		cfg: &Config{},
+	}
+
+	var called []string
+	localHandler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+		called = append(called, "handler")
+		return nil
+	}
+	listenerMiddleware := middlewareFunc(func(handler httputils.APIFunc) httputils.APIFunc {
+		return func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+			called = append(called, "listener")
+			return handler(ctx, w, r, vars)
+		}
+	})
+
+	cfg := &ListenerConfig{
+		ReadOnly:    true,
+		Middlewares: []middleware.Middleware{listenerMiddleware},
+	}
+	handlerFunc := srv.handlerWithGlobalMiddlewares(handlerWithListenerMiddlewares(localHandler, cfg))
+
+	req, _ := http.NewRequest("GET", "/containers/json", nil)
+	if err := handlerFunc(context.Background(), httptest.NewRecorder(), req, map[string]string{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(called) != 2 || called[0] != "listener" || called[1] != "handler" {
+		t.Fatalf("Expected the listener middleware to be called before the handler, got %v", called)
+	}
+
+	called = nil
+	req, _ = http.NewRequest("POST", "/containers/create", nil)
+	if err := handlerFunc(context.Background(), httptest.NewRecorder(), req, map[string]string{}); err == nil {
+		t.Fatal("Expected POST to be rejected on a read-only listener")
+	}
+	if len(called) != 0 {
+		t.Fatalf("Expected no middleware to be called for a rejected request, got %v", called)
+	}
+
+	// Without a configuration, the handler is called directly.
+	called = nil
+	handlerFunc = srv.handlerWithGlobalMiddlewares(handlerWithListenerMiddlewares(localHandler, nil))
+	if err := handlerFunc(context.Background(), httptest.NewRecorder(), req, map[string]string{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(called) != 1 {
+		t.Fatalf("Expected only the handler to be called, got %v", called)
+	}
+}
+
+type middlewareFunc func(httputils.APIFunc) httputils.APIFunc
+
+func (f middlewareFunc) WrapHandler(handler func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error) func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	return f(handler)
+}
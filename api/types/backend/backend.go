@@ -32,6 +32,18 @@ type ContainerLogsConfig struct {
 	OutStream io.Writer
 }
 
+// ContainerCopyConfig holds the configuration of a copy of a filesystem
+// resource from a container to another, for backend.ContainerCopyBetween.
+type ContainerCopyConfig struct {
+	SrcContainer         string
+	SrcPath              string
+	DstPath              string
+	FollowLink           bool
+	CopyUIDGID           bool
+	NoOverwriteDirNonDir bool
+	OutStream            io.Writer
+}
+
 // ContainerStatsConfig holds information for configuring the runtime
 // behavior of a backend.ContainerStats() call.
 type ContainerStatsConfig struct {
@@ -40,6 +52,13 @@ type ContainerStatsConfig struct {
 	Version   string
 }
 
+// ContainersWatchConfig holds information for configuring the runtime
+// behavior of a backend.ContainersWatch() call.
+type ContainersWatchConfig struct {
+	ListOptions *types.ContainerListOptions
+	OutStream   io.Writer
+}
+
 // ExecInspect holds information about a running process started
 // with docker exec.
 type ExecInspect struct {
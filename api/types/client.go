@@ -94,10 +94,43 @@ type ContainerStartOptions struct {
 	CheckpointDir string
 }
 
+// ContainerDiffOptions holds parameters to list the changes to the
+// filesystem of a container.
+type ContainerDiffOptions struct {
+	Size bool
+}
+
+// ContainerExportOptions holds parameters to export a container.
+type ContainerExportOptions struct {
+	// Full exports the configuration and the changes of the container, to
+	// import it on another host, instead of its filesystem.
+	Full bool
+	// Volumes includes the data of the volumes of the container in a full
+	// export.
+	Volumes bool
+}
+
+// ContainerImportOptions holds parameters to import a container exported
+// with ContainerExportOptions.Full.
+type ContainerImportOptions struct {
+	// Name is the name of the imported container. The name of the exported
+	// container is used if it is empty.
+	Name string
+}
+
 // CopyToContainerOptions holds information
 // about files to copy into a container
 type CopyToContainerOptions struct {
 	AllowOverwriteDirWithFile bool
+	CopyUIDGID                bool
+}
+
+// CopyBetweenContainersOptions holds information
+// about files to copy from a container to another
+type CopyBetweenContainersOptions struct {
+	AllowOverwriteDirWithFile bool
+	CopyUIDGID                bool
+	FollowLink                bool
 }
 
 // EventsOptions holds parameters to filter events with.
@@ -107,6 +140,11 @@ type EventsOptions struct {
 	Filters filters.Args
 }
 
+// FsckOptions holds parameters to check the integrity of the layers.
+type FsckOptions struct {
+	Repair bool
+}
+
 // NetworkListOptions holds parameters to filter the list of networks with.
 type NetworkListOptions struct {
 	Filters filters.Args
@@ -209,6 +247,12 @@ type ImageListOptions struct {
 	Filters filters.Args
 }
 
+// ImageLoadOptions holds parameters to load images from the client host.
+type ImageLoadOptions struct {
+	Quiet     bool
+	VerifyKey string // VerifyKey is the PEM encoded public key the archive must be signed with
+}
+
 // ImageLoadResponse returns information to the client about a load process.
 type ImageLoadResponse struct {
 	// Body must be closed to avoid a resource leak
@@ -232,12 +276,21 @@ type ImagePullOptions struct {
 type RequestPrivilegeFunc func() (string, error)
 
 //ImagePushOptions holds information to push images.
-type ImagePushOptions ImagePullOptions
+type ImagePushOptions struct {
+	All           bool
+	RegistryAuth  string // RegistryAuth is the base64 encoded credentials for the registry
+	PrivilegeFunc RequestPrivilegeFunc
+
+	// CompressionLevel is the gzip compression level of the layers. The
+	// compression level of the daemon is used if it is nil.
+	CompressionLevel *int
+}
 
 // ImageRemoveOptions holds parameters to remove images.
 type ImageRemoveOptions struct {
 	Force         bool
 	PruneChildren bool
+	DryRun        bool
 }
 
 // ImageSearchOptions holds parameters to search images with.
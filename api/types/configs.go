@@ -50,6 +50,8 @@ type ExecConfig struct {
 	Detach       bool     // Execute in detach mode
 	DetachKeys   string   // Escape keys for detach
 	Env          []string // Environment variables
+	WorkingDir   string   // Working directory of the command
+	Init         bool     // Run an init inside the exec to reap zombie processes
 	Cmd          []string // Execution commands and args
 }
 
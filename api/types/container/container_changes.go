@@ -18,4 +18,7 @@ type ContainerChangeResponseItem struct {
 	// Path to file that has changed
 	// Required: true
 	Path string `json:"Path"`
+
+	// Size of the file that was added or modified, only returned if requested with the `size` parameter
+	Size int64 `json:"Size,omitempty"`
 }
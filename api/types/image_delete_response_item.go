@@ -10,6 +10,9 @@ type ImageDeleteResponseItem struct {
 	// The image ID of an image that was deleted
 	Deleted string `json:"Deleted,omitempty"`
 
+	// The size of a layer that was deleted, in bytes
+	Size int64 `json:"Size,omitempty"`
+
 	// The image ID of an image that was untagged
 	Untagged string `json:"Untagged,omitempty"`
 }
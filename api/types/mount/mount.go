@@ -15,6 +15,8 @@ const (
 	TypeVolume Type = "volume"
 	// TypeTmpfs is the type for mounting tmpfs
 	TypeTmpfs Type = "tmpfs"
+	// TypeImage is the type for mounting the filesystem of an image read-only
+	TypeImage Type = "image"
 )
 
 // Mount represents a mount (volume).
@@ -23,6 +25,7 @@ type Mount struct {
 	// Source specifies the name of the mount. Depending on mount type, this
 	// may be a volume name or a host path, or even ignored.
 	// Source is not supported for tmpfs (must be an empty value)
+	// Source is the name or ID of the image for image mounts
 	Source   string `json:",omitempty"`
 	Target   string `json:",omitempty"`
 	ReadOnly bool   `json:",omitempty"`
@@ -30,6 +33,7 @@ type Mount struct {
 	BindOptions   *BindOptions   `json:",omitempty"`
 	VolumeOptions *VolumeOptions `json:",omitempty"`
 	TmpfsOptions  *TmpfsOptions  `json:",omitempty"`
+	ImageOptions  *ImageOptions  `json:",omitempty"`
 }
 
 // Propagation represents the propagation of a mount.
@@ -111,3 +115,11 @@ type TmpfsOptions struct {
 	// Some of these may be straightforward to add, but others, such as
 	// uid/gid have implications in a clustered system.
 }
+
+// ImageOptions defines options specific to mounts of type "image".
+type ImageOptions struct {
+	// Subpath is the path of the directory of the image to mount, relative
+	// to the root of its filesystem. The whole filesystem is mounted if it
+	// is empty.
+	Subpath string `json:",omitempty"`
+}
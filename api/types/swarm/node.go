@@ -52,6 +52,7 @@ type NodeDescription struct {
 	Platform  Platform          `json:",omitempty"`
 	Resources Resources         `json:",omitempty"`
 	Engine    EngineDescription `json:",omitempty"`
+	DiskUsage *NodeDiskUsage    `json:",omitempty"`
 }
 
 // Platform represents the platform (Arch/OS).
@@ -67,6 +68,27 @@ type EngineDescription struct {
 	Plugins       []PluginDescription `json:",omitempty"`
 }
 
+// NodeDiskUsage represents the disk usage of the images, containers and
+// local volumes of a node, as last reported by the node.
+type NodeDiskUsage struct {
+	Images     DiskUsageSummary
+	Containers DiskUsageSummary
+	Volumes    DiskUsageSummary
+}
+
+// DiskUsageSummary summarizes the disk usage of one kind of object.
+type DiskUsageSummary struct {
+	// TotalCount is the number of objects.
+	TotalCount int64
+	// ActiveCount is the number of objects in use.
+	ActiveCount int64
+	// Size is the disk space used by the objects, in bytes.
+	Size int64
+	// Reclaimable is the disk space that would be freed by removing the
+	// objects that are not in use, in bytes.
+	Reclaimable int64
+}
+
 // PluginDescription represents the description of an engine plugin.
 type PluginDescription struct {
 	Type string `json:",omitempty"`
@@ -68,6 +68,11 @@ const (
 	UpdateFailureActionPause = "pause"
 	// UpdateFailureActionContinue CONTINUE
 	UpdateFailureActionContinue = "continue"
+
+	// UpdateOrderStopFirst STOP_FIRST
+	UpdateOrderStopFirst = "stop-first"
+	// UpdateOrderStartFirst START_FIRST
+	UpdateOrderStartFirst = "start-first"
 )
 
 // UpdateConfig represents the update configuration.
@@ -102,4 +107,15 @@ type UpdateConfig struct {
 	// If the failure action is PAUSE, no more tasks will be updated until
 	// another update is started.
 	MaxFailureRatio float32
+
+	// HealthTimeout is the amount of time an updated task whose container
+	// defines a healthcheck is given to report healthy. A task that does not
+	// become healthy within HealthTimeout is stopped and counts as a failure.
+	// Zero means the task waits for its healthcheck indefinitely.
+	HealthTimeout time.Duration `json:",omitempty"`
+
+	// Order is the order of operations when rolling out an updated task.
+	// Either the old task is shut down before the new task is started, or
+	// the new task is started before the old task is shut down.
+	Order string `json:",omitempty"`
 }
@@ -82,6 +82,9 @@ type ResourceRequirements struct {
 // Placement represents orchestration parameters.
 type Placement struct {
 	Constraints []string `json:",omitempty"`
+	// PinSlots places the replacement of a task of a replicated service on
+	// the node of the task it replaces.
+	PinSlots bool `json:",omitempty"`
 }
 
 // RestartPolicy represents the restart policy.
@@ -119,6 +122,9 @@ type ContainerStatus struct {
 	ContainerID string `json:",omitempty"`
 	PID         int    `json:",omitempty"`
 	ExitCode    int    `json:",omitempty"`
+	// Health is the health status of the container when the task status
+	// was last updated, if the container has a healthcheck.
+	Health string `json:",omitempty"`
 }
 
 // PortStatus represents the port status of a task's host ports whose
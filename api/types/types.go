@@ -69,6 +69,23 @@ type Container struct {
 	Mounts          []MountPoint
 }
 
+// Types of the events of ContainerWatchEvent
+const (
+	ContainerWatchAdd    = "add"    // ContainerWatchAdd indicates that a container was added to the list
+	ContainerWatchUpdate = "update" // ContainerWatchUpdate indicates that a container of the list changed
+	ContainerWatchDelete = "delete" // ContainerWatchDelete indicates that a container was removed from the list
+	ContainerWatchSync   = "sync"   // ContainerWatchSync indicates the end of the initial list of containers
+)
+
+// ContainerWatchEvent is an event of the stream returned by Engine API:
+// GET "/containers/json?watch=1"
+// Container is the new state of the container, or its last state for
+// "delete" events. It is not set for "sync" events.
+type ContainerWatchEvent struct {
+	Type      string
+	Container *Container `json:",omitempty"`
+}
+
 // CopyConfig contains request body of Engine API:
 // POST "/containers/"+containerID+"/copy"
 type CopyConfig struct {
@@ -248,6 +265,23 @@ type ExecStartCheck struct {
 	Tty bool
 }
 
+// ExecSummary contains the response for an entry in
+// GET "/containers/{name:.*}/execs"
+type ExecSummary struct {
+	ID         string
+	Cmd        []string
+	User       string
+	WorkingDir string
+	Privileged bool
+	Tty        bool
+	Running    bool
+	ExitCode   *int
+	Pid        int
+	CreatedAt  time.Time
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
 // HealthcheckResult stores information about a single run of a healthcheck probe
 type HealthcheckResult struct {
 	Start    time.Time // Start is the time this check started
@@ -491,6 +525,35 @@ type ImagesPruneReport struct {
 	SpaceReclaimed uint64
 }
 
+// FsckCorruptLayer describes a corrupt layer in the response of Engine API:
+// POST "/system/fsck"
+type FsckCorruptLayer struct {
+	ChainID string
+	DiffID  string
+	Error   string
+	// Images are the IDs of the images that use the layer.
+	Images []string
+}
+
+// FsckReport contains the response for Engine API:
+// POST "/system/fsck"
+type FsckReport struct {
+	CorruptLayers []FsckCorruptLayer
+	// DanglingLayers are the IDs of the layers of the storage driver that
+	// are not used by any image or container.
+	DanglingLayers []string
+	// OrphanedRWLayers are the names of the RW layers that are not used by
+	// any container.
+	OrphanedRWLayers []string
+	// ImagesDeleted are the images that were removed because they use
+	// corrupt layers, when repairing.
+	ImagesDeleted []ImageDeleteResponseItem
+	// RemovedReferences are the references of the images that were removed,
+	// so that they can be pulled again.
+	RemovedReferences []string
+	Warnings          []string
+}
+
 // NetworksPruneReport contains the response for Engine API:
 // POST "/networks/prune"
 type NetworksPruneReport struct {
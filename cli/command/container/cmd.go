@@ -21,7 +21,9 @@ func NewContainerCommand(dockerCli *command.DockerCli) *cobra.Command {
 		NewCreateCommand(dockerCli),
 		NewDiffCommand(dockerCli),
 		NewExecCommand(dockerCli),
+		NewExecsCommand(dockerCli),
 		NewExportCommand(dockerCli),
+		NewImportCommand(dockerCli),
 		NewKillCommand(dockerCli),
 		NewLogsCommand(dockerCli),
 		NewPauseCommand(dockerCli),
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -12,6 +13,9 @@ import (
 	"github.com/docker/docker/cli"
 	"github.com/docker/docker/cli/command"
 	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/docker/docker/pkg/progress"
+	"github.com/docker/docker/pkg/streamformatter"
 	"github.com/docker/docker/pkg/system"
 	"github.com/spf13/cobra"
 	"golang.org/x/net/context"
@@ -21,6 +25,7 @@ type copyOptions struct {
 	source      string
 	destination string
 	followLink  bool
+	copyUIDGID  bool
 }
 
 type copyDirection int
@@ -33,6 +38,7 @@ const (
 
 type cpConfig struct {
 	followLink bool
+	copyUIDGID bool
 }
 
 // NewCopyCommand creates a new `docker cp` command
@@ -41,10 +47,12 @@ func NewCopyCommand(dockerCli *command.DockerCli) *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use: `cp [OPTIONS] CONTAINER:SRC_PATH DEST_PATH|-
-	docker cp [OPTIONS] SRC_PATH|- CONTAINER:DEST_PATH`,
+	docker cp [OPTIONS] SRC_PATH|- CONTAINER:DEST_PATH
+	docker cp [OPTIONS] CONTAINER:SRC_PATH CONTAINER:DEST_PATH`,
 		Short: "Copy files/folders between a container and the local filesystem",
 		Long: strings.Join([]string{
-			"Copy files/folders between a container and the local filesystem\n",
+			"Copy files/folders between a container and the local filesystem,\n",
+			"or between two containers\n",
 			"\nUse '-' as the source to read a tar archive from stdin\n",
 			"and extract it to a directory destination in a container.\n",
 			"Use '-' as the destination to stream a tar archive of a\n",
@@ -67,6 +75,8 @@ func NewCopyCommand(dockerCli *command.DockerCli) *cobra.Command {
 	flags := cmd.Flags()
 
 	flags.BoolVarP(&opts.followLink, "follow-link", "L", false, "Always follow symbol link in SRC_PATH")
+	flags.BoolVarP(&opts.copyUIDGID, "archive", "a", false, "Archive mode (copy all uid/gid information)")
+	flags.SetAnnotation("archive", "version", []string{"1.26"})
 
 	return cmd
 }
@@ -85,6 +95,7 @@ func runCopy(dockerCli *command.DockerCli, opts copyOptions) error {
 
 	cpParam := &cpConfig{
 		followLink: opts.followLink,
+		copyUIDGID: opts.copyUIDGID,
 	}
 
 	ctx := context.Background()
@@ -95,8 +106,7 @@ func runCopy(dockerCli *command.DockerCli, opts copyOptions) error {
 	case toContainer:
 		return copyToContainer(ctx, dockerCli, srcPath, dstContainer, dstPath, cpParam)
 	case acrossContainers:
-		// Copying between containers isn't supported.
-		return errors.New("copying between containers is not supported")
+		return copyBetweenContainers(ctx, dockerCli, srcContainer, srcPath, dstContainer, dstPath, cpParam)
 	default:
 		// User didn't specify any container.
 		return errors.New("must specify at least one container source")
@@ -165,15 +175,27 @@ func copyFromContainer(ctx context.Context, dockerCli *command.DockerCli, srcCon
 		RebaseName: rebaseName,
 	}
 
+	var size int64
+	if stat.Mode.IsRegular() {
+		size = stat.Size
+	}
+	content = newCopyProgressReader(dockerCli, content, size, "Copying from container")
+	defer content.Close()
+
 	preArchive := content
 	if len(srcInfo.RebaseName) != 0 {
 		_, srcBase := archive.SplitPathDirEntry(srcInfo.Path)
 		preArchive = archive.RebaseArchiveEntries(content, srcBase, srcInfo.RebaseName)
 	}
+
+	options := &archive.TarOptions{
+		NoLchown:             !cpParam.copyUIDGID,
+		NoOverwriteDirNonDir: true,
+	}
 	// See comments in the implementation of `archive.CopyTo` for exactly what
 	// goes into deciding how and whether the source archive needs to be
 	// altered for the correct copy behavior.
-	return archive.CopyTo(preArchive, srcInfo, dstPath)
+	return archive.CopyToWithOptions(preArchive, srcInfo, dstPath, options)
 }
 
 func copyToContainer(ctx context.Context, dockerCli *command.DockerCli, srcPath, dstContainer, dstPath string, cpParam *cpConfig) (err error) {
@@ -218,13 +240,14 @@ func copyToContainer(ctx context.Context, dockerCli *command.DockerCli, srcPath,
 	}
 
 	var (
-		content         io.Reader
+		content         io.ReadCloser
+		size            int64
 		resolvedDstPath string
 	)
 
 	if srcPath == "-" {
 		// Use STDIN.
-		content = os.Stdin
+		content = ioutil.NopCloser(os.Stdin)
 		resolvedDstPath = dstInfo.Path
 		if !dstInfo.IsDir {
 			return fmt.Errorf("destination \"%s:%s\" must be a directory", dstContainer, dstPath)
@@ -236,6 +259,10 @@ func copyToContainer(ctx context.Context, dockerCli *command.DockerCli, srcPath,
 			return err
 		}
 
+		if fi, err := os.Lstat(srcInfo.Path); err == nil && fi.Mode().IsRegular() {
+			size = fi.Size()
+		}
+
 		srcArchive, err := archive.TarResource(srcInfo)
 		if err != nil {
 			return err
@@ -264,13 +291,72 @@ func copyToContainer(ctx context.Context, dockerCli *command.DockerCli, srcPath,
 		content = preparedArchive
 	}
 
+	content = newCopyProgressReader(dockerCli, content, size, "Copying to container")
+	defer content.Close()
+
 	options := types.CopyToContainerOptions{
 		AllowOverwriteDirWithFile: false,
+		CopyUIDGID:                cpParam.copyUIDGID,
 	}
 
 	return dockerCli.Client().CopyToContainer(ctx, dstContainer, resolvedDstPath, content, options)
 }
 
+// copyBetweenContainers copies srcPath in srcContainer to dstPath in
+// dstContainer. The daemon streams the content from a container to the other,
+// and reports the progress of the copy.
+func copyBetweenContainers(ctx context.Context, dockerCli *command.DockerCli, srcContainer, srcPath, dstContainer, dstPath string, cpParam *cpConfig) error {
+	options := types.CopyBetweenContainersOptions{
+		AllowOverwriteDirWithFile: false,
+		CopyUIDGID:                cpParam.copyUIDGID,
+		FollowLink:                cpParam.followLink,
+	}
+
+	responseBody, err := dockerCli.Client().CopyBetweenContainers(ctx, srcContainer, srcPath, dstContainer, dstPath, options)
+	if err != nil {
+		return err
+	}
+	defer responseBody.Close()
+
+	out := dockerCli.Out()
+	if !out.IsTerminal() {
+		// only errors are reported if the progress is not displayed
+		return jsonmessage.DisplayJSONMessagesStream(responseBody, ioutil.Discard, 0, false, nil)
+	}
+	return jsonmessage.DisplayJSONMessagesStream(responseBody, out, out.FD(), true, nil)
+}
+
+// newCopyProgressReader wraps content to display the progress of its copy if
+// the output is a terminal. The progress is displayed against size if it is
+// known, that is if the copied resource is a regular file.
+func newCopyProgressReader(dockerCli *command.DockerCli, content io.ReadCloser, size int64, action string) io.ReadCloser {
+	if !dockerCli.Out().IsTerminal() {
+		return content
+	}
+	progressOutput := streamformatter.NewStreamFormatter().NewProgressOutput(dockerCli.Out(), false)
+	return &copyProgressReader{
+		Reader: progress.NewProgressReader(content, progressOutput, size, "", action),
+		out:    dockerCli.Out(),
+	}
+}
+
+// copyProgressReader ends the line of the progress bar when it is closed.
+type copyProgressReader struct {
+	*progress.Reader
+	out    io.Writer
+	closed bool
+}
+
+func (r *copyProgressReader) Close() error {
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	err := r.Reader.Close()
+	fmt.Fprintln(r.out)
+	return err
+}
+
 // We use `:` as a delimiter between CONTAINER and PATH, but `:` could also be
 // in a valid LOCALPATH, like `file:name.txt`. We can resolve this ambiguity by
 // requiring a LOCALPATH with a `:` to be made explicit with a relative or
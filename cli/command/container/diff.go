@@ -3,24 +3,31 @@ package container
 import (
 	"errors"
 	"fmt"
+	"io"
+	"text/tabwriter"
 
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/cli"
 	"github.com/docker/docker/cli/command"
 	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/go-units"
 	"github.com/spf13/cobra"
 	"golang.org/x/net/context"
 )
 
 type diffOptions struct {
 	container string
+	stat      bool
+	export    bool
+	output    string
 }
 
 // NewDiffCommand creates a new cobra.Command for `docker diff`
 func NewDiffCommand(dockerCli *command.DockerCli) *cobra.Command {
 	var opts diffOptions
 
-	return &cobra.Command{
-		Use:   "diff CONTAINER",
+	cmd := &cobra.Command{
+		Use:   "diff [OPTIONS] CONTAINER",
 		Short: "Inspect changes to files or directories on a container's filesystem",
 		Args:  cli.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -28,19 +35,47 @@ func NewDiffCommand(dockerCli *command.DockerCli) *cobra.Command {
 			return runDiff(dockerCli, &opts)
 		},
 	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&opts.stat, "stat", false, "Display the size of the added and changed files")
+	flags.SetAnnotation("stat", "version", []string{"1.26"})
+	flags.BoolVar(&opts.export, "export", false, "Write a tar archive of the changed files to STDOUT")
+	flags.SetAnnotation("export", "version", []string{"1.26"})
+	flags.StringVarP(&opts.output, "output", "o", "", "Write the tar archive of the changed files to a file, instead of STDOUT")
+	flags.SetAnnotation("output", "version", []string{"1.26"})
+
+	return cmd
 }
 
 func runDiff(dockerCli *command.DockerCli, opts *diffOptions) error {
 	if opts.container == "" {
 		return errors.New("Container name cannot be empty")
 	}
+	if opts.output != "" {
+		opts.export = true
+	}
+	if opts.export && opts.stat {
+		return errors.New("Conflicting options: --stat and --export")
+	}
 	ctx := context.Background()
 
-	changes, err := dockerCli.Client().ContainerDiff(ctx, opts.container)
+	if opts.export {
+		return runExportChanges(ctx, dockerCli, opts)
+	}
+
+	changes, err := dockerCli.Client().ContainerDiffWithOptions(ctx, opts.container, types.ContainerDiffOptions{Size: opts.stat})
 	if err != nil {
 		return err
 	}
 
+	var w io.Writer = dockerCli.Out()
+	if opts.stat {
+		tw := tabwriter.NewWriter(dockerCli.Out(), 0, 4, 2, ' ', 0)
+		// Ignore flushing errors
+		defer tw.Flush()
+		w = tw
+	}
+
 	for _, change := range changes {
 		var kind string
 		switch change.Kind {
@@ -51,8 +86,36 @@ func runDiff(dockerCli *command.DockerCli, opts *diffOptions) error {
 		case archive.ChangeDelete:
 			kind = "D"
 		}
-		fmt.Fprintln(dockerCli.Out(), kind, change.Path)
+		if !opts.stat {
+			fmt.Fprintln(w, kind, change.Path)
+			continue
+		}
+
+		size := "-"
+		if change.Kind != archive.ChangeDelete {
+			size = units.HumanSize(float64(change.Size))
+		}
+		fmt.Fprintf(w, "%s %s\t%s\n", kind, change.Path, size)
 	}
 
 	return nil
 }
+
+func runExportChanges(ctx context.Context, dockerCli *command.DockerCli, opts *diffOptions) error {
+	if opts.output == "" && dockerCli.Out().IsTerminal() {
+		return errors.New("Cowardly refusing to save to a terminal. Use the -o flag or redirect.")
+	}
+
+	responseBody, err := dockerCli.Client().ContainerExportChanges(ctx, opts.container)
+	if err != nil {
+		return err
+	}
+	defer responseBody.Close()
+
+	if opts.output == "" {
+		_, err := io.Copy(dockerCli.Out(), responseBody)
+		return err
+	}
+
+	return command.CopyToFile(opts.output, responseBody)
+}
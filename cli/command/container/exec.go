@@ -16,13 +16,16 @@ import (
 )
 
 type execOptions struct {
-	detachKeys  string
-	interactive bool
-	tty         bool
-	detach      bool
-	user        string
-	privileged  bool
-	env         *options.ListOpts
+	detachKeys   string
+	killOnDetach bool
+	interactive  bool
+	tty          bool
+	detach       bool
+	user         string
+	privileged   bool
+	env          *options.ListOpts
+	workdir      string
+	init         bool
 }
 
 func newExecOptions() *execOptions {
@@ -51,6 +54,8 @@ func NewExecCommand(dockerCli *command.DockerCli) *cobra.Command {
 	flags.SetInterspersed(false)
 
 	flags.StringVarP(&opts.detachKeys, "detach-keys", "", "", "Override the key sequence for detaching a container")
+	flags.BoolVarP(&opts.killOnDetach, "kill-on-detach", "", false, "Kill the command when detaching from it")
+	flags.SetAnnotation("kill-on-detach", "version", []string{"1.26"})
 	flags.BoolVarP(&opts.interactive, "interactive", "i", false, "Keep STDIN open even if not attached")
 	flags.BoolVarP(&opts.tty, "tty", "t", false, "Allocate a pseudo-TTY")
 	flags.BoolVarP(&opts.detach, "detach", "d", false, "Detached mode: run command in the background")
@@ -58,11 +63,19 @@ func NewExecCommand(dockerCli *command.DockerCli) *cobra.Command {
 	flags.BoolVarP(&opts.privileged, "privileged", "", false, "Give extended privileges to the command")
 	flags.VarP(opts.env, "env", "e", "Set environment variables")
 	flags.SetAnnotation("env", "version", []string{"1.25"})
+	flags.StringVarP(&opts.workdir, "workdir", "w", "", "Working directory inside the container")
+	flags.SetAnnotation("workdir", "version", []string{"1.26"})
+	flags.BoolVar(&opts.init, "init", false, "Run an init inside the exec to reap zombie processes")
+	flags.SetAnnotation("init", "version", []string{"1.26"})
 
 	return cmd
 }
 
 func runExec(dockerCli *command.DockerCli, opts *execOptions, container string, execCmd []string) error {
+	if opts.detach && opts.killOnDetach {
+		return fmt.Errorf("Conflicting options: --kill-on-detach and -d")
+	}
+
 	execConfig, err := parseExec(opts, execCmd)
 	// just in case the ParseExec does not exit
 	if container == "" || err != nil {
@@ -150,11 +163,18 @@ func runExec(dockerCli *command.DockerCli, opts *execOptions, container string,
 		return err
 	}
 
-	var status int
-	if _, status, err = getExecExitCode(ctx, client, execID); err != nil {
+	running, status, err := getExecExitCode(ctx, client, execID)
+	if err != nil {
 		return err
 	}
 
+	// The command is still running if the user detached from it
+	if running && opts.killOnDetach {
+		if err := client.ContainerExecKill(ctx, execID, "KILL"); err != nil {
+			return err
+		}
+	}
+
 	if status != 0 {
 		return cli.StatusError{StatusCode: status}
 	}
@@ -186,6 +206,8 @@ func parseExec(opts *execOptions, execCmd []string) (*types.ExecConfig, error) {
 		Tty:        opts.tty,
 		Cmd:        execCmd,
 		Detach:     opts.detach,
+		WorkingDir: opts.workdir,
+		Init:       opts.init,
 	}
 
 	// If -d is not set, attach to everything by default
@@ -69,6 +69,19 @@ func TestParseExec(t *testing.T) {
 			Tty:          true,
 			Cmd:          []string{"command"},
 		},
+		&arguments{
+			options: execOptions{
+				workdir: "/tmp",
+				init:    true,
+			},
+			execCmd: []string{"command"},
+		}: {
+			AttachStdout: true,
+			AttachStderr: true,
+			WorkingDir:   "/tmp",
+			Init:         true,
+			Cmd:          []string{"command"},
+		},
 	}
 
 	for valid, expectedExecConfig := range valids {
@@ -104,6 +117,12 @@ func compareExecConfig(config1 *types.ExecConfig, config2 *types.ExecConfig) boo
 	if config1.User != config2.User {
 		return false
 	}
+	if config1.WorkingDir != config2.WorkingDir {
+		return false
+	}
+	if config1.Init != config2.Init {
+		return false
+	}
 	if len(config1.Cmd) != len(config2.Cmd) {
 		return false
 	}
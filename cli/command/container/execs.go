@@ -0,0 +1,109 @@
+package container
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/docker/docker/pkg/stringutils"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+)
+
+const execsItemFmt = "%s\t%s\t%s\t%s\t%s\n"
+
+type execsOptions struct {
+	container string
+	quiet     bool
+	noTrunc   bool
+}
+
+// NewExecsCommand creates a new cobra.Command for `docker container execs`
+func NewExecsCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var opts execsOptions
+
+	cmd := &cobra.Command{
+		Use:   "execs [OPTIONS] CONTAINER",
+		Short: "List the running and recently finished exec processes of a container",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.container = args[0]
+			return runExecs(dockerCli, &opts)
+		},
+		Tags: map[string]string{"version": "1.26"},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "Only display exec IDs")
+	flags.BoolVar(&opts.noTrunc, "no-trunc", false, "Don't truncate output")
+
+	return cmd
+}
+
+func runExecs(dockerCli *command.DockerCli, opts *execsOptions) error {
+	ctx := context.Background()
+
+	execs, err := dockerCli.Client().ContainerExecList(ctx, opts.container)
+	if err != nil {
+		return err
+	}
+
+	if opts.quiet {
+		for _, e := range execs {
+			fmt.Fprintln(dockerCli.Out(), e.ID)
+		}
+		return nil
+	}
+	printExecsTable(dockerCli.Out(), execs, opts.noTrunc)
+	return nil
+}
+
+func printExecsTable(out io.Writer, execs []types.ExecSummary, noTrunc bool) {
+	writer := tabwriter.NewWriter(out, 0, 4, 3, ' ', 0)
+
+	// Ignore flushing errors
+	defer writer.Flush()
+
+	fmt.Fprintf(writer, execsItemFmt, "EXEC ID", "COMMAND", "USER", "CREATED", "STATUS")
+	for _, e := range execs {
+		id := e.ID
+		command := strconv.Quote(strings.Join(e.Cmd, " "))
+		if !noTrunc {
+			id = stringid.TruncateID(id)
+			command = strconv.Quote(stringutils.Ellipsis(strings.Join(e.Cmd, " "), 20))
+		}
+		user := e.User
+		if user == "" {
+			user = "-"
+		}
+		created := units.HumanDuration(time.Now().UTC().Sub(e.CreatedAt)) + " ago"
+		fmt.Fprintf(writer, execsItemFmt, id, command, user, created, execStatus(e))
+	}
+}
+
+// execStatus returns a human readable status of an exec, similar to the
+// status of containers in `docker ps`.
+func execStatus(e types.ExecSummary) string {
+	status := "Created"
+	switch {
+	case e.Running && e.StartedAt.IsZero():
+		status = "Starting"
+	case e.Running:
+		status = "Running for " + units.HumanDuration(time.Now().UTC().Sub(e.StartedAt))
+	case e.ExitCode != nil:
+		status = fmt.Sprintf("Exited (%d) %s ago", *e.ExitCode, units.HumanDuration(time.Now().UTC().Sub(e.FinishedAt)))
+	}
+	if e.Privileged {
+		status += " (privileged)"
+	}
+	return status
+}
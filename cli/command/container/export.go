@@ -4,6 +4,7 @@ import (
 	"errors"
 	"io"
 
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/cli"
 	"github.com/docker/docker/cli/command"
 	"github.com/spf13/cobra"
@@ -13,6 +14,8 @@ import (
 type exportOptions struct {
 	container string
 	output    string
+	full      bool
+	volumes   bool
 }
 
 // NewExportCommand creates a new `docker export` command
@@ -32,6 +35,10 @@ func NewExportCommand(dockerCli *command.DockerCli) *cobra.Command {
 	flags := cmd.Flags()
 
 	flags.StringVarP(&opts.output, "output", "o", "", "Write to a file, instead of STDOUT")
+	flags.BoolVar(&opts.full, "full", false, "Export the configuration and the changes of the container, to import it with 'docker container import'")
+	flags.SetAnnotation("full", "version", []string{"1.26"})
+	flags.BoolVar(&opts.volumes, "volumes", false, "Include the data of the volumes of the container in a full export")
+	flags.SetAnnotation("volumes", "version", []string{"1.26"})
 
 	return cmd
 }
@@ -41,9 +48,17 @@ func runExport(dockerCli *command.DockerCli, opts exportOptions) error {
 		return errors.New("Cowardly refusing to save to a terminal. Use the -o flag or redirect.")
 	}
 
+	if opts.volumes && !opts.full {
+		return errors.New("--volumes requires --full")
+	}
+
 	clnt := dockerCli.Client()
 
-	responseBody, err := clnt.ContainerExport(context.Background(), opts.container)
+	options := types.ContainerExportOptions{
+		Full:    opts.full,
+		Volumes: opts.volumes,
+	}
+	responseBody, err := clnt.ContainerExport(context.Background(), opts.container, options)
 	if err != nil {
 		return err
 	}
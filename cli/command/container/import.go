@@ -0,0 +1,68 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/pkg/system"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+type importOptions struct {
+	input string
+	name  string
+}
+
+// NewImportCommand creates a new `docker container import` command
+func NewImportCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var opts importOptions
+
+	cmd := &cobra.Command{
+		Use:   "import [OPTIONS]",
+		Short: "Import a container from a tar archive created with 'docker container export --full'",
+		Args:  cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runImport(dockerCli, opts)
+		},
+		Tags: map[string]string{"version": "1.26"},
+	}
+
+	flags := cmd.Flags()
+
+	flags.StringVarP(&opts.input, "input", "i", "", "Read from tar archive file, instead of STDIN")
+	flags.StringVar(&opts.name, "name", "", "Assign a name to the container, instead of the name of the exported container")
+
+	return cmd
+}
+
+func runImport(dockerCli *command.DockerCli, opts importOptions) error {
+	var input io.Reader = dockerCli.In()
+	if opts.input != "" {
+		file, err := system.OpenSequential(opts.input)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+
+	if opts.input == "" && dockerCli.In().IsTerminal() {
+		return errors.New("requested import from stdin, but stdin is empty")
+	}
+
+	response, err := dockerCli.Client().ContainerImport(context.Background(), input, types.ContainerImportOptions{Name: opts.name})
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range response.Warnings {
+		fmt.Fprintf(dockerCli.Err(), "WARNING: %s\n", warning)
+	}
+	fmt.Fprintln(dockerCli.Out(), response.ID)
+	return nil
+}
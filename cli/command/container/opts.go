@@ -30,6 +30,7 @@ var (
 type containerOptions struct {
 	attach             opts.ListOpts
 	volumes            opts.ListOpts
+	mounts             opts.MountOpt
 	tmpfs              opts.ListOpts
 	blkioWeightDevice  opts.WeightdeviceOpt
 	deviceReadBps      opts.ThrottledeviceOpt
@@ -225,6 +226,8 @@ func addFlags(flags *pflag.FlagSet) *containerOptions {
 	flags.Var(&copts.tmpfs, "tmpfs", "Mount a tmpfs directory")
 	flags.Var(&copts.volumesFrom, "volumes-from", "Mount volumes from the specified container(s)")
 	flags.VarP(&copts.volumes, "volume", "v", "Bind mount a volume")
+	flags.Var(&copts.mounts, "mount", "Attach a filesystem mount to the container")
+	flags.SetAnnotation("mount", "version", []string{"1.26"})
 
 	// Health-checking
 	flags.StringVar(&copts.healthCmd, "health-cmd", "", "Command to run to check health")
@@ -592,6 +595,7 @@ func parse(flags *pflag.FlagSet, copts *containerOptions) (*container.Config, *c
 
 	hostConfig := &container.HostConfig{
 		Binds:           binds,
+		Mounts:          copts.mounts.Value(),
 		ContainerIDFile: copts.containerIDFile,
 		OomScoreAdj:     copts.oomScoreAdj,
 		AutoRemove:      copts.autoRemove,
@@ -13,6 +13,7 @@ import (
 
 type pruneOptions struct {
 	force  bool
+	dryRun bool
 	filter opts.FilterOpt
 }
 
@@ -32,6 +33,10 @@ func NewPruneCommand(dockerCli *command.DockerCli) *cobra.Command {
 			if output != "" {
 				fmt.Fprintln(dockerCli.Out(), output)
 			}
+			if opts.dryRun {
+				fmt.Fprintln(dockerCli.Out(), "Total reclaimable space:", units.HumanSize(float64(spaceReclaimed)))
+				return nil
+			}
 			fmt.Fprintln(dockerCli.Out(), "Total reclaimed space:", units.HumanSize(float64(spaceReclaimed)))
 			return nil
 		},
@@ -40,6 +45,8 @@ func NewPruneCommand(dockerCli *command.DockerCli) *cobra.Command {
 
 	flags := cmd.Flags()
 	flags.BoolVarP(&opts.force, "force", "f", false, "Do not prompt for confirmation")
+	flags.BoolVar(&opts.dryRun, "dry-run", false, "Only show what would be removed and the space it would free")
+	flags.SetAnnotation("dry-run", "version", []string{"1.26"})
 	flags.Var(&opts.filter, "filter", "Provide filter values (e.g. 'until=<timestamp>')")
 
 	return cmd
@@ -51,17 +58,20 @@ Are you sure you want to continue?`
 func runPrune(dockerCli *command.DockerCli, opts pruneOptions) (spaceReclaimed uint64, output string, err error) {
 	pruneFilters := opts.filter.Value()
 
-	if !opts.force && !command.PromptForConfirmation(dockerCli.In(), dockerCli.Out(), warning) {
+	if !opts.force && !opts.dryRun && !command.PromptForConfirmation(dockerCli.In(), dockerCli.Out(), warning) {
 		return
 	}
 
-	report, err := dockerCli.Client().ContainersPrune(context.Background(), pruneFilters)
+	report, err := dockerCli.Client().ContainersPrune(context.Background(), pruneFilters, opts.dryRun)
 	if err != nil {
 		return
 	}
 
 	if len(report.ContainersDeleted) > 0 {
 		output = "Deleted Containers:\n"
+		if opts.dryRun {
+			output = "Containers that would be deleted:\n"
+		}
 		for _, id := range report.ContainersDeleted {
 			output += id + "\n"
 		}
@@ -42,6 +42,9 @@ Placement:
 {{- if .TaskPlacementConstraints -}}
  Contraints:	{{ .TaskPlacementConstraints }}
 {{- end }}
+{{- if .TaskPlacementPinSlots }}
+ Pin slots:	true
+{{- end }}
 {{- if .HasUpdateConfig }}
 UpdateConfig:
  Parallelism:	{{ .UpdateParallelism }}
@@ -53,6 +56,12 @@ UpdateConfig:
  Monitoring Period: {{ .UpdateMonitor }}
 {{- end }}
  Max failure ratio: {{ .UpdateMaxFailureRatio }}
+{{- if .UpdateOrder}}
+ Update order:      {{ .UpdateOrder }}
+{{- end }}
+{{- if .HasUpdateHealthTimeout}}
+ Health timeout: {{ .UpdateHealthTimeout }}
+{{- end }}
 {{- end }}
 ContainerSpec:
  Image:		{{ .ContainerImage }}
@@ -211,6 +220,13 @@ func (ctx *serviceInspectContext) TaskPlacementConstraints() []string {
 	return nil
 }
 
+func (ctx *serviceInspectContext) TaskPlacementPinSlots() bool {
+	if ctx.Service.Spec.TaskTemplate.Placement != nil {
+		return ctx.Service.Spec.TaskTemplate.Placement.PinSlots
+	}
+	return false
+}
+
 func (ctx *serviceInspectContext) HasUpdateConfig() bool {
 	return ctx.Service.Spec.UpdateConfig != nil
 }
@@ -243,6 +259,18 @@ func (ctx *serviceInspectContext) UpdateMaxFailureRatio() float32 {
 	return ctx.Service.Spec.UpdateConfig.MaxFailureRatio
 }
 
+func (ctx *serviceInspectContext) UpdateOrder() string {
+	return ctx.Service.Spec.UpdateConfig.Order
+}
+
+func (ctx *serviceInspectContext) HasUpdateHealthTimeout() bool {
+	return ctx.Service.Spec.UpdateConfig.HealthTimeout.Nanoseconds() > 0
+}
+
+func (ctx *serviceInspectContext) UpdateHealthTimeout() time.Duration {
+	return ctx.Service.Spec.UpdateConfig.HealthTimeout
+}
+
 func (ctx *serviceInspectContext) ContainerImage() string {
 	return ctx.Service.Spec.TaskTemplate.ContainerSpec.Image
 }
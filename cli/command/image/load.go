@@ -1,21 +1,25 @@
 package image
 
 import (
+	"encoding/pem"
 	"fmt"
 	"io"
 
 	"golang.org/x/net/context"
 
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/cli"
 	"github.com/docker/docker/cli/command"
 	"github.com/docker/docker/pkg/jsonmessage"
 	"github.com/docker/docker/pkg/system"
+	"github.com/docker/libtrust"
 	"github.com/spf13/cobra"
 )
 
 type loadOptions struct {
-	input string
-	quiet bool
+	input     string
+	quiet     bool
+	verifyKey string
 }
 
 // NewLoadCommand creates a new `docker load` command
@@ -35,11 +39,25 @@ func NewLoadCommand(dockerCli *command.DockerCli) *cobra.Command {
 
 	flags.StringVarP(&opts.input, "input", "i", "", "Read from tar archive file, instead of STDIN")
 	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "Suppress the load output")
+	flags.StringVar(&opts.verifyKey, "verify-key", "", "Only load the archive if it is signed with the public key in this file")
+	flags.SetAnnotation("verify-key", "version", []string{"1.26"})
 
 	return cmd
 }
 
 func runLoad(dockerCli *command.DockerCli, opts loadOptions) error {
+	var verifyKey string
+	if opts.verifyKey != "" {
+		key, err := libtrust.LoadPublicKeyFile(opts.verifyKey)
+		if err != nil {
+			return fmt.Errorf("failed to load verification key %s: %v", opts.verifyKey, err)
+		}
+		block, err := key.PEMBlock()
+		if err != nil {
+			return err
+		}
+		verifyKey = string(pem.EncodeToMemory(block))
+	}
 
 	var input io.Reader = dockerCli.In()
 	if opts.input != "" {
@@ -62,7 +80,10 @@ func runLoad(dockerCli *command.DockerCli, opts loadOptions) error {
 	if !dockerCli.Out().IsTerminal() {
 		opts.quiet = true
 	}
-	response, err := dockerCli.Client().ImageLoad(context.Background(), input, opts.quiet)
+	response, err := dockerCli.Client().ImageLoad(context.Background(), input, types.ImageLoadOptions{
+		Quiet:     opts.quiet,
+		VerifyKey: verifyKey,
+	})
 	if err != nil {
 		return err
 	}
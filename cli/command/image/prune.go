@@ -15,6 +15,7 @@ import (
 type pruneOptions struct {
 	force  bool
 	all    bool
+	dryRun bool
 	filter opts.FilterOpt
 }
 
@@ -34,6 +35,10 @@ func NewPruneCommand(dockerCli *command.DockerCli) *cobra.Command {
 			if output != "" {
 				fmt.Fprintln(dockerCli.Out(), output)
 			}
+			if opts.dryRun {
+				fmt.Fprintln(dockerCli.Out(), "Total reclaimable space:", units.HumanSize(float64(spaceReclaimed)))
+				return nil
+			}
 			fmt.Fprintln(dockerCli.Out(), "Total reclaimed space:", units.HumanSize(float64(spaceReclaimed)))
 			return nil
 		},
@@ -42,6 +47,8 @@ func NewPruneCommand(dockerCli *command.DockerCli) *cobra.Command {
 
 	flags := cmd.Flags()
 	flags.BoolVarP(&opts.force, "force", "f", false, "Do not prompt for confirmation")
+	flags.BoolVar(&opts.dryRun, "dry-run", false, "Only show what would be removed and the space it would free")
+	flags.SetAnnotation("dry-run", "version", []string{"1.26"})
 	flags.BoolVarP(&opts.all, "all", "a", false, "Remove all unused images, not just dangling ones")
 	flags.Var(&opts.filter, "filter", "Provide filter values (e.g. 'until=<timestamp>')")
 
@@ -63,22 +70,27 @@ func runPrune(dockerCli *command.DockerCli, opts pruneOptions) (spaceReclaimed u
 	if opts.all {
 		warning = allImageWarning
 	}
-	if !opts.force && !command.PromptForConfirmation(dockerCli.In(), dockerCli.Out(), warning) {
+	if !opts.force && !opts.dryRun && !command.PromptForConfirmation(dockerCli.In(), dockerCli.Out(), warning) {
 		return
 	}
 
-	report, err := dockerCli.Client().ImagesPrune(context.Background(), pruneFilters)
+	report, err := dockerCli.Client().ImagesPrune(context.Background(), pruneFilters, opts.dryRun)
 	if err != nil {
 		return
 	}
 
 	if len(report.ImagesDeleted) > 0 {
+		untagged, deleted := "untagged:", "deleted:"
 		output = "Deleted Images:\n"
+		if opts.dryRun {
+			untagged, deleted = "untag:", "delete:"
+			output = "Images that would be deleted:\n"
+		}
 		for _, st := range report.ImagesDeleted {
 			if st.Untagged != "" {
-				output += fmt.Sprintln("untagged:", st.Untagged)
+				output += fmt.Sprintln(untagged, st.Untagged)
 			} else {
-				output += fmt.Sprintln("deleted:", st.Deleted)
+				output += fmt.Sprintln(deleted, st.Deleted)
 			}
 		}
 		spaceReclaimed = report.SpaceReclaimed
@@ -11,26 +11,43 @@ import (
 	"github.com/spf13/cobra"
 )
 
+type pushOptions struct {
+	remote           string
+	compressionLevel *int
+}
+
 // NewPushCommand creates a new `docker push` command
 func NewPushCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var (
+		opts             pushOptions
+		compressionLevel int
+	)
+
 	cmd := &cobra.Command{
 		Use:   "push [OPTIONS] NAME[:TAG]",
 		Short: "Push an image or a repository to a registry",
 		Args:  cli.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runPush(dockerCli, args[0])
+			opts.remote = args[0]
+			if cmd.Flags().Changed("compression-level") {
+				opts.compressionLevel = &compressionLevel
+			}
+			return runPush(dockerCli, opts)
 		},
 	}
 
 	flags := cmd.Flags()
 
+	flags.IntVar(&compressionLevel, "compression-level", 0, "Gzip compression level of the layers, from 0 (no compression) to 9 (best compression)")
+	flags.SetAnnotation("compression-level", "version", []string{"1.26"})
+
 	command.AddTrustSigningFlags(flags)
 
 	return cmd
 }
 
-func runPush(dockerCli *command.DockerCli, remote string) error {
-	ref, err := reference.ParseNormalizedNamed(remote)
+func runPush(dockerCli *command.DockerCli, opts pushOptions) error {
+	ref, err := reference.ParseNormalizedNamed(opts.remote)
 	if err != nil {
 		return err
 	}
@@ -48,10 +65,10 @@ func runPush(dockerCli *command.DockerCli, remote string) error {
 	requestPrivilege := command.RegistryAuthenticationPrivilegedFunc(dockerCli, repoInfo.Index, "push")
 
 	if command.IsTrusted() {
-		return trustedPush(ctx, dockerCli, repoInfo, ref, authConfig, requestPrivilege)
+		return trustedPush(ctx, dockerCli, repoInfo, ref, authConfig, opts.compressionLevel, requestPrivilege)
 	}
 
-	responseBody, err := imagePushPrivileged(ctx, dockerCli, authConfig, ref, requestPrivilege)
+	responseBody, err := imagePushPrivileged(ctx, dockerCli, authConfig, ref, opts.compressionLevel, requestPrivilege)
 	if err != nil {
 		return err
 	}
@@ -9,12 +9,14 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/cli"
 	"github.com/docker/docker/cli/command"
+	units "github.com/docker/go-units"
 	"github.com/spf13/cobra"
 )
 
 type removeOptions struct {
 	force   bool
 	noPrune bool
+	dryRun  bool
 }
 
 // NewRemoveCommand creates a new `docker remove` command
@@ -34,6 +36,8 @@ func NewRemoveCommand(dockerCli *command.DockerCli) *cobra.Command {
 
 	flags.BoolVarP(&opts.force, "force", "f", false, "Force removal of the image")
 	flags.BoolVar(&opts.noPrune, "no-prune", false, "Do not delete untagged parents")
+	flags.BoolVar(&opts.dryRun, "dry-run", false, "Only show what would be removed and the space it would free")
+	flags.SetAnnotation("dry-run", "version", []string{"1.26"})
 
 	return cmd
 }
@@ -52,9 +56,16 @@ func runRemove(dockerCli *command.DockerCli, opts removeOptions, images []string
 	options := types.ImageRemoveOptions{
 		Force:         opts.force,
 		PruneChildren: !opts.noPrune,
+		DryRun:        opts.dryRun,
+	}
+
+	untagged, deleted := "Untagged", "Deleted"
+	if opts.dryRun {
+		untagged, deleted = "Would untag", "Would delete"
 	}
 
 	var errs []string
+	var spaceReclaimed int64
 	for _, image := range images {
 		dels, err := client.ImageRemove(ctx, image, options)
 		if err != nil {
@@ -62,14 +73,19 @@ func runRemove(dockerCli *command.DockerCli, opts removeOptions, images []string
 		} else {
 			for _, del := range dels {
 				if del.Deleted != "" {
-					fmt.Fprintf(dockerCli.Out(), "Deleted: %s\n", del.Deleted)
+					fmt.Fprintf(dockerCli.Out(), "%s: %s\n", deleted, del.Deleted)
 				} else {
-					fmt.Fprintf(dockerCli.Out(), "Untagged: %s\n", del.Untagged)
+					fmt.Fprintf(dockerCli.Out(), "%s: %s\n", untagged, del.Untagged)
 				}
+				spaceReclaimed += del.Size
 			}
 		}
 	}
 
+	if opts.dryRun {
+		fmt.Fprintln(dockerCli.Out(), "Total reclaimable space:", units.HumanSize(float64(spaceReclaimed)))
+	}
+
 	if len(errs) > 0 {
 		return fmt.Errorf("%s", strings.Join(errs, "\n"))
 	}
@@ -2,18 +2,22 @@ package image
 
 import (
 	"errors"
+	"fmt"
 	"io"
 
 	"golang.org/x/net/context"
 
 	"github.com/docker/docker/cli"
 	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/image/tarexport/signature"
+	"github.com/docker/libtrust"
 	"github.com/spf13/cobra"
 )
 
 type saveOptions struct {
-	images []string
-	output string
+	images  []string
+	output  string
+	signKey string
 }
 
 // NewSaveCommand creates a new `docker save` command
@@ -33,6 +37,7 @@ func NewSaveCommand(dockerCli *command.DockerCli) *cobra.Command {
 	flags := cmd.Flags()
 
 	flags.StringVarP(&opts.output, "output", "o", "", "Write to a file, instead of STDOUT")
+	flags.StringVar(&opts.signKey, "sign-key", "", "Sign the archive with the private key in this file")
 
 	return cmd
 }
@@ -42,16 +47,34 @@ func runSave(dockerCli *command.DockerCli, opts saveOptions) error {
 		return errors.New("Cowardly refusing to save to a terminal. Use the -o flag or redirect.")
 	}
 
+	var signKey libtrust.PrivateKey
+	if opts.signKey != "" {
+		var err error
+		if signKey, err = libtrust.LoadKeyFile(opts.signKey); err != nil {
+			return fmt.Errorf("failed to load signing key %s: %v", opts.signKey, err)
+		}
+	}
+
 	responseBody, err := dockerCli.Client().ImageSave(context.Background(), opts.images)
 	if err != nil {
 		return err
 	}
 	defer responseBody.Close()
 
+	var archive io.Reader = responseBody
+	if signKey != nil {
+		pr, pw := io.Pipe()
+		defer pr.Close()
+		go func() {
+			pw.CloseWithError(signature.Sign(pw, responseBody, signKey))
+		}()
+		archive = pr
+	}
+
 	if opts.output == "" {
-		_, err := io.Copy(dockerCli.Out(), responseBody)
+		_, err := io.Copy(dockerCli.Out(), archive)
 		return err
 	}
 
-	return command.CopyToFile(opts.output, responseBody)
+	return command.CopyToFile(opts.output, archive)
 }
@@ -18,6 +18,7 @@ func NewNodeCommand(dockerCli *command.DockerCli) *cobra.Command {
 	}
 	cmd.AddCommand(
 		newDemoteCommand(dockerCli),
+		newDiskUsageCommand(dockerCli),
 		newInspectCommand(dockerCli),
 		newListCommand(dockerCli),
 		newPromoteCommand(dockerCli),
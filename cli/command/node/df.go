@@ -0,0 +1,86 @@
+package node
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/opts"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+)
+
+const (
+	dfItemFmt = "%s\t%s\t%s\t%s\t%s\t%s\n"
+)
+
+type dfOptions struct {
+	filter opts.FilterOpt
+}
+
+func newDiskUsageCommand(dockerCli command.Cli) *cobra.Command {
+	opts := dfOptions{filter: opts.NewFilterOpt()}
+
+	cmd := &cobra.Command{
+		Use:   "df [OPTIONS]",
+		Short: "Show disk usage of the nodes in the swarm",
+		Args:  cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiskUsage(dockerCli, opts)
+		},
+		Tags: map[string]string{"version": "1.26"},
+	}
+	flags := cmd.Flags()
+	flags.VarP(&opts.filter, "filter", "f", "Filter output based on conditions provided")
+
+	return cmd
+}
+
+func runDiskUsage(dockerCli command.Cli, opts dfOptions) error {
+	client := dockerCli.Client()
+	ctx := context.Background()
+
+	nodes, err := client.NodeList(
+		ctx,
+		types.NodeListOptions{Filters: opts.filter.Value()})
+	if err != nil {
+		return err
+	}
+
+	printDiskUsageTable(dockerCli.Out(), nodes)
+	return nil
+}
+
+func printDiskUsageTable(out io.Writer, nodes []swarm.Node) {
+	writer := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+
+	// Ignore flushing errors
+	defer writer.Flush()
+
+	fmt.Fprintf(writer, dfItemFmt, "ID", "HOSTNAME", "IMAGES", "CONTAINERS", "LOCAL VOLUMES", "RECLAIMABLE")
+	for _, node := range nodes {
+		usage := node.Description.DiskUsage
+		if usage == nil {
+			// the node did not report its disk usage (yet)
+			fmt.Fprintf(writer, dfItemFmt, node.ID, node.Description.Hostname, "-", "-", "-", "-")
+			continue
+		}
+
+		reclaimable := usage.Images.Reclaimable + usage.Containers.Reclaimable + usage.Volumes.Reclaimable
+		fmt.Fprintf(
+			writer,
+			dfItemFmt,
+			node.ID,
+			node.Description.Hostname,
+			units.HumanSize(float64(usage.Images.Size)),
+			units.HumanSize(float64(usage.Containers.Size)),
+			units.HumanSize(float64(usage.Volumes.Size)),
+			units.HumanSize(float64(reclaimable)))
+	}
+}
@@ -0,0 +1,48 @@
+package node
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/docker/docker/cli/internal/test"
+	// Import builders to get the builder function as package function
+	. "github.com/docker/docker/cli/internal/test/builders"
+	"github.com/docker/docker/pkg/testutil/assert"
+)
+
+func TestNodeDiskUsageErrorOnAPIFailure(t *testing.T) {
+	buf := new(bytes.Buffer)
+	cmd := newDiskUsageCommand(
+		test.NewFakeCli(&fakeClient{
+			nodeListFunc: func() ([]swarm.Node, error) {
+				return []swarm.Node{}, fmt.Errorf("error listing nodes")
+			},
+		}, buf))
+	cmd.SetOutput(ioutil.Discard)
+	assert.Error(t, cmd.Execute(), "error listing nodes")
+}
+
+func TestNodeDiskUsage(t *testing.T) {
+	buf := new(bytes.Buffer)
+	cmd := newDiskUsageCommand(
+		test.NewFakeCli(&fakeClient{
+			nodeListFunc: func() ([]swarm.Node, error) {
+				withUsage := Node(NodeID("nodeID1"), Hostname("nodeHostname1"))
+				withUsage.Description.DiskUsage = &swarm.NodeDiskUsage{
+					Images:     swarm.DiskUsageSummary{TotalCount: 2, ActiveCount: 1, Size: 2000, Reclaimable: 1000},
+					Containers: swarm.DiskUsageSummary{TotalCount: 1, ActiveCount: 1, Size: 100},
+					Volumes:    swarm.DiskUsageSummary{TotalCount: 1, Size: 10, Reclaimable: 10},
+				}
+				return []swarm.Node{
+					*withUsage,
+					*Node(NodeID("nodeID2"), Hostname("nodeHostname2")),
+				}, nil
+			},
+		}, buf))
+	assert.NilError(t, cmd.Execute())
+	assert.Contains(t, buf.String(), `nodeID1  nodeHostname1  2 kB    100 B       10 B           1.01 kB`)
+	assert.Contains(t, buf.String(), `nodeID2  nodeHostname2  -       -           -              -`)
+}
@@ -116,6 +116,13 @@ func printNode(out io.Writer, node swarm.Node) {
 	fmt.Fprintf(out, " CPUs:\t\t\t%d\n", node.Description.Resources.NanoCPUs/1e9)
 	fmt.Fprintf(out, " Memory:\t\t%s\n", units.BytesSize(float64(node.Description.Resources.MemoryBytes)))
 
+	if usage := node.Description.DiskUsage; usage != nil {
+		fmt.Fprintln(out, "Disk Usage:")
+		fmt.Fprintf(out, " Images:\t\t%s (%s reclaimable)\n", units.HumanSize(float64(usage.Images.Size)), units.HumanSize(float64(usage.Images.Reclaimable)))
+		fmt.Fprintf(out, " Containers:\t\t%s (%s reclaimable)\n", units.HumanSize(float64(usage.Containers.Size)), units.HumanSize(float64(usage.Containers.Reclaimable)))
+		fmt.Fprintf(out, " Local Volumes:\t\t%s (%s reclaimable)\n", units.HumanSize(float64(usage.Volumes.Size)), units.HumanSize(float64(usage.Volumes.Reclaimable)))
+	}
+
 	var pluginTypes []string
 	pluginNamesByType := map[string][]string{}
 	for _, p := range node.Description.Engine.Plugins {
@@ -13,30 +13,35 @@ import (
 	"github.com/docker/docker/cli"
 	"github.com/docker/docker/cli/command"
 	"github.com/docker/docker/cli/command/idresolver"
+	"github.com/docker/docker/client"
 	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/docker/pkg/stringid"
 	"github.com/spf13/cobra"
 )
 
 type logsOptions struct {
 	noResolve  bool
+	noTrunc    bool
+	noTaskIDs  bool
+	raw        bool
 	follow     bool
 	since      string
 	timestamps bool
 	details    bool
 	tail       string
 
-	service string
+	target string
 }
 
 func newLogsCommand(dockerCli *command.DockerCli) *cobra.Command {
 	var opts logsOptions
 
 	cmd := &cobra.Command{
-		Use:   "logs [OPTIONS] SERVICE",
-		Short: "Fetch the logs of a service",
+		Use:   "logs [OPTIONS] SERVICE|TASK",
+		Short: "Fetch the logs of a service or task",
 		Args:  cli.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			opts.service = args[0]
+			opts.target = args[0]
 			return runLogs(dockerCli, &opts)
 		},
 		Tags: map[string]string{"experimental": ""},
@@ -44,6 +49,9 @@ func newLogsCommand(dockerCli *command.DockerCli) *cobra.Command {
 
 	flags := cmd.Flags()
 	flags.BoolVar(&opts.noResolve, "no-resolve", false, "Do not map IDs to Names")
+	flags.BoolVar(&opts.noTrunc, "no-trunc", false, "Do not truncate output")
+	flags.BoolVar(&opts.noTaskIDs, "no-task-ids", false, "Do not include task IDs in output")
+	flags.BoolVar(&opts.raw, "raw", false, "Do not prefix log lines with the task and node")
 	flags.BoolVarP(&opts.follow, "follow", "f", false, "Follow log output")
 	flags.StringVar(&opts.since, "since", "", "Show logs since timestamp")
 	flags.BoolVarP(&opts.timestamps, "timestamps", "t", false, "Show timestamps")
@@ -65,27 +73,103 @@ func runLogs(dockerCli *command.DockerCli, opts *logsOptions) error {
 		Details:    opts.details,
 	}
 
-	client := dockerCli.Client()
-	responseBody, err := client.ServiceLogs(ctx, opts.service, options)
-	if err != nil {
-		return err
+	cli := dockerCli.Client()
+
+	var responseBody io.ReadCloser
+	if _, _, err := cli.ServiceInspectWithRaw(ctx, opts.target); err != nil {
+		if !client.IsErrServiceNotFound(err) {
+			return err
+		}
+		// Not a service, so try to fetch the logs of a single task.
+		responseBody, err = cli.TaskLogs(ctx, opts.target, options)
+		if err != nil {
+			if client.IsErrTaskNotFound(err) {
+				return fmt.Errorf("Error: No such service or task: %s", opts.target)
+			}
+			return err
+		}
+	} else {
+		responseBody, err = cli.ServiceLogs(ctx, opts.target, options)
+		if err != nil {
+			return err
+		}
 	}
 	defer responseBody.Close()
 
-	resolver := idresolver.New(client, opts.noResolve)
+	formatter := &taskFormatter{
+		client: cli,
+		opts:   opts,
+		r:      idresolver.New(cli, opts.noResolve),
+		cache:  make(map[logContext]string),
+	}
 
-	stdout := &logWriter{ctx: ctx, opts: opts, r: resolver, w: dockerCli.Out()}
-	stderr := &logWriter{ctx: ctx, opts: opts, r: resolver, w: dockerCli.Err()}
+	stdout := &logWriter{ctx: ctx, opts: opts, f: formatter, w: dockerCli.Out()}
+	stderr := &logWriter{ctx: ctx, opts: opts, f: formatter, w: dockerCli.Err()}
 
 	// TODO(aluzzardi): Do an io.Copy for services with TTY enabled.
-	_, err = stdcopy.StdCopy(stdout, stderr, responseBody)
+	_, err := stdcopy.StdCopy(stdout, stderr, responseBody)
 	return err
 }
 
+// logContext identifies the task a log line was emitted by.
+type logContext struct {
+	nodeID    string
+	serviceID string
+	taskID    string
+}
+
+// taskFormatter builds the `name.slot.id@node` prefix of the log lines, and
+// caches it per task.
+type taskFormatter struct {
+	client client.APIClient
+	opts   *logsOptions
+
+	r     *idresolver.IDResolver
+	cache map[logContext]string
+}
+
+func (f *taskFormatter) format(ctx context.Context, logCtx logContext) (string, error) {
+	if cached, ok := f.cache[logCtx]; ok {
+		return cached, nil
+	}
+
+	nodeName, err := f.r.Resolve(ctx, swarm.Node{}, logCtx.nodeID)
+	if err != nil {
+		return "", err
+	}
+
+	serviceName, err := f.r.Resolve(ctx, swarm.Service{}, logCtx.serviceID)
+	if err != nil {
+		return "", err
+	}
+
+	task, _, err := f.client.TaskInspectWithRaw(ctx, logCtx.taskID)
+	if err != nil {
+		return "", err
+	}
+
+	taskName := fmt.Sprintf("%s.%d", serviceName, task.Slot)
+	if task.Slot == 0 {
+		// Tasks of global services have no slot, use the node instead.
+		taskName = fmt.Sprintf("%s.%s", serviceName, task.NodeID)
+	}
+	if !f.opts.noTaskIDs {
+		if f.opts.noTrunc {
+			taskName += fmt.Sprintf(".%s", task.ID)
+		} else {
+			taskName += fmt.Sprintf(".%s", stringid.TruncateID(task.ID))
+		}
+	}
+
+	formatted := fmt.Sprintf("%s@%s", taskName, nodeName)
+	f.cache[logCtx] = formatted
+	return formatted, nil
+}
+
 type logWriter struct {
 	ctx  context.Context
 	opts *logsOptions
-	r    *idresolver.IDResolver
+	f    *taskFormatter
 	w    io.Writer
 }
 
@@ -102,62 +186,81 @@ func (lw *logWriter) Write(buf []byte) (int, error) {
 		return 0, fmt.Errorf("invalid context in log message: %v", string(buf))
 	}
 
-	taskName, nodeName, err := lw.parseContext(string(parts[contextIndex]))
-	if err != nil {
-		return 0, err
-	}
-
 	output := []byte{}
-	for i, part := range parts {
-		// First part doesn't get space separation.
-		if i > 0 {
-			output = append(output, []byte(" ")...)
+	if lw.opts.raw {
+		// Drop the context, but keep the timestamp if there is one.
+		for i, part := range parts {
+			if i == contextIndex {
+				continue
+			}
+			output = append(output, part...)
+			if i < len(parts)-1 {
+				output = append(output, []byte(" ")...)
+			}
+		}
+	} else {
+		logCtx, err := lw.parseContext(string(parts[contextIndex]))
+		if err != nil {
+			return 0, err
 		}
 
-		if i == contextIndex {
-			// TODO(aluzzardi): Consider constant padding.
-			output = append(output, []byte(fmt.Sprintf("%s@%s    |", taskName, nodeName))...)
-		} else {
-			output = append(output, part...)
+		taskName, err := lw.f.format(lw.ctx, logCtx)
+		if err != nil {
+			return 0, err
+		}
+
+		for i, part := range parts {
+			// First part doesn't get space separation.
+			if i > 0 {
+				output = append(output, []byte(" ")...)
+			}
+
+			if i == contextIndex {
+				// TODO(aluzzardi): Consider constant padding.
+				output = append(output, []byte(fmt.Sprintf("%s    |", taskName))...)
+			} else {
+				output = append(output, part...)
+			}
 		}
 	}
-	_, err = lw.w.Write(output)
-	if err != nil {
+
+	if _, err := lw.w.Write(output); err != nil {
 		return 0, err
 	}
 
 	return len(buf), nil
 }
 
-func (lw *logWriter) parseContext(input string) (string, string, error) {
+func (lw *logWriter) parseContext(input string) (logContext, error) {
 	context := make(map[string]string)
 
 	components := strings.Split(input, ",")
 	for _, component := range components {
 		parts := strings.SplitN(component, "=", 2)
 		if len(parts) != 2 {
-			return "", "", fmt.Errorf("invalid context: %s", input)
+			return logContext{}, fmt.Errorf("invalid context: %s", input)
 		}
 		context[parts[0]] = parts[1]
 	}
 
-	taskID, ok := context["com.docker.swarm.task.id"]
+	nodeID, ok := context["com.docker.swarm.node.id"]
 	if !ok {
-		return "", "", fmt.Errorf("missing task id in context: %s", input)
-	}
-	taskName, err := lw.r.Resolve(lw.ctx, swarm.Task{}, taskID)
-	if err != nil {
-		return "", "", err
+		return logContext{}, fmt.Errorf("missing node id in context: %s", input)
 	}
 
-	nodeID, ok := context["com.docker.swarm.node.id"]
+	serviceID, ok := context["com.docker.swarm.service.id"]
 	if !ok {
-		return "", "", fmt.Errorf("missing node id in context: %s", input)
+		return logContext{}, fmt.Errorf("missing service id in context: %s", input)
 	}
-	nodeName, err := lw.r.Resolve(lw.ctx, swarm.Node{}, nodeID)
-	if err != nil {
-		return "", "", err
+
+	taskID, ok := context["com.docker.swarm.task.id"]
+	if !ok {
+		return logContext{}, fmt.Errorf("missing task id in context: %s", input)
 	}
 
-	return taskName, nodeName, nil
+	return logContext{
+		nodeID:    nodeID,
+		serviceID: serviceID,
+		taskID:    taskID,
+	}, nil
 }
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/docker/docker/api/types/swarm"
+	"github.com/docker/docker/cli/command/idresolver"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/testutil/assert"
+	"golang.org/x/net/context"
+)
+
+const (
+	testTaskID     = "a1b2c3d4e5f6a1b2c3d4e5f6a"
+	testLogContext = "com.docker.swarm.node.id=node1,com.docker.swarm.service.id=service1,com.docker.swarm.task.id=" + testTaskID
+)
+
+type fakeTaskClient struct {
+	client.Client
+	task swarm.Task
+}
+
+func (cli *fakeTaskClient) TaskInspectWithRaw(ctx context.Context, taskID string) (swarm.Task, []byte, error) {
+	return cli.task, nil, nil
+}
+
+func writeLog(t *testing.T, opts *logsOptions, line string) string {
+	cli := &fakeTaskClient{task: swarm.Task{ID: testTaskID, Slot: 2, NodeID: "node1"}}
+	formatter := &taskFormatter{
+		client: cli,
+		opts:   opts,
+		r:      idresolver.New(cli, true),
+		cache:  make(map[logContext]string),
+	}
+	out := &bytes.Buffer{}
+	lw := &logWriter{ctx: context.Background(), opts: opts, f: formatter, w: out}
+	n, err := lw.Write([]byte(line))
+	assert.NilError(t, err)
+	assert.Equal(t, n, len(line))
+	return out.String()
+}
+
+func TestLogWriter(t *testing.T) {
+	out := writeLog(t, &logsOptions{}, testLogContext+" hello\n")
+	assert.Equal(t, out, "service1.2.a1b2c3d4e5f6@node1    | hello\n")
+}
+
+func TestLogWriterNoTrunc(t *testing.T) {
+	out := writeLog(t, &logsOptions{noTrunc: true}, testLogContext+" hello\n")
+	assert.Equal(t, out, "service1.2."+testTaskID+"@node1    | hello\n")
+}
+
+func TestLogWriterNoTaskIDs(t *testing.T) {
+	out := writeLog(t, &logsOptions{noTaskIDs: true}, testLogContext+" hello\n")
+	assert.Equal(t, out, "service1.2@node1    | hello\n")
+}
+
+func TestLogWriterRaw(t *testing.T) {
+	out := writeLog(t, &logsOptions{raw: true}, testLogContext+" hello world\n")
+	assert.Equal(t, out, "hello world\n")
+
+	// the timestamp is kept
+	out = writeLog(t, &logsOptions{raw: true, timestamps: true}, "2017-01-20T10:00:00.000000000Z "+testLogContext+" hello\n")
+	assert.Equal(t, out, "2017-01-20T10:00:00.000000000Z hello\n")
+}
+
+func TestLogWriterInvalidContext(t *testing.T) {
+	cli := &fakeTaskClient{}
+	opts := &logsOptions{}
+	lw := &logWriter{
+		ctx:  context.Background(),
+		opts: opts,
+		f:    &taskFormatter{client: cli, opts: opts, r: idresolver.New(cli, true), cache: make(map[logContext]string)},
+		w:    &bytes.Buffer{},
+	}
+	_, err := lw.Write([]byte("com.docker.swarm.node.id=node1 hello\n"))
+	assert.Error(t, err, "missing service id")
+}
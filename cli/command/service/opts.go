@@ -124,6 +124,8 @@ type updateOptions struct {
 	monitor         time.Duration
 	onFailure       string
 	maxFailureRatio floatValue
+	healthTimeout   time.Duration
+	order           string
 }
 
 type resourceOptions struct {
@@ -297,6 +299,7 @@ type serviceOptions struct {
 
 	restartPolicy restartPolicyOptions
 	constraints   opts.ListOpts
+	pinSlots      bool
 	update        updateOptions
 	networks      opts.ListOpts
 	endpoint      endpointOptions
@@ -410,6 +413,7 @@ func (opts *serviceOptions) ToService() (swarm.ServiceSpec, error) {
 			RestartPolicy: opts.restartPolicy.ToRestartPolicy(),
 			Placement: &swarm.Placement{
 				Constraints: opts.constraints.GetAll(),
+				PinSlots:    opts.pinSlots,
 			},
 			LogDriver: opts.logDriver.toLogDriver(),
 		},
@@ -421,6 +425,8 @@ func (opts *serviceOptions) ToService() (swarm.ServiceSpec, error) {
 			Monitor:         opts.update.monitor,
 			FailureAction:   opts.update.onFailure,
 			MaxFailureRatio: opts.update.maxFailureRatio.Value(),
+			HealthTimeout:   opts.update.healthTimeout,
+			Order:           opts.update.order,
 		},
 		EndpointSpec: opts.endpoint.ToEndpointSpec(),
 	}
@@ -451,6 +457,9 @@ func addServiceFlags(cmd *cobra.Command, opts *serviceOptions) {
 	flags.Var(&opts.restartPolicy.maxAttempts, flagRestartMaxAttempts, "Maximum number of restarts before giving up")
 	flags.Var(&opts.restartPolicy.window, flagRestartWindow, "Window used to evaluate the restart policy (ns|us|ms|s|m|h)")
 
+	flags.BoolVar(&opts.pinSlots, flagPinSlots, false, "Place replaced tasks on the node of the task they replace")
+	flags.SetAnnotation(flagPinSlots, "version", []string{"1.26"})
+
 	flags.Uint64Var(&opts.update.parallelism, flagUpdateParallelism, 1, "Maximum number of tasks updated simultaneously (0 to update all at once)")
 	flags.DurationVar(&opts.update.delay, flagUpdateDelay, time.Duration(0), "Delay between updates (ns|us|ms|s|m|h) (default 0s)")
 	flags.DurationVar(&opts.update.monitor, flagUpdateMonitor, time.Duration(0), "Duration after each task update to monitor for failure (ns|us|ms|s|m|h) (default 0s)")
@@ -458,6 +467,10 @@ func addServiceFlags(cmd *cobra.Command, opts *serviceOptions) {
 	flags.StringVar(&opts.update.onFailure, flagUpdateFailureAction, "pause", "Action on update failure (pause|continue)")
 	flags.Var(&opts.update.maxFailureRatio, flagUpdateMaxFailureRatio, "Failure rate to tolerate during an update")
 	flags.SetAnnotation(flagUpdateMaxFailureRatio, "version", []string{"1.25"})
+	flags.DurationVar(&opts.update.healthTimeout, flagUpdateHealthTimeout, time.Duration(0), "Time to wait for an updated task to become healthy before it is considered failed (ns|us|ms|s|m|h) (default 0s)")
+	flags.SetAnnotation(flagUpdateHealthTimeout, "version", []string{"1.26"})
+	flags.StringVar(&opts.update.order, flagUpdateOrder, "stop-first", "Update order (start-first|stop-first)")
+	flags.SetAnnotation(flagUpdateOrder, "version", []string{"1.26"})
 
 	flags.StringVar(&opts.endpoint.mode, flagEndpointMode, "vip", "Endpoint mode (vip or dnsrr)")
 
@@ -523,6 +536,7 @@ const (
 	flagMountAdd              = "mount-add"
 	flagName                  = "name"
 	flagNetwork               = "network"
+	flagPinSlots              = "pin-slots"
 	flagPublish               = "publish"
 	flagPublishRemove         = "publish-rm"
 	flagPublishAdd            = "publish-add"
@@ -538,8 +552,10 @@ const (
 	flagTTY                   = "tty"
 	flagUpdateDelay           = "update-delay"
 	flagUpdateFailureAction   = "update-failure-action"
+	flagUpdateHealthTimeout   = "update-health-timeout"
 	flagUpdateMaxFailureRatio = "update-max-failure-ratio"
 	flagUpdateMonitor         = "update-monitor"
+	flagUpdateOrder           = "update-order"
 	flagUpdateParallelism     = "update-parallelism"
 	flagUser                  = "user"
 	flagWorkdir               = "workdir"
@@ -256,18 +256,25 @@ func updateService(flags *pflag.FlagSet, spec *swarm.ServiceSpec) error {
 		updateDurationOpt(flagRestartWindow, &task.RestartPolicy.Window)
 	}
 
-	if anyChanged(flags, flagConstraintAdd, flagConstraintRemove) {
+	if anyChanged(flags, flagConstraintAdd, flagConstraintRemove, flagPinSlots) {
 		if task.Placement == nil {
 			task.Placement = &swarm.Placement{}
 		}
 		updatePlacement(flags, task.Placement)
+		if flags.Changed(flagPinSlots) {
+			pinSlots, err := flags.GetBool(flagPinSlots)
+			if err != nil {
+				return err
+			}
+			task.Placement.PinSlots = pinSlots
+		}
 	}
 
 	if err := updateReplicas(flags, &spec.Mode); err != nil {
 		return err
 	}
 
-	if anyChanged(flags, flagUpdateParallelism, flagUpdateDelay, flagUpdateMonitor, flagUpdateFailureAction, flagUpdateMaxFailureRatio) {
+	if anyChanged(flags, flagUpdateParallelism, flagUpdateDelay, flagUpdateMonitor, flagUpdateFailureAction, flagUpdateMaxFailureRatio, flagUpdateHealthTimeout, flagUpdateOrder) {
 		if spec.UpdateConfig == nil {
 			spec.UpdateConfig = &swarm.UpdateConfig{}
 		}
@@ -276,6 +283,8 @@ func updateService(flags *pflag.FlagSet, spec *swarm.ServiceSpec) error {
 		updateDuration(flagUpdateMonitor, &spec.UpdateConfig.Monitor)
 		updateString(flagUpdateFailureAction, &spec.UpdateConfig.FailureAction)
 		updateFloatValue(flagUpdateMaxFailureRatio, &spec.UpdateConfig.MaxFailureRatio)
+		updateDuration(flagUpdateHealthTimeout, &spec.UpdateConfig.HealthTimeout)
+		updateString(flagUpdateOrder, &spec.UpdateConfig.Order)
 	}
 
 	if flags.Changed(flagEndpointMode) {
@@ -464,3 +464,25 @@ func TestUpdateReadOnly(t *testing.T) {
 	updateService(flags, spec)
 	assert.Equal(t, cspec.ReadOnly, false)
 }
+
+func TestUpdatePinSlots(t *testing.T) {
+	spec := &swarm.ServiceSpec{}
+	task := &spec.TaskTemplate
+
+	// Update with --pin-slots=true, changed to true
+	flags := newUpdateCommand(nil).Flags()
+	flags.Set("pin-slots", "true")
+	updateService(flags, spec)
+	assert.Equal(t, task.Placement.PinSlots, true)
+
+	// Update without --pin-slots, no change
+	flags = newUpdateCommand(nil).Flags()
+	updateService(flags, spec)
+	assert.Equal(t, task.Placement.PinSlots, true)
+
+	// Update with --pin-slots=false, changed to false
+	flags = newUpdateCommand(nil).Flags()
+	flags.Set("pin-slots", "false")
+	updateService(flags, spec)
+	assert.Equal(t, task.Placement.PinSlots, false)
+}
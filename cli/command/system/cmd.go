@@ -20,6 +20,7 @@ func NewSystemCommand(dockerCli *command.DockerCli) *cobra.Command {
 		NewInfoCommand(dockerCli),
 		NewDiskUsageCommand(dockerCli),
 		NewPruneCommand(dockerCli),
+		NewFsckCommand(dockerCli),
 	)
 
 	return cmd
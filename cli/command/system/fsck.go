@@ -0,0 +1,129 @@
+package system
+
+import (
+	"fmt"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/docker/docker/registry"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+type fsckOptions struct {
+	repair bool
+	noPull bool
+}
+
+// NewFsckCommand creates a new cobra.Command for `docker system fsck`
+func NewFsckCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var opts fsckOptions
+
+	cmd := &cobra.Command{
+		Use:   "fsck [OPTIONS]",
+		Short: "Verify the integrity of the image layers",
+		Args:  cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFsck(dockerCli, opts)
+		},
+		Tags: map[string]string{"version": "1.26"},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&opts.repair, "repair", false, "Remove the unused layers and the images with corrupt layers")
+	flags.BoolVar(&opts.noPull, "no-pull", false, "Do not pull the removed images again after a repair")
+
+	return cmd
+}
+
+func runFsck(dockerCli *command.DockerCli, opts fsckOptions) error {
+	ctx := context.Background()
+
+	report, err := dockerCli.Client().SystemFsck(ctx, types.FsckOptions{Repair: opts.repair})
+	if err != nil {
+		return err
+	}
+
+	out := dockerCli.Out()
+	for _, l := range report.CorruptLayers {
+		fmt.Fprintf(out, "Corrupt layer %s (DiffID %s): %s\n", l.ChainID, l.DiffID, l.Error)
+		for _, id := range l.Images {
+			fmt.Fprintf(out, "  used by image %s\n", id)
+		}
+	}
+	for _, id := range report.DanglingLayers {
+		fmt.Fprintf(out, "Dangling layer %s\n", id)
+	}
+	for _, name := range report.OrphanedRWLayers {
+		fmt.Fprintf(out, "Orphaned RW layer %s\n", name)
+	}
+	for _, w := range report.Warnings {
+		fmt.Fprintf(dockerCli.Err(), "WARNING: %s\n", w)
+	}
+
+	if !opts.repair {
+		if len(report.CorruptLayers) == 0 && len(report.DanglingLayers) == 0 && len(report.OrphanedRWLayers) == 0 {
+			fmt.Fprintln(out, "No problem found")
+			return nil
+		}
+		return cli.StatusError{StatusCode: 1, Status: "Problems found, run `docker system fsck --repair` to repair them"}
+	}
+
+	for _, item := range report.ImagesDeleted {
+		if item.Untagged != "" {
+			fmt.Fprintln(out, "Untagged:", item.Untagged)
+		}
+		if item.Deleted != "" {
+			fmt.Fprintln(out, "Deleted:", item.Deleted)
+		}
+	}
+
+	if opts.noPull {
+		return nil
+	}
+
+	var errs []string
+	for _, ref := range report.RemovedReferences {
+		fmt.Fprintf(out, "Pulling %s\n", ref)
+		if err := pullReference(ctx, dockerCli, ref); err != nil {
+			errs = append(errs, fmt.Sprintf("could not pull %s: %v", ref, err))
+		}
+	}
+	if len(errs) > 0 {
+		for _, e := range errs {
+			fmt.Fprintln(dockerCli.Err(), e)
+		}
+		return cli.StatusError{StatusCode: 1}
+	}
+	return nil
+}
+
+// pullReference pulls an image removed by a repair again.
+func pullReference(ctx context.Context, dockerCli *command.DockerCli, ref string) error {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return err
+	}
+
+	repoInfo, err := registry.ParseRepositoryInfo(named)
+	if err != nil {
+		return err
+	}
+
+	authConfig := command.ResolveAuthConfig(ctx, dockerCli, repoInfo.Index)
+	encodedAuth, err := command.EncodeAuthToBase64(authConfig)
+	if err != nil {
+		return err
+	}
+
+	responseBody, err := dockerCli.Client().ImageCreate(ctx, ref, types.ImageCreateOptions{RegistryAuth: encodedAuth})
+	if err != nil {
+		return err
+	}
+	defer responseBody.Close()
+
+	return jsonmessage.DisplayJSONMessagesStream(responseBody, dockerCli.Out(), dockerCli.Out().FD(), dockerCli.Out().IsTerminal(), nil)
+}
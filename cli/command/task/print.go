@@ -19,7 +19,7 @@ import (
 )
 
 const (
-	psTaskItemFmt = "%s\t%s\t%s\t%s\t%s\t%s %s ago\t%s\t%s\n"
+	psTaskItemFmt = "%s\t%s\t%s\t%s\t%s\t%s %s ago%s\t%s\t%s\n"
 	maxErrLength  = 30
 )
 
@@ -127,6 +127,12 @@ func print(out io.Writer, ctx context.Context, tasks []swarm.Task, resolver *idr
 			taskErr = fmt.Sprintf("\"%s\"", taskErr)
 		}
 
+		// Show the health of the container like docker ps does.
+		health := ""
+		if task.Status.ContainerStatus.Health != "" {
+			health = fmt.Sprintf(" (%s)", task.Status.ContainerStatus.Health)
+		}
+
 		image := task.Spec.ContainerSpec.Image
 		if !noTrunc {
 			ref, err := distreference.ParseNamed(image)
@@ -149,6 +155,7 @@ func print(out io.Writer, ctx context.Context, tasks []swarm.Task, resolver *idr
 			command.PrettyPrint(task.DesiredState),
 			command.PrettyPrint(task.Status.State),
 			strings.ToLower(units.HumanDuration(time.Since(task.Status.Timestamp))),
+			health,
 			taskErr,
 			portStatus(task.Status.PortStatus),
 		)
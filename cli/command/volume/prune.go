@@ -13,7 +13,8 @@ import (
 )
 
 type pruneOptions struct {
-	force bool
+	force  bool
+	dryRun bool
 }
 
 // NewPruneCommand returns a new cobra prune command for volumes
@@ -32,6 +33,10 @@ func NewPruneCommand(dockerCli *command.DockerCli) *cobra.Command {
 			if output != "" {
 				fmt.Fprintln(dockerCli.Out(), output)
 			}
+			if opts.dryRun {
+				fmt.Fprintln(dockerCli.Out(), "Total reclaimable space:", units.HumanSize(float64(spaceReclaimed)))
+				return nil
+			}
 			fmt.Fprintln(dockerCli.Out(), "Total reclaimed space:", units.HumanSize(float64(spaceReclaimed)))
 			return nil
 		},
@@ -40,6 +45,8 @@ func NewPruneCommand(dockerCli *command.DockerCli) *cobra.Command {
 
 	flags := cmd.Flags()
 	flags.BoolVarP(&opts.force, "force", "f", false, "Do not prompt for confirmation")
+	flags.BoolVar(&opts.dryRun, "dry-run", false, "Only show what would be removed and the space it would free")
+	flags.SetAnnotation("dry-run", "version", []string{"1.26"})
 
 	return cmd
 }
@@ -48,17 +55,20 @@ const warning = `WARNING! This will remove all volumes not used by at least one
 Are you sure you want to continue?`
 
 func runPrune(dockerCli *command.DockerCli, opts pruneOptions) (spaceReclaimed uint64, output string, err error) {
-	if !opts.force && !command.PromptForConfirmation(dockerCli.In(), dockerCli.Out(), warning) {
+	if !opts.force && !opts.dryRun && !command.PromptForConfirmation(dockerCli.In(), dockerCli.Out(), warning) {
 		return
 	}
 
-	report, err := dockerCli.Client().VolumesPrune(context.Background(), filters.Args{})
+	report, err := dockerCli.Client().VolumesPrune(context.Background(), filters.Args{}, opts.dryRun)
 	if err != nil {
 		return
 	}
 
 	if len(report.VolumesDeleted) > 0 {
 		output = "Deleted Volumes:\n"
+		if opts.dryRun {
+			output = "Volumes that would be deleted:\n"
+		}
 		for _, id := range report.VolumesDeleted {
 			output += id + "\n"
 		}
@@ -2,6 +2,7 @@
 // sources:
 // data/config_schema_v3.0.json
 // data/config_schema_v3.1.json
+// data/config_schema_v3.2.json
 // DO NOT EDIT!
 
 package schema
@@ -109,6 +110,26 @@ func dataConfig_schema_v31Json() (*asset, error) {
 	return a, nil
 }
 
+var _dataConfig_schema_v32Json = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xed\x1a\x5d\x93\xdb\x26\xf0\xdd\xbf\xe2\x46\xc9\x5b\xec\xbb\x4c\x9b\xe9\x4c\xf3\xd6\xc7\x3e\xb5\xcf\xbd\x71\x34\x58\xc2\x36\x39\x21\x08\x20\xdf\x39\x37\xfe\xef\x05\x21\xc9\x80\x40\xe0\xb3\x72\x97\xce\x34\x2f\x39\xc3\xee\xb2\xdf\xbb\xac\x78\x5e\xdc\xdc\x64\xef\x79\xb1\x87\x18\x64\x9f\x6f\xb2\xbd\x10\xf4\xf3\xdd\xdd\x57\x4e\xea\x95\x5e\xbd\x25\x6c\x77\x57\x32\xb0\x15\xab\x8f\x9f\xee\xf4\xda\xbb\x6c\xa9\xf0\x50\xa9\x50\x0a\x52\x6f\xd1\x2e\xd7\x3b\xf9\xe1\xd7\xdb\x5f\x6e\x15\xba\x06\x11\x47\x0a\x15\x10\xd9\x7c\x85\x85\xd0\x6b\x0c\x7e\x6b\x10\x83\x0a\xf9\x3e\x3b\x40\xc6\x91\x84\x5e\x2f\x17\x6a\x8f\x32\x42\x21\x13\x08\x72\xb9\xfb\x2c\x57\xe4\x5a\x0f\xd2\x2f\x18\x64\xb9\x60\xa8\xde\x65\xed\xf2\xa9\xa5\x20\x37\x39\x64\x07\x54\x18\x14\x06\x56\xdf\xdd\x9d\xe9\xdf\x0d\x60\x4b\x97\xaa\xc1\x6c\xbb\x4e\x81\x10\x90\xd5\x7f\x8f\x79\x6b\xb7\xbf\xdc\x83\xd5\xf7\x3f\x56\xff\x7c\x5c\xfd\x7e\x9b\xaf\xd6\x1f\xde\x5b\xdb\x4a\xbf\x0c\x6e\xf5\xf1\x25\xdc\xa2\x1a\x09\x29\xcd\x70\x7e\x36\x40\x9e\xba\xbf\x4e\xc3\xc1\xa0\x2c\x5b\x60\x50\x59\x67\x6f\x41\xc5\xa1\x2d\x73\x0d\xc5\x23\x61\x0f\x31\x99\x07\xb0\x37\x92\xb9\x3b\xdf\x23\xb3\x2d\xce\x81\x54\x0d\x8e\x5a\xb0\x87\x7a\x23\x61\xf4\xf1\xf3\xd8\x8f\xc3\x82\x41\x11\x77\x59\x0d\xf5\x66\x1e\xab\x8e\xbf\x4e\xe0\x45\x2f\xf4\x24\xac\x86\x30\xce\x6e\x19\xb4\xc2\xdb\xa7\x2a\x5f\x78\x85\x75\x35\x28\x2b\xa0\xa5\x12\xd2\x8a\x1c\xd5\x5a\x40\x1f\x1a\x00\xc3\x5a\x64\x83\x0a\x24\xde\xa6\x41\x55\xe9\x6a\x94\xd4\xf0\x2f\x45\xe2\xde\x58\xbc\x91\x94\x9d\x4c\x66\xd0\x69\xf7\xad\x5f\x61\x83\x0f\xfb\x01\x59\x86\x7d\x99\xac\x05\x7c\x12\xad\x50\xd3\x47\x6b\x15\x90\xe2\x01\xb2\x2d\xaa\x60\x2a\x06\x60\x3b\x3e\xa1\xb2\x0a\x71\x91\x13\x96\x97\x48\x72\x7f\x72\xd0\x47\xf4\xe2\xfe\x34\xa0\x1a\xbf\xd6\x67\x07\x35\xac\x52\x00\x9a\x4b\x72\x96\x1c\x80\x31\x70\xcc\x96\xd2\x81\x04\xc4\xdc\x2f\xe2\x4d\xd6\xd4\xe8\x5b\x03\xff\xec\x40\x04\x6b\xa0\x4b\xb7\x94\xcc\xcd\x4f\x78\xc7\x48\x43\x73\x0a\x98\x72\xb0\x69\xf5\x4b\xbb\x62\x0c\xea\xb9\xbc\xee\x12\x39\x12\x34\x2f\x7d\x0e\xa0\x1a\xb2\xbc\x06\x38\xe6\x48\x2a\xea\x60\x5d\xf2\x5c\x17\xfc\x49\x37\xda\xe6\x1a\x9f\x3b\x04\x86\xea\x3f\xab\x3d\xca\x7a\xca\xb1\x35\x19\xe5\xda\x8a\xb7\xcc\x41\xcc\x39\x04\xac\xd8\xbf\x10\x9f\x60\xa9\xbe\x14\xdd\x49\x47\x61\x47\x4a\x90\xf6\x97\x9f\xce\x11\x60\x7d\xc8\x87\x5c\x72\xb1\x1a\x24\x36\x62\xa4\xc6\x7d\x34\xa4\x24\x98\x21\xc9\x2b\xfc\x27\x4a\x38\x74\x15\xe3\x08\x68\x6e\x0d\xa2\x5a\x3a\xe9\x31\xee\x7b\xc1\xa5\x52\xea\x06\x6f\x20\x53\x3d\xac\x05\xb9\x25\x0c\x03\xc5\x6c\x7f\xb6\xb1\x6d\x69\xda\xe3\x79\xa6\x02\x4d\x19\x54\x59\x07\x95\xd4\x4e\xfd\x30\xbf\x8b\x4b\xf2\x0c\xe4\x7b\xc2\x45\x7a\x0e\x37\xd0\xf7\x10\x54\x62\x2f\xef\x01\xc5\xc3\x04\xba\x09\x65\x61\xcb\x63\x53\x9c\x1c\x61\xb0\x8b\x03\xd1\x22\x06\x52\x81\x0d\xac\x5e\x24\xe7\xac\xca\x37\xc8\x92\xdd\x4e\x81\x86\x3c\x6e\xd4\xb9\x74\xdb\xb1\x9a\x5f\x32\x24\xaf\x50\xa9\x05\x9c\xd0\x73\xc3\xe5\x6e\xc6\x1b\x10\xcd\xd0\x64\xf7\x69\x81\x7e\xb9\xd5\xcd\xe7\x44\x54\xb5\x7f\x55\x55\xb6\x76\xdb\x05\xf5\x6f\xbc\x66\xaf\x38\x12\xa6\x35\x14\x96\x55\x30\x28\x54\xdf\xc0\x20\x0f\xd8\xf5\x0c\xda\xdd\x6e\x72\x4c\xca\x90\x83\x8e\x80\x5d\xdd\x04\x33\xf5\xc5\x85\xb0\x45\xbb\xb8\x7f\x4c\x32\x5d\xf4\x02\x11\x91\x26\xc4\x5e\x2a\x9b\x67\x76\xe3\x2e\xd6\xc2\x81\x0a\x01\x0e\xe3\xc1\x1e\x54\xa4\x45\x0d\xd1\xc3\xa7\x44\x9f\xf0\xe1\xfe\x36\x89\x1b\x40\x0d\xd2\x4c\xef\x91\x23\xa4\xce\xac\xb4\xe1\xe6\x63\x64\x1d\x89\xb6\x1f\xdc\xc2\x53\x54\x86\x73\x45\x9b\x21\xcc\x00\xa3\x84\x89\x51\x74\xbd\x4e\xb9\xd7\x47\x5f\x5d\xed\xa9\x4c\xdc\xb2\x5d\xda\x41\xfb\xd6\xb2\x21\xa4\x82\xa0\xb6\x52\x0f\x83\xa0\x94\x2d\x73\x75\x4c\x80\xe4\x02\xb0\xe8\x85\x42\xde\xf5\x1b\x86\xc4\x31\x97\xf5\x60\xf6\x3e\x83\xef\x71\xce\xd1\x77\x68\x5b\xf3\x9c\xef\x3b\x42\x6b\x87\x21\x67\x42\xf2\x42\x83\x86\x52\x52\x3c\x8c\x3d\x89\x30\x9a\xa8\xe2\x29\x2a\xe3\xa4\x61\x45\xea\x05\x5b\x9d\x29\xef\xd8\x30\xf5\x0a\xaf\xdc\xcd\x0e\x9b\x69\xe0\xdd\x25\xc0\xa3\x42\xd7\x99\x30\x56\x95\xdd\xdf\x66\x5e\x39\x79\x43\x9f\x1f\x79\x21\x5e\xd6\xad\x71\x51\xa2\x5a\xba\x31\xac\xa3\xb1\xc1\x05\xa1\xf9\x8e\x81\x02\xe6\xd2\x66\x88\x78\x55\xb1\x34\x23\xbd\x6c\x18\x50\xe7\x8f\xc9\x70\xb4\x93\x39\x2f\x16\x66\x02\xd3\xed\x0b\xaf\x95\x42\xc4\x83\xbd\xa9\x10\x46\xe1\xa0\xf1\x78\x6d\x42\x07\xa0\xab\xbf\xbf\xe8\x4f\x14\xfc\x33\xa7\xf2\x7e\x2a\x93\x1a\xf3\x39\xd5\x44\xcf\x39\xdd\x72\x26\xf4\x9a\x7b\xc0\x6c\x83\x4e\xf0\xd1\x22\x70\xb2\x15\x7e\x04\x5f\x27\xea\xe5\xcb\xfa\xd6\xd1\xd2\x5b\x76\x8c\xac\xbd\xf0\x17\x15\x73\x97\x0d\xbb\x3c\x9f\xa2\x41\xd5\xf0\xe8\xb5\xa0\x85\xa9\xf9\x54\x4b\x3b\x80\x1a\x43\xfb\x59\xab\x85\x6a\x93\x55\x10\x94\xc8\xcf\xed\xc2\x91\xec\x82\xb1\xbb\x73\x63\xed\x09\xf8\xe6\xc9\x26\x68\x74\xfe\x3e\x3d\xdb\xee\x80\x82\x73\x67\xc4\xc1\xc6\x99\xb8\xfa\x82\x5b\x79\x23\x3b\xc4\x73\x8c\x2c\x9b\x0c\x39\x76\xe9\x13\xb5\x99\x4f\x64\x63\x90\x7a\x19\x79\xd5\xb1\x91\x40\x18\x92\xc6\x5f\xf0\x7a\xdb\x2f\x0c\xa4\xcc\x98\xcb\x47\x8c\x6a\x40\xba\x36\xbd\x1f\x8c\xda\x77\x97\x51\xc3\xa5\x04\x09\x93\x27\xa2\x02\xd8\xba\xf0\x24\xa2\x2b\xc6\x13\x0d\x2d\x81\x80\xb9\xfe\x2e\x7b\x51\xea\x9f\xc8\xf9\x14\x30\x50\x55\x50\x1e\x8a\x53\x72\xa8\xb4\x41\x05\x8e\x3e\x65\x44\xcb\x67\x8b\xbe\x05\xa8\x6a\x18\xcc\x41\x21\xba\x4f\xbf\x11\x9f\x93\xca\x97\x8a\x21\xde\x0c\x91\x76\x24\x06\x4f\x79\x7f\x6c\x0b\x12\x09\x98\x16\x89\xb0\xd2\x9f\x43\x97\x6a\x60\xd9\x60\x4f\x25\xcc\xda\xfe\x7b\xb5\x45\x8c\x0b\xdd\xec\x12\xda\xfd\xb2\x9b\x22\x2b\x81\x5b\x86\x4a\x9d\x62\x0c\x08\xb2\xe9\x6f\x9b\xcc\xcb\x3a\x81\x09\x77\x38\xf7\x15\x01\xef\xec\x4f\x1c\x69\x4c\x6e\xa8\xac\x35\x0c\x99\xa2\xf8\xd1\x32\xd6\xdd\x68\x72\x4a\x64\x64\x1d\xe7\x92\x50\x86\x8f\x56\x72\x8a\xf3\x5d\xe9\xed\xca\xf5\x54\xdb\x85\xa9\x88\x26\x86\x16\xe1\x11\xd5\x25\x79\xbc\xe0\xc0\xf9\x5c\x89\x56\xb2\x3d\x76\x72\xeb\xb5\x8a\x96\xbc\x03\x29\xea\xc5\xad\xc3\xb5\x62\x5d\xd1\x39\x0c\xfe\x19\xa9\x30\x03\x5c\xfc\x9b\x7d\xa0\xaa\x14\xb4\x89\x4e\x1e\x31\xc4\x84\x79\x1d\x70\x86\x47\x25\x31\x11\x7b\xb0\x19\x2a\x68\xd2\xa8\xba\x83\x52\x93\x89\xd9\x6f\x36\xf1\x71\xf4\x3a\x9e\x90\x10\x05\x78\xae\xe8\x48\x1e\xde\x67\xde\x7a\x6f\x9d\x3d\x9e\x91\x68\x76\xbd\x73\x92\x18\xd7\x71\xde\x3b\x08\xde\x6c\xea\xc0\xb8\x62\x7c\xa3\xf1\xbd\x28\x48\xbf\x12\x39\xb3\xff\xf9\x92\x5e\xff\xdd\x2d\x60\xd5\xfb\xa1\x3f\x5f\x0e\xba\x5a\x27\x9b\x38\xf8\xd1\x6b\x3e\xfe\xdb\xab\x82\x3b\x8e\xf0\xdd\x29\x2e\xec\x3a\xaf\x48\x2e\xdd\xeb\xa9\x48\x6e\xe9\xa0\xfe\x4f\x2d\x1d\x95\xff\xba\x23\xbe\x9e\x7f\x75\x8f\xd5\x22\xfe\xd5\x41\xbd\xb8\x38\x27\x3c\x8d\xfa\x09\x6c\xf6\xd6\xa6\xb0\x87\x98\x86\x49\xc6\xf3\x85\x29\x4d\x26\x7f\xbb\xeb\x30\xd6\x36\x1b\x2e\x98\xc1\x87\xbf\x42\x4e\x0d\xad\x7a\x90\xc0\xb7\x1c\xe7\xd0\x4e\x89\xd3\x92\xcf\x98\x6c\x6e\x3f\x4c\xf4\x01\x53\xdf\xd8\x7f\x50\x01\x9d\x61\x20\xe8\xb7\xa9\x73\x79\xe8\xb5\x3b\x7e\x23\x1a\x88\x7f\x03\x7f\xf4\x62\x54\xc9\x59\x1f\x47\xf3\xaf\x67\x7b\xa8\xab\x5f\x7b\xae\x2d\xfd\x38\x20\xfa\xc5\x8a\x91\xdd\x8d\xf0\x0e\x07\xb7\xf7\x1d\xe9\x8d\x33\x52\xee\xdf\x73\x06\xbe\xa0\x2c\xcc\xff\xdb\xb7\xb7\x8b\xd3\xe2\x5f\x08\x68\xf2\x29\xf4\x2f\x00\x00")
+
+func dataConfig_schema_v32JsonBytes() ([]byte, error) {
+	return bindataRead(
+		_dataConfig_schema_v32Json,
+		"data/config_schema_v3.2.json",
+	)
+}
+
+func dataConfig_schema_v32Json() (*asset, error) {
+	bytes, err := dataConfig_schema_v32JsonBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "data/config_schema_v3.2.json", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -163,6 +184,7 @@ func AssetNames() []string {
 var _bindata = map[string]func() (*asset, error){
 	"data/config_schema_v3.0.json": dataConfig_schema_v30Json,
 	"data/config_schema_v3.1.json": dataConfig_schema_v31Json,
+	"data/config_schema_v3.2.json": dataConfig_schema_v32Json,
 }
 
 // AssetDir returns the file names below a certain
@@ -208,6 +230,7 @@ var _bintree = &bintree{nil, map[string]*bintree{
 	"data": &bintree{nil, map[string]*bintree{
 		"config_schema_v3.0.json": &bintree{dataConfig_schema_v30Json, map[string]*bintree{}},
 		"config_schema_v3.1.json": &bintree{dataConfig_schema_v31Json, map[string]*bintree{}},
+		"config_schema_v3.2.json": &bintree{dataConfig_schema_v32Json, map[string]*bintree{}},
 	}},
 }}
 
@@ -50,3 +50,28 @@ func TestValidateInvalidVersion(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "unsupported Compose file version: 2.1")
 }
+
+func TestValidateUpdateOrder(t *testing.T) {
+	config := func(order string) dict {
+		return dict{
+			"version": "3.2",
+			"services": dict{
+				"foo": dict{
+					"image": "busybox",
+					"deploy": dict{
+						"update_config": dict{
+							"order": order,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	assert.NoError(t, Validate(config("start-first"), "3.2"))
+	assert.Error(t, Validate(config("sideways"), "3.2"))
+
+	err := Validate(config("start-first"), "3.1")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "order")
+}
@@ -37,6 +37,9 @@ func (cli *Client) CopyToContainer(ctx context.Context, container, path string,
 	if !options.AllowOverwriteDirWithFile {
 		query.Set("noOverwriteDirNonDir", "true")
 	}
+	if options.CopyUIDGID {
+		query.Set("copyUIDGID", "true")
+	}
 
 	apiPath := fmt.Sprintf("/containers/%s/archive", container)
 
@@ -53,6 +56,34 @@ func (cli *Client) CopyToContainer(ctx context.Context, container, path string,
 	return nil
 }
 
+// CopyBetweenContainers copies the content at srcPath in srcContainer to
+// dstPath in dstContainer. The content is streamed by the daemon, which
+// returns a JSON messages stream reporting the progress of the copy. It's up
+// to the caller to close the stream.
+func (cli *Client) CopyBetweenContainers(ctx context.Context, srcContainer, srcPath, dstContainer, dstPath string, options types.CopyBetweenContainersOptions) (io.ReadCloser, error) {
+	query := url.Values{}
+	query.Set("srcContainer", srcContainer)
+	query.Set("srcPath", filepath.ToSlash(srcPath)) // Normalize the paths used in the API.
+	query.Set("path", filepath.ToSlash(dstPath))
+	// Do not allow for an existing directory to be overwritten by a non-directory and vice versa.
+	if !options.AllowOverwriteDirWithFile {
+		query.Set("noOverwriteDirNonDir", "true")
+	}
+	if options.CopyUIDGID {
+		query.Set("copyUIDGID", "true")
+	}
+	if options.FollowLink {
+		query.Set("followLink", "true")
+	}
+
+	apiPath := fmt.Sprintf("/containers/%s/copy-from", dstContainer)
+	response, err := cli.post(ctx, apiPath, query, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return response.body, nil
+}
+
 // CopyFromContainer gets the content from the container and returns it as a Reader
 // to manipulate it in the host. It's up to the caller to close the reader.
 func (cli *Client) CopyFromContainer(ctx context.Context, container, srcPath string) (io.ReadCloser, types.ContainerPathStat, error) {
@@ -242,3 +242,59 @@ func TestCopyFromContainer(t *testing.T) {
 		t.Fatalf("expected content to be 'content', got %s", string(content))
 	}
 }
+
+func TestCopyBetweenContainersError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.CopyBetweenContainers(context.Background(), "src_id", "/src", "dst_id", "/dst", types.CopyBetweenContainersOptions{})
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server error, got %v", err)
+	}
+}
+
+func TestCopyBetweenContainers(t *testing.T) {
+	expectedURL := "/containers/dst_id/copy-from"
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if req.Method != "POST" {
+				return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+			}
+			query := req.URL.Query()
+			expectedQuery := map[string]string{
+				"srcContainer":         "src_id",
+				"srcPath":              "/src/dir",
+				"path":                 "/dst",
+				"noOverwriteDirNonDir": "true",
+				"copyUIDGID":           "true",
+				"followLink":           "",
+			}
+			for key, expected := range expectedQuery {
+				if actual := query.Get(key); actual != expected {
+					return nil, fmt.Errorf("%s not set in URL query properly, expected '%s', got '%s'", key, expected, actual)
+				}
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"status":"Copying"}`))),
+			}, nil
+		}),
+	}
+	body, err := client.CopyBetweenContainers(context.Background(), "src_id", "/src/dir", "dst_id", "/dst", types.CopyBetweenContainersOptions{
+		CopyUIDGID: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+	content, err := ioutil.ReadAll(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != `{"status":"Copying"}` {
+		t.Fatalf("expected the progress stream to be returned, got %s", content)
+	}
+}
@@ -2,17 +2,31 @@ package client
 
 import (
 	"encoding/json"
+	"io"
 	"net/url"
 
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"golang.org/x/net/context"
 )
 
 // ContainerDiff shows differences in a container filesystem since it was started.
 func (cli *Client) ContainerDiff(ctx context.Context, containerID string) ([]container.ContainerChangeResponseItem, error) {
+	return cli.ContainerDiffWithOptions(ctx, containerID, types.ContainerDiffOptions{})
+}
+
+// ContainerDiffWithOptions shows differences in a container filesystem since
+// it was started, with the size of the added and modified files if
+// options.Size is set.
+func (cli *Client) ContainerDiffWithOptions(ctx context.Context, containerID string, options types.ContainerDiffOptions) ([]container.ContainerChangeResponseItem, error) {
 	var changes []container.ContainerChangeResponseItem
 
-	serverResp, err := cli.get(ctx, "/containers/"+containerID+"/changes", url.Values{}, nil)
+	query := url.Values{}
+	if options.Size {
+		query.Set("size", "1")
+	}
+
+	serverResp, err := cli.get(ctx, "/containers/"+containerID+"/changes", query, nil)
 	if err != nil {
 		return changes, err
 	}
@@ -21,3 +35,15 @@ func (cli *Client) ContainerDiff(ctx context.Context, containerID string) ([]con
 	ensureReaderClosed(serverResp)
 	return changes, err
 }
+
+// ContainerExportChanges retrieves a tar archive of the files of a container
+// that changed since it was created. Deleted files are represented by whiteout
+// files. It's up to the caller to close the stream.
+func (cli *Client) ContainerExportChanges(ctx context.Context, containerID string) (io.ReadCloser, error) {
+	serverResp, err := cli.get(ctx, "/containers/"+containerID+"/export-changes", url.Values{}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return serverResp.body, nil
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"golang.org/x/net/context"
 )
@@ -31,6 +32,9 @@ func TestContainerDiff(t *testing.T) {
 			if !strings.HasPrefix(req.URL.Path, expectedURL) {
 				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
 			}
+			if size := req.URL.Query().Get("size"); size != "" {
+				return nil, fmt.Errorf("size not expected in URL query, got '%s'", size)
+			}
 			b, err := json.Marshal([]container.ContainerChangeResponseItem{
 				{
 					Kind: 0,
@@ -59,3 +63,66 @@ func TestContainerDiff(t *testing.T) {
 		t.Fatalf("expected an array of 2 changes, got %v", changes)
 	}
 }
+
+func TestContainerDiffWithOptions(t *testing.T) {
+	expectedURL := "/containers/container_id/changes"
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if size := req.URL.Query().Get("size"); size != "1" {
+				return nil, fmt.Errorf("size not set in URL query properly, expected '1', got '%s'", size)
+			}
+			b, err := json.Marshal([]container.ContainerChangeResponseItem{
+				{
+					Kind: 0,
+					Path: "/path/1",
+					Size: 42,
+				},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(b)),
+			}, nil
+		}),
+	}
+
+	changes, err := client.ContainerDiffWithOptions(context.Background(), "container_id", types.ContainerDiffOptions{Size: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || changes[0].Size != 42 {
+		t.Fatalf("expected a change with a size of 42, got %v", changes)
+	}
+}
+
+func TestContainerExportChanges(t *testing.T) {
+	expectedURL := "/containers/container_id/export-changes"
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte("response"))),
+			}, nil
+		}),
+	}
+	body, err := client.ContainerExportChanges(context.Background(), "container_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+	content, err := ioutil.ReadAll(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "response" {
+		t.Fatalf("expected response to contain 'response', got %s", string(content))
+	}
+}
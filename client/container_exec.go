@@ -2,6 +2,7 @@ package client
 
 import (
 	"encoding/json"
+	"net/url"
 
 	"github.com/docker/docker/api/types"
 	"golang.org/x/net/context"
@@ -31,6 +32,16 @@ func (cli *Client) ContainerExecStart(ctx context.Context, execID string, config
 	return err
 }
 
+// ContainerExecKill sends a signal to a running exec process in the docker host.
+func (cli *Client) ContainerExecKill(ctx context.Context, execID, signal string) error {
+	query := url.Values{}
+	query.Set("signal", signal)
+
+	resp, err := cli.post(ctx, "/exec/"+execID+"/kill", query, nil, nil)
+	ensureReaderClosed(resp)
+	return err
+}
+
 // ContainerExecAttach attaches a connection to an exec process in the server.
 // It returns a types.HijackedConnection with the hijacked connection
 // and the a reader to get output. It's up to the called to close
@@ -52,3 +63,16 @@ func (cli *Client) ContainerExecInspect(ctx context.Context, execID string) (typ
 	ensureReaderClosed(resp)
 	return response, err
 }
+
+// ContainerExecList returns the running and recently finished exec processes of a container.
+func (cli *Client) ContainerExecList(ctx context.Context, container string) ([]types.ExecSummary, error) {
+	var execs []types.ExecSummary
+	resp, err := cli.get(ctx, "/containers/"+container+"/execs", nil, nil)
+	if err != nil {
+		return execs, err
+	}
+
+	err = json.NewDecoder(resp.body).Decode(&execs)
+	ensureReaderClosed(resp)
+	return execs, err
+}
@@ -113,6 +113,40 @@ func TestContainerExecStart(t *testing.T) {
 	}
 }
 
+func TestContainerExecKillError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	err := client.ContainerExecKill(context.Background(), "nothing", "SIGKILL")
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestContainerExecKill(t *testing.T) {
+	expectedURL := "/exec/exec_id/kill"
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			signal := req.URL.Query().Get("signal")
+			if signal != "SIGHUP" {
+				return nil, fmt.Errorf("signal not set in URL query properly. Expected 'SIGHUP', got %s", signal)
+			}
+			return &http.Response{
+				StatusCode: http.StatusNoContent,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte(""))),
+			}, nil
+		}),
+	}
+
+	err := client.ContainerExecKill(context.Background(), "exec_id", "SIGHUP")
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestContainerExecInspectError(t *testing.T) {
 	client := &Client{
 		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
@@ -155,3 +189,49 @@ func TestContainerExecInspect(t *testing.T) {
 		t.Fatalf("expected ContainerID `container_id`, got %s", inspect.ContainerID)
 	}
 }
+
+func TestContainerExecListError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.ContainerExecList(context.Background(), "nothing")
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestContainerExecList(t *testing.T) {
+	expectedURL := "/containers/container_id/execs"
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if req.Method != "GET" {
+				return nil, fmt.Errorf("expected GET method, got %s", req.Method)
+			}
+			b, err := json.Marshal([]types.ExecSummary{
+				{ID: "exec_id1", Cmd: []string{"top"}, Running: true},
+				{ID: "exec_id2", Cmd: []string{"ls", "-l"}},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(b)),
+			}, nil
+		}),
+	}
+
+	execs, err := client.ContainerExecList(context.Background(), "container_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(execs) != 2 {
+		t.Fatalf("expected 2 execs, got %v", execs)
+	}
+	if execs[0].ID != "exec_id1" || !execs[0].Running {
+		t.Fatalf("unexpected exec: %v", execs[0])
+	}
+}
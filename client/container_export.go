@@ -4,14 +4,28 @@ import (
 	"io"
 	"net/url"
 
+	"github.com/docker/docker/api/types"
 	"golang.org/x/net/context"
 )
 
 // ContainerExport retrieves the raw contents of a container
-// and returns them as an io.ReadCloser. It's up to the caller
+// and returns them as an io.ReadCloser. With options.Full, it
+// retrieves an archive of the container which can be imported
+// with ContainerImport instead. It's up to the caller
 // to close the stream.
-func (cli *Client) ContainerExport(ctx context.Context, containerID string) (io.ReadCloser, error) {
-	serverResp, err := cli.get(ctx, "/containers/"+containerID+"/export", url.Values{}, nil)
+func (cli *Client) ContainerExport(ctx context.Context, containerID string, options types.ContainerExportOptions) (io.ReadCloser, error) {
+	query := url.Values{}
+	if options.Full {
+		if err := cli.NewVersionError("1.26", "container full export"); err != nil {
+			return nil, err
+		}
+		query.Set("full", "1")
+		if options.Volumes {
+			query.Set("volumes", "1")
+		}
+	}
+
+	serverResp, err := cli.get(ctx, "/containers/"+containerID+"/export", query, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/docker/docker/api/types"
 	"golang.org/x/net/context"
 )
 
@@ -15,7 +16,7 @@ func TestContainerExportError(t *testing.T) {
 	client := &Client{
 		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
 	}
-	_, err := client.ContainerExport(context.Background(), "nothing")
+	_, err := client.ContainerExport(context.Background(), "nothing", types.ContainerExportOptions{})
 	if err == nil || err.Error() != "Error response from daemon: Server error" {
 		t.Fatalf("expected a Server Error, got %v", err)
 	}
@@ -35,7 +36,7 @@ func TestContainerExport(t *testing.T) {
 			}, nil
 		}),
 	}
-	body, err := client.ContainerExport(context.Background(), "container_id")
+	body, err := client.ContainerExport(context.Background(), "container_id", types.ContainerExportOptions{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -48,3 +49,32 @@ func TestContainerExport(t *testing.T) {
 		t.Fatalf("expected response to contain 'response', got %s", string(content))
 	}
 }
+
+func TestContainerExportFull(t *testing.T) {
+	expectedURL := "/v1.26/containers/container_id/export"
+	client := &Client{
+		version: "1.26",
+		client: newMockClient(func(r *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(r.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, r.URL)
+			}
+			query := r.URL.Query()
+			if full := query.Get("full"); full != "1" {
+				return nil, fmt.Errorf("full not set in URL query properly, expected '1', got '%s'", full)
+			}
+			if volumes := query.Get("volumes"); volumes != "1" {
+				return nil, fmt.Errorf("volumes not set in URL query properly, expected '1', got '%s'", volumes)
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte("response"))),
+			}, nil
+		}),
+	}
+	body, err := client.ContainerExport(context.Background(), "container_id", types.ContainerExportOptions{Full: true, Volumes: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body.Close()
+}
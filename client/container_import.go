@@ -0,0 +1,36 @@
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"golang.org/x/net/context"
+)
+
+// ContainerImport creates a container from an archive retrieved with
+// ContainerExport with the Full option.
+func (cli *Client) ContainerImport(ctx context.Context, input io.Reader, options types.ContainerImportOptions) (container.ContainerCreateCreatedBody, error) {
+	var response container.ContainerCreateCreatedBody
+
+	if err := cli.NewVersionError("1.26", "container import"); err != nil {
+		return response, err
+	}
+
+	query := url.Values{}
+	if options.Name != "" {
+		query.Set("name", options.Name)
+	}
+
+	headers := map[string][]string{"Content-Type": {"application/x-tar"}}
+	serverResp, err := cli.postRaw(ctx, "/containers/import", query, input, headers)
+	if err != nil {
+		return response, err
+	}
+
+	err = json.NewDecoder(serverResp.body).Decode(&response)
+	ensureReaderClosed(serverResp)
+	return response, err
+}
@@ -12,6 +12,23 @@ import (
 
 // ContainerList returns the list of containers in the docker host.
 func (cli *Client) ContainerList(ctx context.Context, options types.ContainerListOptions) ([]types.Container, error) {
+	query, err := buildContainerListQueryParams(cli.version, options)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := cli.get(ctx, "/containers/json", query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var containers []types.Container
+	err = json.NewDecoder(resp.body).Decode(&containers)
+	ensureReaderClosed(resp)
+	return containers, err
+}
+
+func buildContainerListQueryParams(cliVersion string, options types.ContainerListOptions) (url.Values, error) {
 	query := url.Values{}
 
 	if options.All {
@@ -35,7 +52,7 @@ func (cli *Client) ContainerList(ctx context.Context, options types.ContainerLis
 	}
 
 	if options.Filters.Len() > 0 {
-		filterJSON, err := filters.ToParamWithVersion(cli.version, options.Filters)
+		filterJSON, err := filters.ToParamWithVersion(cliVersion, options.Filters)
 
 		if err != nil {
 			return nil, err
@@ -44,13 +61,5 @@ func (cli *Client) ContainerList(ctx context.Context, options types.ContainerLis
 		query.Set("filters", filterJSON)
 	}
 
-	resp, err := cli.get(ctx, "/containers/json", query, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var containers []types.Container
-	err = json.NewDecoder(resp.body).Decode(&containers)
-	ensureReaderClosed(resp)
-	return containers, err
+	return query, nil
 }
@@ -9,8 +9,9 @@ import (
 	"golang.org/x/net/context"
 )
 
-// ContainersPrune requests the daemon to delete unused data
-func (cli *Client) ContainersPrune(ctx context.Context, pruneFilters filters.Args) (types.ContainersPruneReport, error) {
+// ContainersPrune requests the daemon to delete unused data. If dryRun is true,
+// nothing is deleted, and the report lists what would be deleted.
+func (cli *Client) ContainersPrune(ctx context.Context, pruneFilters filters.Args, dryRun bool) (types.ContainersPruneReport, error) {
 	var report types.ContainersPruneReport
 
 	if err := cli.NewVersionError("1.25", "container prune"); err != nil {
@@ -22,6 +23,13 @@ func (cli *Client) ContainersPrune(ctx context.Context, pruneFilters filters.Arg
 		return report, err
 	}
 
+	if dryRun {
+		if err := cli.NewVersionError("1.26", "container prune dry run"); err != nil {
+			return report, err
+		}
+		query.Set("dryrun", "1")
+	}
+
 	serverResp, err := cli.post(ctx, "/containers/prune", query, nil, nil)
 	if err != nil {
 		return report, err
@@ -23,7 +23,7 @@ func TestContainersPruneError(t *testing.T) {
 
 	filters := filters.NewArgs()
 
-	_, err := client.ContainersPrune(context.Background(), filters)
+	_, err := client.ContainersPrune(context.Background(), filters, false)
 	assert.Error(t, err, "Error response from daemon: Server error")
 }
 
@@ -103,7 +103,7 @@ func TestContainersPrune(t *testing.T) {
 			version: "1.25",
 		}
 
-		report, err := client.ContainersPrune(context.Background(), listCase.filters)
+		report, err := client.ContainersPrune(context.Background(), listCase.filters, false)
 		assert.NilError(t, err)
 		assert.Equal(t, len(report.ContainersDeleted), 2)
 		assert.Equal(t, report.SpaceReclaimed, uint64(9999))
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"encoding/json"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/types"
+)
+
+// ContainerWatch returns a stream of the changes to the list of containers in
+// the docker host. The stream starts with the containers matching options, as
+// "add" events followed by a "sync" event. It's up to the caller to close the
+// stream by cancelling the context. If an error is sent over the error channel,
+// all processing is stopped, and the caller needs to watch again.
+func (cli *Client) ContainerWatch(ctx context.Context, options types.ContainerListOptions) (<-chan types.ContainerWatchEvent, <-chan error) {
+	watchEvents := make(chan types.ContainerWatchEvent)
+	errs := make(chan error, 1)
+
+	started := make(chan struct{})
+	go func() {
+		defer close(errs)
+
+		query, err := buildContainerListQueryParams(cli.version, options)
+		if err != nil {
+			close(started)
+			errs <- err
+			return
+		}
+		query.Set("watch", "1")
+
+		resp, err := cli.get(ctx, "/containers/json", query, nil)
+		if err != nil {
+			close(started)
+			errs <- err
+			return
+		}
+		defer resp.body.Close()
+
+		decoder := json.NewDecoder(resp.body)
+
+		close(started)
+		for {
+			select {
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			default:
+				var event types.ContainerWatchEvent
+				if err := decoder.Decode(&event); err != nil {
+					errs <- err
+					return
+				}
+
+				select {
+				case watchEvents <- event:
+				case <-ctx.Done():
+					errs <- ctx.Err()
+					return
+				}
+			}
+		}
+	}()
+	<-started
+
+	return watchEvents, errs
+}
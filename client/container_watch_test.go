@@ -0,0 +1,103 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+)
+
+func TestContainerWatchErrorFromServer(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, errs := client.ContainerWatch(context.Background(), types.ContainerListOptions{})
+	err := <-errs
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestContainerWatch(t *testing.T) {
+	expectedURL := "/containers/json"
+	expectedFilters := `{"label":{"com.example.app":true}}`
+	watchEvents := []types.ContainerWatchEvent{
+		{Type: types.ContainerWatchAdd, Container: &types.Container{ID: "container_id1"}},
+		{Type: types.ContainerWatchSync},
+		{Type: types.ContainerWatchUpdate, Container: &types.Container{ID: "container_id1", State: "running"}},
+		{Type: types.ContainerWatchDelete, Container: &types.Container{ID: "container_id1"}},
+	}
+
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			query := req.URL.Query()
+			if watch := query.Get("watch"); watch != "1" {
+				return nil, fmt.Errorf("watch not set in URL query properly. Expected '1', got %s", watch)
+			}
+			if all := query.Get("all"); all != "1" {
+				return nil, fmt.Errorf("all not set in URL query properly. Expected '1', got %s", all)
+			}
+			if fltrs := query.Get("filters"); fltrs != expectedFilters {
+				return nil, fmt.Errorf("filters not set in URL query properly. Expected '%s', got %s", expectedFilters, fltrs)
+			}
+
+			buffer := new(bytes.Buffer)
+			for _, e := range watchEvents {
+				b, _ := json.Marshal(e)
+				buffer.Write(b)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(buffer),
+			}, nil
+		}),
+	}
+
+	fltrs := filters.NewArgs()
+	fltrs.Add("label", "com.example.app")
+	messages, errs := client.ContainerWatch(context.Background(), types.ContainerListOptions{
+		All:     true,
+		Filters: fltrs,
+	})
+
+	var received []types.ContainerWatchEvent
+loop:
+	for {
+		select {
+		case err := <-errs:
+			if err != nil && err != io.EOF {
+				t.Fatal(err)
+			}
+			break loop
+		case e := <-messages:
+			received = append(received, e)
+		}
+	}
+
+	if len(received) != len(watchEvents) {
+		t.Fatalf("expected %d events, got %d", len(watchEvents), len(received))
+	}
+	for i, e := range watchEvents {
+		if received[i].Type != e.Type {
+			t.Fatalf("expected event %d to be %q, got %q", i, e.Type, received[i].Type)
+		}
+	}
+	if received[1].Container != nil {
+		t.Fatalf("expected no container in the sync event, got %v", received[1].Container)
+	}
+	if received[2].Container.State != "running" {
+		t.Fatalf("expected the updated container to be running, got %q", received[2].Container.State)
+	}
+}
@@ -11,13 +11,21 @@ import (
 
 // ImageLoad loads an image in the docker host from the client host.
 // It's up to the caller to close the io.ReadCloser in the
-// ImageLoadResponse returned by this function.
-func (cli *Client) ImageLoad(ctx context.Context, input io.Reader, quiet bool) (types.ImageLoadResponse, error) {
+// ImageLoadResponse returned by this function. If options.VerifyKey
+// is set, the daemon only loads the images if the archive is signed
+// with the key.
+func (cli *Client) ImageLoad(ctx context.Context, input io.Reader, options types.ImageLoadOptions) (types.ImageLoadResponse, error) {
 	v := url.Values{}
 	v.Set("quiet", "0")
-	if quiet {
+	if options.Quiet {
 		v.Set("quiet", "1")
 	}
+	if options.VerifyKey != "" {
+		if err := cli.NewVersionError("1.26", "signed image load"); err != nil {
+			return types.ImageLoadResponse{}, err
+		}
+		v.Set("verifykey", options.VerifyKey)
+	}
 	headers := map[string][]string{"Content-Type": {"application/x-tar"}}
 	resp, err := cli.postRaw(ctx, "/images/load", v, input, headers)
 	if err != nil {
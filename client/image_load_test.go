@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/docker/docker/api/types"
 	"golang.org/x/net/context"
 )
 
@@ -16,24 +17,24 @@ func TestImageLoadError(t *testing.T) {
 		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
 	}
 
-	_, err := client.ImageLoad(context.Background(), nil, true)
+	_, err := client.ImageLoad(context.Background(), nil, types.ImageLoadOptions{Quiet: true})
 	if err == nil || err.Error() != "Error response from daemon: Server error" {
 		t.Fatalf("expected a Server Error, got %v", err)
 	}
 }
 
 func TestImageLoad(t *testing.T) {
-	expectedURL := "/images/load"
+	expectedURL := "/v1.26/images/load"
 	expectedInput := "inputBody"
 	expectedOutput := "outputBody"
 	loadCases := []struct {
-		quiet                bool
+		options              types.ImageLoadOptions
 		responseContentType  string
 		expectedResponseJSON bool
 		expectedQueryParams  map[string]string
 	}{
 		{
-			quiet:                false,
+			options:              types.ImageLoadOptions{Quiet: false},
 			responseContentType:  "text/plain",
 			expectedResponseJSON: false,
 			expectedQueryParams: map[string]string{
@@ -41,16 +42,26 @@ func TestImageLoad(t *testing.T) {
 			},
 		},
 		{
-			quiet:                true,
+			options:              types.ImageLoadOptions{Quiet: true},
 			responseContentType:  "application/json",
 			expectedResponseJSON: true,
 			expectedQueryParams: map[string]string{
 				"quiet": "1",
 			},
 		},
+		{
+			options:              types.ImageLoadOptions{Quiet: true, VerifyKey: "public key"},
+			responseContentType:  "application/json",
+			expectedResponseJSON: true,
+			expectedQueryParams: map[string]string{
+				"quiet":     "1",
+				"verifykey": "public key",
+			},
+		},
 	}
 	for _, loadCase := range loadCases {
 		client := &Client{
+			version: "1.26",
 			client: newMockClient(func(req *http.Request) (*http.Response, error) {
 				if !strings.HasPrefix(req.URL.Path, expectedURL) {
 					return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
@@ -77,7 +88,7 @@ func TestImageLoad(t *testing.T) {
 		}
 
 		input := bytes.NewReader([]byte(expectedInput))
-		imageLoadResponse, err := client.ImageLoad(context.Background(), input, loadCase.quiet)
+		imageLoadResponse, err := client.ImageLoad(context.Background(), input, loadCase.options)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -9,8 +9,9 @@ import (
 	"golang.org/x/net/context"
 )
 
-// ImagesPrune requests the daemon to delete unused data
-func (cli *Client) ImagesPrune(ctx context.Context, pruneFilters filters.Args) (types.ImagesPruneReport, error) {
+// ImagesPrune requests the daemon to delete unused data. If dryRun is true,
+// nothing is deleted, and the report lists what would be deleted.
+func (cli *Client) ImagesPrune(ctx context.Context, pruneFilters filters.Args, dryRun bool) (types.ImagesPruneReport, error) {
 	var report types.ImagesPruneReport
 
 	if err := cli.NewVersionError("1.25", "image prune"); err != nil {
@@ -22,6 +23,13 @@ func (cli *Client) ImagesPrune(ctx context.Context, pruneFilters filters.Args) (
 		return report, err
 	}
 
+	if dryRun {
+		if err := cli.NewVersionError("1.26", "image prune dry run"); err != nil {
+			return report, err
+		}
+		query.Set("dryrun", "1")
+	}
+
 	serverResp, err := cli.post(ctx, "/images/prune", query, nil, nil)
 	if err != nil {
 		return report, err
@@ -23,7 +23,7 @@ func TestImagesPruneError(t *testing.T) {
 
 	filters := filters.NewArgs()
 
-	_, err := client.ImagesPrune(context.Background(), filters)
+	_, err := client.ImagesPrune(context.Background(), filters, false)
 	assert.Error(t, err, "Error response from daemon: Server error")
 }
 
@@ -98,9 +98,51 @@ func TestImagesPrune(t *testing.T) {
 			version: "1.25",
 		}
 
-		report, err := client.ImagesPrune(context.Background(), listCase.filters)
+		report, err := client.ImagesPrune(context.Background(), listCase.filters, false)
 		assert.NilError(t, err)
 		assert.Equal(t, len(report.ImagesDeleted), 2)
 		assert.Equal(t, report.SpaceReclaimed, uint64(9999))
 	}
 }
+
+func TestImagesPruneDryRun(t *testing.T) {
+	client := &Client{
+		client:  newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+		version: "1.25",
+	}
+	_, err := client.ImagesPrune(context.Background(), filters.NewArgs(), true)
+	assert.Error(t, err, `"image prune dry run" requires API version 1.26, but the Docker server is version 1.25`)
+
+	client = &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if dryRun := req.URL.Query().Get("dryrun"); dryRun != "1" {
+				return nil, fmt.Errorf("dryrun not set in URL query properly. Expected '1', got %s", dryRun)
+			}
+			content, err := json.Marshal(types.ImagesPruneReport{
+				ImagesDeleted: []types.ImageDeleteResponseItem{
+					{
+						Deleted: "image_id1",
+					},
+					{
+						Deleted: "layer_id1",
+						Size:    9999,
+					},
+				},
+				SpaceReclaimed: 9999,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(content)),
+			}, nil
+		}),
+		version: "1.26",
+	}
+	report, err := client.ImagesPrune(context.Background(), filters.NewArgs(), true)
+	assert.NilError(t, err)
+	assert.Equal(t, len(report.ImagesDeleted), 2)
+	assert.Equal(t, report.ImagesDeleted[1].Size, int64(9999))
+	assert.Equal(t, report.SpaceReclaimed, uint64(9999))
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"golang.org/x/net/context"
 
@@ -33,6 +34,12 @@ func (cli *Client) ImagePush(ctx context.Context, ref string, options types.Imag
 
 	query := url.Values{}
 	query.Set("tag", tag)
+	if options.CompressionLevel != nil {
+		if err := cli.NewVersionError("1.26", "compression level"); err != nil {
+			return nil, err
+		}
+		query.Set("compressionlevel", strconv.Itoa(*options.CompressionLevel))
+	}
 
 	resp, err := cli.tryImagePush(ctx, distributionRef.Name(), query, options.RegistryAuth)
 	if resp.statusCode == http.StatusUnauthorized && options.PrivilegeFunc != nil {
@@ -178,3 +178,28 @@ func TestImagePushWithoutErrors(t *testing.T) {
 		}
 	}
 }
+
+func TestImagePushCompressionLevel(t *testing.T) {
+	level := 1
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if compressionLevel := req.URL.Query().Get("compressionlevel"); compressionLevel != "1" {
+				return nil, fmt.Errorf("compressionlevel not set in URL query properly. Expected '1', got %s", compressionLevel)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte("hello world"))),
+			}, nil
+		}),
+		version: "1.26",
+	}
+	if _, err := client.ImagePush(context.Background(), "myimage:tag", types.ImagePushOptions{CompressionLevel: &level}); err != nil {
+		t.Fatal(err)
+	}
+
+	client.version = "1.25"
+	_, err := client.ImagePush(context.Background(), "myimage:tag", types.ImagePushOptions{CompressionLevel: &level})
+	if err == nil || !strings.Contains(err.Error(), `"compression level" requires API version 1.26`) {
+		t.Fatalf("expected a version error, got %v", err)
+	}
+}
@@ -18,6 +18,12 @@ func (cli *Client) ImageRemove(ctx context.Context, imageID string, options type
 	if !options.PruneChildren {
 		query.Set("noprune", "1")
 	}
+	if options.DryRun {
+		if err := cli.NewVersionError("1.26", "image remove dry run"); err != nil {
+			return nil, err
+		}
+		query.Set("dryrun", "1")
+	}
 
 	resp, err := cli.delete(ctx, "/images/"+imageID, query, nil)
 	if err != nil {
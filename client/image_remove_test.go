@@ -25,10 +25,11 @@ func TestImageRemoveError(t *testing.T) {
 }
 
 func TestImageRemove(t *testing.T) {
-	expectedURL := "/images/image_id"
+	expectedURL := "/v1.26/images/image_id"
 	removeCases := []struct {
 		force               bool
 		pruneChildren       bool
+		dryRun              bool
 		expectedQueryParams map[string]string
 	}{
 		{
@@ -37,6 +38,7 @@ func TestImageRemove(t *testing.T) {
 			expectedQueryParams: map[string]string{
 				"force":   "",
 				"noprune": "1",
+				"dryrun":  "",
 			},
 		}, {
 			force:         true,
@@ -44,6 +46,15 @@ func TestImageRemove(t *testing.T) {
 			expectedQueryParams: map[string]string{
 				"force":   "1",
 				"noprune": "",
+				"dryrun":  "",
+			},
+		}, {
+			pruneChildren: true,
+			dryRun:        true,
+			expectedQueryParams: map[string]string{
+				"force":   "",
+				"noprune": "",
+				"dryrun":  "1",
 			},
 		},
 	}
@@ -80,10 +91,12 @@ func TestImageRemove(t *testing.T) {
 					Body:       ioutil.NopCloser(bytes.NewReader(b)),
 				}, nil
 			}),
+			version: "1.26",
 		}
 		imageDeletes, err := client.ImageRemove(context.Background(), "image_id", types.ImageRemoveOptions{
 			Force:         removeCase.force,
 			PruneChildren: removeCase.pruneChildren,
+			DryRun:        removeCase.dryRun,
 		})
 		if err != nil {
 			t.Fatal(err)
@@ -0,0 +1,37 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// SystemFsck requests the daemon to verify the integrity of its layers, and
+// to repair them if options.Repair is true.
+func (cli *Client) SystemFsck(ctx context.Context, options types.FsckOptions) (types.FsckReport, error) {
+	var report types.FsckReport
+
+	if err := cli.NewVersionError("1.26", "system fsck"); err != nil {
+		return report, err
+	}
+
+	query := url.Values{}
+	if options.Repair {
+		query.Set("repair", "1")
+	}
+
+	serverResp, err := cli.post(ctx, "/system/fsck", query, nil, nil)
+	if err != nil {
+		return report, err
+	}
+	defer ensureReaderClosed(serverResp)
+
+	if err := json.NewDecoder(serverResp.body).Decode(&report); err != nil {
+		return report, fmt.Errorf("Error retrieving fsck report: %v", err)
+	}
+
+	return report, nil
+}
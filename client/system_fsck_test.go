@@ -0,0 +1,80 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/testutil/assert"
+	"golang.org/x/net/context"
+)
+
+func TestSystemFsckError(t *testing.T) {
+	client := &Client{
+		client:  newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+		version: "1.26",
+	}
+
+	_, err := client.SystemFsck(context.Background(), types.FsckOptions{})
+	assert.Error(t, err, "Error response from daemon: Server error")
+}
+
+func TestSystemFsckVersion(t *testing.T) {
+	client := &Client{
+		client:  newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+		version: "1.25",
+	}
+
+	_, err := client.SystemFsck(context.Background(), types.FsckOptions{})
+	assert.Error(t, err, `"system fsck" requires API version 1.26`)
+}
+
+func TestSystemFsck(t *testing.T) {
+	expectedURL := "/v1.26/system/fsck"
+
+	cases := []struct {
+		options        types.FsckOptions
+		expectedRepair string
+	}{
+		{options: types.FsckOptions{}, expectedRepair: ""},
+		{options: types.FsckOptions{Repair: true}, expectedRepair: "1"},
+	}
+	for _, c := range cases {
+		client := &Client{
+			client: newMockClient(func(req *http.Request) (*http.Response, error) {
+				if !strings.HasPrefix(req.URL.Path, expectedURL) {
+					return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+				}
+				if req.Method != "POST" {
+					return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+				}
+				assert.Equal(t, req.URL.Query().Get("repair"), c.expectedRepair)
+				content, err := json.Marshal(types.FsckReport{
+					CorruptLayers: []types.FsckCorruptLayer{
+						{ChainID: "sha256:chain", DiffID: "sha256:diff", Error: "corrupt", Images: []string{"sha256:image"}},
+					},
+					DanglingLayers: []string{"dangling"},
+				})
+				if err != nil {
+					return nil, err
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(bytes.NewReader(content)),
+				}, nil
+			}),
+			version: "1.26",
+		}
+
+		report, err := client.SystemFsck(context.Background(), c.options)
+		assert.NilError(t, err)
+		assert.Equal(t, len(report.CorruptLayers), 1)
+		assert.Equal(t, report.CorruptLayers[0].Images[0], "sha256:image")
+		assert.DeepEqual(t, report.DanglingLayers, []string{"dangling"})
+	}
+}
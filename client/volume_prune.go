@@ -9,8 +9,9 @@ import (
 	"golang.org/x/net/context"
 )
 
-// VolumesPrune requests the daemon to delete unused data
-func (cli *Client) VolumesPrune(ctx context.Context, pruneFilters filters.Args) (types.VolumesPruneReport, error) {
+// VolumesPrune requests the daemon to delete unused data. If dryRun is true,
+// nothing is deleted, and the report lists what would be deleted.
+func (cli *Client) VolumesPrune(ctx context.Context, pruneFilters filters.Args, dryRun bool) (types.VolumesPruneReport, error) {
 	var report types.VolumesPruneReport
 
 	if err := cli.NewVersionError("1.25", "volume prune"); err != nil {
@@ -22,6 +23,13 @@ func (cli *Client) VolumesPrune(ctx context.Context, pruneFilters filters.Args)
 		return report, err
 	}
 
+	if dryRun {
+		if err := cli.NewVersionError("1.26", "volume prune dry run"); err != nil {
+			return report, err
+		}
+		query.Set("dryrun", "1")
+	}
+
 	serverResp, err := cli.post(ctx, "/volumes/prune", query, nil, nil)
 	if err != nil {
 		return report, err
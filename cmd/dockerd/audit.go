@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/RackSec/srslog"
+	"github.com/docker/docker/api/server/middleware"
+	"github.com/docker/docker/daemon"
+	"github.com/docker/docker/daemon/logger/loggerutils"
+	"github.com/docker/go-units"
+)
+
+const auditSyslogTag = "dockerd-audit"
+
+// newAuditMiddleware creates the middleware writing the audit log
+// configured with the --audit-log and --audit-log-opt flags. It returns a
+// nil middleware if the audit log is disabled.
+func newAuditMiddleware(config *daemon.Config) (*middleware.AuditMiddleware, io.Closer, error) {
+	if config.AuditLog == "" {
+		return nil, nil, nil
+	}
+
+	var redact []string
+	for key, value := range config.AuditLogOpts {
+		switch key {
+		case "max-size", "max-file", "syslog-address":
+		case "redact":
+			redact = strings.Split(value, ",")
+		default:
+			return nil, nil, fmt.Errorf("unknown audit log opt '%s'", key)
+		}
+	}
+
+	var (
+		w   io.WriteCloser
+		err error
+	)
+	if config.AuditLog == "syslog" {
+		w, err = newAuditSyslogWriter(config.AuditLogOpts)
+	} else {
+		w, err = newAuditFileWriter(config.AuditLog, config.AuditLogOpts)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open audit log: %v", err)
+	}
+
+	m := middleware.NewAuditMiddleware(w, redact)
+	return &m, w, nil
+}
+
+func newAuditFileWriter(path string, opts map[string]string) (io.WriteCloser, error) {
+	var capacity int64 = -1
+	if maxSize, ok := opts["max-size"]; ok {
+		var err error
+		capacity, err = units.FromHumanSize(maxSize)
+		if err != nil {
+			return nil, err
+		}
+	}
+	maxFiles := 1
+	if maxFile, ok := opts["max-file"]; ok {
+		var err error
+		maxFiles, err = strconv.Atoi(maxFile)
+		if err != nil {
+			return nil, err
+		}
+		if maxFiles < 1 {
+			return nil, fmt.Errorf("max-file cannot be less than 1")
+		}
+	}
+	return loggerutils.NewRotateFileWriter(path, capacity, maxFiles)
+}
+
+func newAuditSyslogWriter(opts map[string]string) (io.WriteCloser, error) {
+	var network, address string
+	if addr, ok := opts["syslog-address"]; ok {
+		u, err := url.Parse(addr)
+		if err != nil {
+			return nil, err
+		}
+		switch u.Scheme {
+		case "unix", "unixgram":
+			network, address = u.Scheme, u.Path
+		case "tcp", "udp":
+			network, address = u.Scheme, u.Host
+		default:
+			return nil, fmt.Errorf("unsupported syslog-address scheme: %s", u.Scheme)
+		}
+	}
+	return srslog.Dial(network, address, srslog.LOG_INFO|srslog.LOG_AUTHPRIV, auditSyslogTag)
+}
@@ -61,6 +61,15 @@ type DaemonCli struct {
 	api             *apiserver.Server
 	d               *daemon.Daemon
 	authzMiddleware *authorization.Middleware // authzMiddleware enables to dynamically reload the authorization plugins
+	auditLog        io.Closer                 // auditLog is closed when the daemon shuts down
+	listeners       []configuredListener      // listeners holds the listeners configured in the configuration file
+}
+
+// configuredListener associates the configuration of a listener in the
+// configuration file with the one of the API server.
+type configuredListener struct {
+	config       daemon.ListenerConfig
+	serverConfig *apiserver.ListenerConfig
 }
 
 // NewDaemonCli returns a daemon CLI
@@ -185,17 +194,7 @@ func (cli *DaemonCli) start(opts daemonOptions) (err error) {
 	}
 
 	if cli.Config.TLS {
-		tlsOptions := tlsconfig.Options{
-			CAFile:   cli.Config.CommonTLSOptions.CAFile,
-			CertFile: cli.Config.CommonTLSOptions.CertFile,
-			KeyFile:  cli.Config.CommonTLSOptions.KeyFile,
-		}
-
-		if cli.Config.TLSVerify {
-			// server requires and verifies client's certificate
-			tlsOptions.ClientAuth = tls.RequireAndVerifyClientCert
-		}
-		tlsConfig, err := tlsconfig.Server(tlsOptions)
+		tlsConfig, err := newServerTLSConfig(cli.Config.CommonTLSOptions, cli.Config.TLSVerify)
 		if err != nil {
 			return err
 		}
@@ -215,32 +214,32 @@ func (cli *DaemonCli) start(opts daemonOptions) (err error) {
 			return fmt.Errorf("error parsing -H %s : %v", cli.Config.Hosts[i], err)
 		}
 
-		protoAddr := cli.Config.Hosts[i]
-		protoAddrParts := strings.SplitN(protoAddr, "://", 2)
-		if len(protoAddrParts) != 2 {
-			return fmt.Errorf("bad format %s, expected PROTO://ADDR", protoAddr)
+		if err := acceptHost(api, cli.Config.Hosts[i], serverConfig.SocketGroup, serverConfig.TLSConfig, nil); err != nil {
+			return err
 		}
+	}
 
-		proto := protoAddrParts[0]
-		addr := protoAddrParts[1]
-
-		// It's a bad idea to bind to TCP without tlsverify.
-		if proto == "tcp" && (serverConfig.TLSConfig == nil || serverConfig.TLSConfig.ClientAuth != tls.RequireAndVerifyClientCert) {
-			logrus.Warn("[!] DON'T BIND ON ANY IP ADDRESS WITHOUT setting --tlsverify IF YOU DON'T KNOW WHAT YOU'RE DOING [!]")
-		}
-		ls, err := listeners.Init(proto, addr, serverConfig.SocketGroup, serverConfig.TLSConfig)
+	for _, l := range cli.Config.Listeners {
+		tlsEnabled := l.TLS || l.TLSVerify
+		host, err := dopts.ParseHost(tlsEnabled, l.Host)
 		if err != nil {
-			return err
+			return fmt.Errorf("error parsing listener %s : %v", l.Host, err)
 		}
-		ls = wrapListeners(proto, ls)
-		// If we're binding to a TCP port, make sure that a container doesn't try to use it.
-		if proto == "tcp" {
-			if err := allocateDaemonPort(addr); err != nil {
-				return err
+
+		var tlsConfig *tls.Config
+		if tlsEnabled {
+			if tlsConfig, err = newServerTLSConfig(l.CommonTLSOptions, l.TLSVerify); err != nil {
+				return fmt.Errorf("error configuring TLS of listener %s : %v", host, err)
 			}
 		}
-		logrus.Debugf("Listener created for HTTP on %s (%s)", proto, addr)
-		api.Accept(addr, ls...)
+
+		listenerConfig := &apiserver.ListenerConfig{
+			ReadOnly: l.ReadOnly,
+		}
+		if err := acceptHost(api, host, serverConfig.SocketGroup, tlsConfig, listenerConfig); err != nil {
+			return err
+		}
+		cli.listeners = append(cli.listeners, configuredListener{config: l, serverConfig: listenerConfig})
 	}
 
 	if err := migrateKey(cli.Config); err != nil {
@@ -327,6 +326,9 @@ func (cli *DaemonCli) start(opts daemonOptions) (err error) {
 	c.Cleanup()
 	shutdownDaemon(d)
 	containerdRemote.Cleanup()
+	if cli.auditLog != nil {
+		cli.auditLog.Close()
+	}
 	if errAPI != nil {
 		return fmt.Errorf("Shutting down due to ServeAPI error: %v", errAPI)
 	}
@@ -334,6 +336,53 @@ func (cli *DaemonCli) start(opts daemonOptions) (err error) {
 	return nil
 }
 
+// newServerTLSConfig returns the TLS configuration of an API listener. If
+// verify is true, the listener requires and verifies the client certificates.
+func newServerTLSConfig(options daemon.CommonTLSOptions, verify bool) (*tls.Config, error) {
+	tlsOptions := tlsconfig.Options{
+		CAFile:   options.CAFile,
+		CertFile: options.CertFile,
+		KeyFile:  options.KeyFile,
+	}
+
+	if verify {
+		// server requires and verifies client's certificate
+		tlsOptions.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsconfig.Server(tlsOptions)
+}
+
+// acceptHost creates the listeners of a PROTO://ADDR host and adds them to
+// the API server, with the given configuration of the listener, if any.
+func acceptHost(api *apiserver.Server, protoAddr, socketGroup string, tlsConfig *tls.Config, cfg *apiserver.ListenerConfig) error {
+	protoAddrParts := strings.SplitN(protoAddr, "://", 2)
+	if len(protoAddrParts) != 2 {
+		return fmt.Errorf("bad format %s, expected PROTO://ADDR", protoAddr)
+	}
+
+	proto := protoAddrParts[0]
+	addr := protoAddrParts[1]
+
+	// It's a bad idea to bind to TCP without tlsverify.
+	if proto == "tcp" && (tlsConfig == nil || tlsConfig.ClientAuth != tls.RequireAndVerifyClientCert) {
+		logrus.Warn("[!] DON'T BIND ON ANY IP ADDRESS WITHOUT setting --tlsverify IF YOU DON'T KNOW WHAT YOU'RE DOING [!]")
+	}
+	ls, err := listeners.Init(proto, addr, socketGroup, tlsConfig)
+	if err != nil {
+		return err
+	}
+	ls = wrapListeners(proto, ls)
+	// If we're binding to a TCP port, make sure that a container doesn't try to use it.
+	if proto == "tcp" {
+		if err := allocateDaemonPort(addr); err != nil {
+			return err
+		}
+	}
+	logrus.Debugf("Listener created for HTTP on %s (%s)", proto, addr)
+	api.AcceptWithConfig(addr, cfg, ls...)
+	return nil
+}
+
 func (cli *DaemonCli) reloadConfig() {
 	reload := func(config *daemon.Config) {
 
@@ -344,6 +393,12 @@ func (cli *DaemonCli) reloadConfig() {
 		}
 		cli.authzMiddleware.SetPlugins(config.AuthorizationPlugins)
 
+		// Reload the authorization policy, unless it was set with the
+		// --authorization-policy flag
+		if config.IsValueSet("authorization-policy") || !cli.flags.Changed("authorization-policy") {
+			cli.authzMiddleware.SetPolicyPlugin(cli.newPolicyPlugin(config.AuthorizationPolicy))
+		}
+
 		if err := cli.d.Reload(config); err != nil {
 			logrus.Errorf("Error reconfiguring the daemon: %v", err)
 			return
@@ -508,10 +563,48 @@ func (cli *DaemonCli) initMiddlewares(s *apiserver.Server, cfg *apiserver.Config
 		return fmt.Errorf("Error validating authorization plugin: %v", err)
 	}
 	cli.authzMiddleware = authorization.NewMiddleware(cli.Config.AuthorizationPlugins, cli.d.PluginStore)
+	cli.authzMiddleware.SetPolicyPlugin(cli.newPolicyPlugin(cli.Config.AuthorizationPolicy))
 	s.UseMiddleware(cli.authzMiddleware)
+
+	// The listeners configured with their own authorization plugins or
+	// policy consult them after the ones of the daemon
+	for _, l := range cli.listeners {
+		if !l.config.HasAuthorization() {
+			continue
+		}
+		if err := validateAuthzPlugins(l.config.AuthorizationPlugins, cli.d.PluginStore); err != nil {
+			return fmt.Errorf("Error validating authorization plugin of listener %s: %v", l.config.Host, err)
+		}
+		authz := authorization.NewMiddleware(l.config.AuthorizationPlugins, cli.d.PluginStore)
+		authz.SetPolicyPlugin(cli.newPolicyPlugin(l.config.AuthorizationPolicy))
+		l.serverConfig.Middlewares = append(l.serverConfig.Middlewares, authz)
+	}
+
+	// The audit middleware is used last so that it also records the
+	// requests denied by the authorization middleware
+	audit, auditLog, err := newAuditMiddleware(cli.Config)
+	if err != nil {
+		return err
+	}
+	if audit != nil {
+		cli.auditLog = auditLog
+		s.UseMiddleware(*audit)
+	}
 	return nil
 }
 
+// newPolicyPlugin returns the built-in authorization plugin enforcing
+// policy, or nil if policy is nil.
+func (cli *DaemonCli) newPolicyPlugin(policy *authorization.Policy) authorization.Plugin {
+	if policy == nil {
+		return nil
+	}
+	return authorization.NewPolicyPlugin(policy, cli.d.ResourceLabels, func(req *authorization.Request, reason string) {
+		logrus.Warnf("Authorization policy denied %s %s: %s", req.RequestMethod, req.RequestURI, reason)
+		cli.d.LogAuthorizationDenial(req.User, req.RequestMethod, req.RequestURI, reason)
+	})
+}
+
 // validates that the plugins requested with the --authorization-plugin flag are valid AuthzDriver
 // plugins present on the host and available to the daemon
 func validateAuthzPlugins(requestedPlugins []string, pg plugingetter.PluginGetter) error {
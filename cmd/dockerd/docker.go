@@ -50,6 +50,7 @@ func newDaemonCommand() *cobra.Command {
 	opts.common.InstallFlags(flags)
 	opts.daemonConfig.InstallFlags(flags)
 	installServiceFlags(flags)
+	installStorageCheckCommand(cmd)
 
 	return cmd
 }
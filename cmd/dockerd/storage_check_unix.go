@@ -0,0 +1,63 @@
+// +build linux freebsd
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/daemon/graphdriver/storagecheck"
+	"github.com/docker/docker/opts"
+	"github.com/spf13/cobra"
+)
+
+const defaultStorageCheckRoot = "/var/lib/docker"
+
+type storageCheckOptions struct {
+	root          string
+	driver        string
+	driverOptions opts.ListOpts
+	bench         bool
+}
+
+func installStorageCheckCommand(cmd *cobra.Command) {
+	opts := storageCheckOptions{
+		driverOptions: opts.NewListOpts(nil),
+	}
+
+	checkCmd := &cobra.Command{
+		Use:   "storage-check [OPTIONS]",
+		Short: "Run the conformance tests and the benchmarks of a storage driver",
+		Args:  cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runStorageCheck(opts)
+		},
+	}
+
+	flags := checkCmd.Flags()
+	flags.StringVarP(&opts.root, "graph", "g", defaultStorageCheckRoot, "Root of the Docker runtime in which the driver is tested")
+	flags.StringVarP(&opts.driver, "storage-driver", "s", "", "Storage driver to test")
+	flags.Var(&opts.driverOptions, "storage-opt", "Storage driver options")
+	flags.BoolVar(&opts.bench, "bench", false, "Run the benchmarks after the conformance tests")
+
+	cmd.AddCommand(checkCmd)
+}
+
+func runStorageCheck(opts storageCheckOptions) error {
+	if opts.driver == "" {
+		return fmt.Errorf("a storage driver must be specified with --storage-driver")
+	}
+
+	// The drivers are created in the root directory so that the filesystem
+	// backing the daemon is the one which is tested.
+	root, err := filepath.Abs(opts.root)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(root, "storage-check")
+	defer os.RemoveAll(dir)
+
+	return storagecheck.Run(os.Stdout, dir, opts.driver, opts.driverOptions.GetAll(), opts.bench)
+}
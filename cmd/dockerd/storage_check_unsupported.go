@@ -0,0 +1,10 @@
+// +build !linux,!freebsd
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func installStorageCheckCommand(cmd *cobra.Command) {
+}
@@ -84,6 +84,7 @@ type CommonContainer struct {
 	RestartCount           int
 	HasBeenStartedBefore   bool
 	HasBeenManuallyStopped bool // used for unless-stopped restart policy
+	HasInit                bool // the init binary is mounted at /dev/init since the container was last started
 	MountPoints            map[string]*volume.MountPoint
 	HostConfig             *containertypes.HostConfig `json:"-"` // do not serialize the host config in the json, otherwise we'll make the container unportable
 	ExecCommands           *exec.Store                `json:"-"`
@@ -421,6 +422,13 @@ func (container *Container) UnmountVolumes(volumeEventLog func(name, action stri
 			}
 			volumeEventLog(volumeMount.Volume.Name(), "unmount", attributes)
 		}
+		// The ID of an image mount is kept, as the image may still be mounted
+		// for the container, for example if it is running
+		if volumeMount.Image != nil && volumeMount.ID != "" {
+			if err := volumeMount.Image.Unmount(volumeMount.ID); err != nil {
+				errors = append(errors, err.Error())
+			}
+		}
 	}
 	if len(errors) > 0 {
 		return fmt.Errorf("error while unmounting volumes for container %s: %s", container.ID, strings.Join(errors, "; "))
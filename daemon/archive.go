@@ -2,18 +2,22 @@ package daemon
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/backend"
 	"github.com/docker/docker/builder"
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/chrootarchive"
 	"github.com/docker/docker/pkg/idtools"
 	"github.com/docker/docker/pkg/ioutils"
+	"github.com/docker/docker/pkg/progress"
+	"github.com/docker/docker/pkg/streamformatter"
 	"github.com/docker/docker/pkg/system"
 )
 
@@ -63,16 +67,167 @@ func (daemon *Daemon) ContainerArchivePath(name string, path string) (content io
 // ContainerExtractToDir extracts the given archive to the specified location
 // in the filesystem of the container identified by the given name. The given
 // path must be of a directory in the container. If it is not, the error will
-// be ErrExtractPointNotDirectory. If noOverwriteDirNonDir is true then it will
-// be an error if unpacking the given content would cause an existing directory
-// to be replaced with a non-directory and vice versa.
-func (daemon *Daemon) ContainerExtractToDir(name, path string, noOverwriteDirNonDir bool, content io.Reader) error {
+// be ErrExtractPointNotDirectory. If copyUIDGID is true then the ownership of
+// the archived files is preserved, otherwise they are owned by root. If
+// noOverwriteDirNonDir is true then it will be an error if unpacking the
+// given content would cause an existing directory to be replaced with a
+// non-directory and vice versa.
+func (daemon *Daemon) ContainerExtractToDir(name, path string, copyUIDGID, noOverwriteDirNonDir bool, content io.Reader) error {
 	container, err := daemon.GetContainer(name)
 	if err != nil {
 		return err
 	}
 
-	return daemon.containerExtractToDir(container, path, noOverwriteDirNonDir, content)
+	return daemon.containerExtractToDir(container, path, copyUIDGID, noOverwriteDirNonDir, content)
+}
+
+// ContainerCopyBetween copies the filesystem resource at config.SrcPath in
+// the container config.SrcContainer to config.DstPath in the container
+// identified by the given name. The archive of the resource is streamed from
+// a container to the other, and the progress of the copy is written to
+// config.OutStream.
+func (daemon *Daemon) ContainerCopyBetween(name string, config *backend.ContainerCopyConfig) error {
+	src, err := daemon.GetContainer(config.SrcContainer)
+	if err != nil {
+		return err
+	}
+	dst, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+	if src.ID == dst.ID {
+		return fmt.Errorf("cannot copy from container %s to itself", src.ID)
+	}
+
+	// Lock the containers in a consistent order, so that copies between the
+	// same containers in opposite directions don't deadlock.
+	first, second := src, dst
+	if second.ID < first.ID {
+		first, second = second, first
+	}
+	first.Lock()
+	defer first.Unlock()
+	second.Lock()
+	defer second.Unlock()
+
+	for _, c := range []*container.Container{src, dst} {
+		if err := daemon.Mount(c); err != nil {
+			return err
+		}
+		defer daemon.Unmount(c)
+
+		err := daemon.mountVolumes(c)
+		defer c.DetachAndUnmount(daemon.LogVolumeEvent)
+		if err != nil {
+			return err
+		}
+	}
+
+	// This follows what `docker cp` does when copying from a container to
+	// the local filesystem, and from the local filesystem to a container.
+	srcPath := config.SrcPath
+	var rebaseName string
+	if config.FollowLink {
+		srcStat, err := statContainerPath(src, srcPath)
+		if err == nil && srcStat.Mode&os.ModeSymlink != 0 {
+			linkTarget := srcStat.LinkTarget
+			if !system.IsAbs(linkTarget) {
+				// Join with the parent directory.
+				srcParent, _ := archive.SplitPathDirEntry(srcPath)
+				linkTarget = filepath.Join(srcParent, linkTarget)
+			}
+			srcPath, rebaseName = archive.GetRebaseName(srcPath, linkTarget)
+		}
+	}
+
+	resolvedPath, absPath, err := src.ResolvePath(srcPath)
+	if err != nil {
+		return err
+	}
+	srcStat, err := src.StatPath(resolvedPath, absPath)
+	if err != nil {
+		return err
+	}
+	data, err := archive.TarResourceRebase(resolvedPath, filepath.Base(absPath))
+	if err != nil {
+		return err
+	}
+	defer data.Close()
+
+	srcInfo := archive.CopyInfo{
+		Path:       srcPath,
+		Exists:     true,
+		IsDir:      srcStat.Mode.IsDir(),
+		RebaseName: rebaseName,
+	}
+
+	var content io.ReadCloser = data
+	if rebaseName != "" {
+		_, srcBase := archive.SplitPathDirEntry(srcInfo.Path)
+		content = archive.RebaseArchiveEntries(content, srcBase, rebaseName)
+	}
+
+	dstInfo := archive.CopyInfo{Path: config.DstPath}
+	dstStat, err := statContainerPath(dst, config.DstPath)
+	if err == nil && dstStat.Mode&os.ModeSymlink != 0 {
+		linkTarget := dstStat.LinkTarget
+		if !system.IsAbs(linkTarget) {
+			// Join with the parent directory.
+			dstParent, _ := archive.SplitPathDirEntry(config.DstPath)
+			linkTarget = filepath.Join(dstParent, linkTarget)
+		}
+		dstInfo.Path = linkTarget
+		dstStat, err = statContainerPath(dst, linkTarget)
+	}
+	// Assume that the parent directory of a destination that can't be
+	// stat'ed exists, the extraction fails if it doesn't.
+	if err == nil {
+		dstInfo.Exists, dstInfo.IsDir = true, dstStat.Mode.IsDir()
+	}
+
+	dstDir, preparedArchive, err := archive.PrepareArchiveCopy(content, srcInfo, dstInfo)
+	if err != nil {
+		return err
+	}
+
+	// The size of a directory doesn't tell how large its archive is, the
+	// progress of its copy is reported without a total.
+	var size int64
+	if srcStat.Mode.IsRegular() {
+		size = srcStat.Size
+	}
+	progressOutput := streamformatter.NewJSONStreamFormatter().NewProgressOutput(config.OutStream, false)
+	preparedArchive = progress.NewProgressReader(preparedArchive, progressOutput, size, "", "Copying")
+	defer preparedArchive.Close()
+
+	// The archive holds the IDs of the files on the host, so they are not
+	// remapped when the ownership is preserved.
+	options := &archive.TarOptions{
+		NoOverwriteDirNonDir: config.NoOverwriteDirNonDir,
+	}
+	if !config.CopyUIDGID {
+		uid, gid := daemon.GetRemappedUIDGID()
+		options.ChownOpts = &archive.TarChownOptions{UID: uid, GID: gid}
+	}
+	if err := daemon.extractToDir(dst, dstDir, options, preparedArchive); err != nil {
+		return err
+	}
+
+	daemon.LogContainerEvent(src, "archive-path")
+	daemon.LogContainerEvent(dst, "extract-to-dir")
+
+	return nil
+}
+
+// statContainerPath stats the filesystem resource at the specified path in
+// the container, without evaluating its last element if it is a symbolic
+// link. The container must be locked, and its filesystem mounted.
+func statContainerPath(container *container.Container, path string) (*types.ContainerPathStat, error) {
+	resolvedPath, absPath, err := container.ResolvePath(path)
+	if err != nil {
+		return nil, err
+	}
+	return container.StatPath(resolvedPath, absPath)
 }
 
 // containerStatPath stats the filesystem resource at the specified path in this
@@ -171,10 +326,12 @@ func (daemon *Daemon) containerArchivePath(container *container.Container, path
 // containerExtractToDir extracts the given tar archive to the specified location in the
 // filesystem of this container. The given path must be of a directory in the
 // container. If it is not, the error will be ErrExtractPointNotDirectory. If
+// copyUIDGID is true then the ownership of the archived files is preserved,
+// mapped to the remapped root if user namespaces are enabled. If
 // noOverwriteDirNonDir is true then it will be an error if unpacking the
 // given content would cause an existing directory to be replaced with a non-
 // directory and vice versa.
-func (daemon *Daemon) containerExtractToDir(container *container.Container, path string, noOverwriteDirNonDir bool, content io.Reader) (err error) {
+func (daemon *Daemon) containerExtractToDir(container *container.Container, path string, copyUIDGID, noOverwriteDirNonDir bool, content io.Reader) (err error) {
 	container.Lock()
 	defer container.Unlock()
 
@@ -189,6 +346,30 @@ func (daemon *Daemon) containerExtractToDir(container *container.Container, path
 		return err
 	}
 
+	options := &archive.TarOptions{
+		NoOverwriteDirNonDir: noOverwriteDirNonDir,
+	}
+	if copyUIDGID {
+		options.UIDMaps, options.GIDMaps = daemon.GetUIDGIDMaps()
+	} else {
+		uid, gid := daemon.GetRemappedUIDGID()
+		options.ChownOpts = &archive.TarChownOptions{
+			UID: uid, GID: gid, // TODO: should all ownership be set to root (either real or remapped)?
+		}
+	}
+	if err := daemon.extractToDir(container, path, options, content); err != nil {
+		return err
+	}
+
+	daemon.LogContainerEvent(container, "extract-to-dir")
+
+	return nil
+}
+
+// extractToDir extracts the given tar archive to the specified location in the
+// filesystem of this container with the given options. The container must be
+// locked, and its filesystem and volumes mounted.
+func (daemon *Daemon) extractToDir(container *container.Container, path string, options *archive.TarOptions, content io.Reader) (err error) {
 	// Check if a drive letter supplied, it must be the system drive. No-op except on Windows
 	path, err = system.CheckSystemDriveAndRemoveDriveLetter(path)
 	if err != nil {
@@ -257,20 +438,7 @@ func (daemon *Daemon) containerExtractToDir(container *container.Container, path
 		return ErrRootFSReadOnly
 	}
 
-	uid, gid := daemon.GetRemappedUIDGID()
-	options := &archive.TarOptions{
-		NoOverwriteDirNonDir: noOverwriteDirNonDir,
-		ChownOpts: &archive.TarChownOptions{
-			UID: uid, GID: gid, // TODO: should all ownership be set to root (either real or remapped)?
-		},
-	}
-	if err := chrootarchive.Untar(content, resolvedPath, options); err != nil {
-		return err
-	}
-
-	daemon.LogContainerEvent(container, "extract-to-dir")
-
-	return nil
+	return chrootarchive.Untar(content, resolvedPath, options)
 }
 
 func (daemon *Daemon) containerCopy(container *container.Container, resource string) (rc io.ReadCloser, err error) {
@@ -0,0 +1,56 @@
+package daemon
+
+import (
+	"fmt"
+
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/volume"
+)
+
+// ResourceLabels returns the labels of the container, volume or network
+// with the given name or ID. It is used by the authorization policy to
+// match rules restricted to labelled resources.
+func (daemon *Daemon) ResourceLabels(typ, name string) (map[string]string, error) {
+	switch typ {
+	case "container":
+		c, err := daemon.GetContainer(name)
+		if err != nil {
+			return nil, err
+		}
+		return c.Config.Labels, nil
+	case "volume":
+		v, err := daemon.volumes.Get(name)
+		if err != nil {
+			return nil, err
+		}
+		if v, ok := v.(volume.DetailedVolume); ok {
+			return v.Labels(), nil
+		}
+		return nil, nil
+	case "network":
+		n, err := daemon.FindNetwork(name)
+		if err != nil {
+			return nil, err
+		}
+		return n.Info().Labels(), nil
+	}
+	return nil, fmt.Errorf("unknown resource type %s", typ)
+}
+
+// LogAuthorizationDenial generates a daemon event for an API request denied
+// by the authorization policy.
+func (daemon *Daemon) LogAuthorizationDenial(user, method, uri, reason string) {
+	if daemon.EventsService == nil {
+		return
+	}
+	actor := events.Actor{
+		ID: daemon.ID,
+		Attributes: map[string]string{
+			"user":   user,
+			"method": method,
+			"uri":    uri,
+			"reason": reason,
+		},
+	}
+	daemon.EventsService.Log("authorization-denied", events.DaemonEventType, actor)
+}
@@ -2,14 +2,21 @@ package daemon
 
 import (
 	"errors"
+	"fmt"
+	"io"
+	"os"
 	"runtime"
 	"time"
 
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
 	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/ioutils"
 )
 
-// ContainerChanges returns a list of container fs changes
-func (daemon *Daemon) ContainerChanges(name string) ([]archive.Change, error) {
+// ContainerChanges returns a list of container fs changes. If size is true,
+// the size of the added and modified files is returned as well.
+func (daemon *Daemon) ContainerChanges(name string, size bool) ([]containertypes.ContainerChangeResponseItem, error) {
 	start := time.Now()
 	container, err := daemon.GetContainer(name)
 	if err != nil {
@@ -26,6 +33,94 @@ func (daemon *Daemon) ContainerChanges(name string) ([]archive.Change, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	if size {
+		if err := daemon.Mount(container); err != nil {
+			return nil, err
+		}
+		defer daemon.Unmount(container)
+	}
+
+	changes := make([]containertypes.ContainerChangeResponseItem, 0, len(c))
+	for _, change := range c {
+		item := containertypes.ContainerChangeResponseItem{
+			Kind: uint8(change.Kind),
+			Path: change.Path,
+		}
+		if size && change.Kind != archive.ChangeDelete {
+			item.Size = changeSize(container, change.Path)
+		}
+		changes = append(changes, item)
+	}
 	containerActions.WithValues("changes").UpdateSince(start)
-	return c, nil
+	return changes, nil
+}
+
+// changeSize returns the size of the file at path in the filesystem of the
+// container, or 0 if it is not a regular file.
+func changeSize(container *container.Container, path string) int64 {
+	// Symbolic links are evaluated in the scope of the container's rootfs,
+	// except for the last element of the path.
+	resolvedPath, _, err := container.ResolvePath(path)
+	if err != nil {
+		return 0
+	}
+	fi, err := os.Lstat(resolvedPath)
+	if err != nil || !fi.Mode().IsRegular() {
+		return 0
+	}
+	return fi.Size()
+}
+
+// ContainerExportChanges writes a tar archive of the files of the container
+// that changed since it was created to the given writer. Deleted files are
+// represented by whiteout files, as in the layers of an image.
+func (daemon *Daemon) ContainerExportChanges(name string, out io.Writer) error {
+	if runtime.GOOS == "windows" {
+		return fmt.Errorf("the daemon on this platform does not support export of the changes of a container")
+	}
+
+	container, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+
+	data, err := daemon.containerExportChanges(container)
+	if err != nil {
+		return fmt.Errorf("Error exporting the changes of container %s: %v", name, err)
+	}
+	defer data.Close()
+
+	if _, err := io.Copy(out, data); err != nil {
+		return fmt.Errorf("Error exporting the changes of container %s: %v", name, err)
+	}
+	return nil
+}
+
+func (daemon *Daemon) containerExportChanges(container *container.Container) (io.ReadCloser, error) {
+	container.Lock()
+	defer container.Unlock()
+
+	changes, err := container.RWLayer.Changes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := daemon.Mount(container); err != nil {
+		return nil, err
+	}
+
+	uidMaps, gidMaps := daemon.GetUIDGIDMaps()
+	archive, err := archive.ExportChanges(container.BaseFS, changes, uidMaps, gidMaps)
+	if err != nil {
+		daemon.Unmount(container)
+		return nil, err
+	}
+	arch := ioutils.NewReadCloserWrapper(archive, func() error {
+		err := archive.Close()
+		daemon.Unmount(container)
+		return err
+	})
+	daemon.LogContainerEvent(container, "export-changes")
+	return arch, nil
 }
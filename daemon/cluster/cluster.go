@@ -47,6 +47,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -59,6 +60,7 @@ import (
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/network"
 	types "github.com/docker/docker/api/types/swarm"
+	timetypes "github.com/docker/docker/api/types/time"
 	"github.com/docker/docker/daemon/cluster/convert"
 	executorpkg "github.com/docker/docker/daemon/cluster/executor"
 	"github.com/docker/docker/daemon/logger"
@@ -1095,13 +1097,41 @@ func (c *Cluster) ServiceLogs(ctx context.Context, input string, config *backend
 		return err
 	}
 
+	return c.subscribeLogs(ctx, state, &swarmapi.LogSelector{ServiceIDs: []string{service.ID}}, config, started)
+}
+
+// TaskLogs collects the logs of a single task and writes them back to
+// `config.OutStream`
+func (c *Cluster) TaskLogs(ctx context.Context, input string, config *backend.ContainerLogsConfig, started chan struct{}) error {
+	c.mu.RLock()
+	state := c.currentNodeState()
+	if !state.IsActiveManager() {
+		c.mu.RUnlock()
+		return c.errNoManager(state)
+	}
+
+	task, err := getTask(ctx, state.controlClient, input)
+	if err != nil {
+		c.mu.RUnlock()
+		return err
+	}
+
+	return c.subscribeLogs(ctx, state, &swarmapi.LogSelector{TaskIDs: []string{task.ID}}, config, started)
+}
+
+// subscribeLogs streams the logs matching selector to `config.OutStream`. It
+// must be called with `c.mu` read locked, and releases the lock once the
+// stream has started.
+func (c *Cluster) subscribeLogs(ctx context.Context, state nodeState, selector *swarmapi.LogSelector, config *backend.ContainerLogsConfig, started chan struct{}) error {
+	options, err := logSubscriptionOptions(config)
+	if err != nil {
+		c.mu.RUnlock()
+		return err
+	}
+
 	stream, err := state.logsClient.SubscribeLogs(ctx, &swarmapi.SubscribeLogsRequest{
-		Selector: &swarmapi.LogSelector{
-			ServiceIDs: []string{service.ID},
-		},
-		Options: &swarmapi.LogSubscriptionOptions{
-			Follow: config.Follow,
-		},
+		Selector: selector,
+		Options:  options,
 	})
 	if err != nil {
 		c.mu.RUnlock()
@@ -1163,6 +1193,41 @@ func (c *Cluster) ServiceLogs(ctx context.Context, input string, config *backend
 	}
 }
 
+// logSubscriptionOptions converts the options of a logs request to the
+// options of a swarmkit log subscription.
+func logSubscriptionOptions(config *backend.ContainerLogsConfig) (*swarmapi.LogSubscriptionOptions, error) {
+	options := &swarmapi.LogSubscriptionOptions{
+		Follow: config.Follow,
+	}
+
+	if config.ShowStdout {
+		options.Streams = append(options.Streams, swarmapi.LogStreamStdout)
+	}
+	if config.ShowStderr {
+		options.Streams = append(options.Streams, swarmapi.LogStreamStderr)
+	}
+
+	// swarmkit counts the tail relative to the end of the stream, offset by
+	// one, see LogSubscriptionOptions. Anything but a number means all logs.
+	if tail, err := strconv.Atoi(config.Tail); err == nil && tail >= 0 {
+		options.Tail = int64(-tail - 1)
+	}
+
+	if config.Since != "" {
+		s, n, err := timetypes.ParseTimestamps(config.Since, 0)
+		if err != nil {
+			return nil, err
+		}
+		since, err := gogotypes.TimestampProto(time.Unix(s, n))
+		if err != nil {
+			return nil, err
+		}
+		options.Since = since
+	}
+
+	return options, nil
+}
+
 // GetNodes returns a list of all nodes known to a cluster.
 func (c *Cluster) GetNodes(options apitypes.NodeListOptions) ([]types.Node, error) {
 	c.mu.RLock()
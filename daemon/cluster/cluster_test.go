@@ -0,0 +1,65 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/backend"
+	swarmapi "github.com/docker/swarmkit/api"
+)
+
+func TestLogSubscriptionOptions(t *testing.T) {
+	options, err := logSubscriptionOptions(&backend.ContainerLogsConfig{
+		ContainerLogsOptions: types.ContainerLogsOptions{
+			Follow:     true,
+			ShowStdout: true,
+			Tail:       "10",
+			Since:      "1485000000.5",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !options.Follow {
+		t.Fatal("expected follow to be set")
+	}
+	if len(options.Streams) != 1 || options.Streams[0] != swarmapi.LogStreamStdout {
+		t.Fatalf("expected only the stdout stream, got %v", options.Streams)
+	}
+	if options.Tail != -11 {
+		t.Fatalf("expected tail -11, got %d", options.Tail)
+	}
+	if options.Since == nil || options.Since.Seconds != 1485000000 || options.Since.Nanos != 500000000 {
+		t.Fatalf("unexpected since %v", options.Since)
+	}
+
+	// all logs of both streams
+	options, err = logSubscriptionOptions(&backend.ContainerLogsConfig{
+		ContainerLogsOptions: types.ContainerLogsOptions{
+			ShowStdout: true,
+			ShowStderr: true,
+			Tail:       "all",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(options.Streams) != 2 {
+		t.Fatalf("expected both streams, got %v", options.Streams)
+	}
+	if options.Tail != 0 || options.Since != nil {
+		t.Fatalf("expected all logs, got tail %d and since %v", options.Tail, options.Since)
+	}
+}
+
+func TestLogSubscriptionOptionsInvalidSince(t *testing.T) {
+	_, err := logSubscriptionOptions(&backend.ContainerLogsConfig{
+		ContainerLogsOptions: types.ContainerLogsOptions{
+			ShowStdout: true,
+			Since:      "invalid",
+		},
+	})
+	if err == nil {
+		t.Fatal("expected an error for an invalid since")
+	}
+}
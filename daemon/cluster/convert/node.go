@@ -51,6 +51,13 @@ func NodeFromGRPC(n swarmapi.Node) types.Node {
 				node.Description.Engine.Plugins = append(node.Description.Engine.Plugins, types.PluginDescription{Type: plugin.Type, Name: plugin.Name})
 			}
 		}
+		if n.Description.DiskUsage != nil {
+			node.Description.DiskUsage = &types.NodeDiskUsage{
+				Images:     diskUsageSummaryFromGRPC(n.Description.DiskUsage.Images),
+				Containers: diskUsageSummaryFromGRPC(n.Description.DiskUsage.Containers),
+				Volumes:    diskUsageSummaryFromGRPC(n.Description.DiskUsage.Volumes),
+			}
+		}
 	}
 
 	//Manager
@@ -87,3 +94,15 @@ func NodeSpecToGRPC(s types.NodeSpec) (swarmapi.NodeSpec, error) {
 
 	return spec, nil
 }
+
+func diskUsageSummaryFromGRPC(s *swarmapi.DiskUsageSummary) types.DiskUsageSummary {
+	if s == nil {
+		return types.DiskUsageSummary{}
+	}
+	return types.DiskUsageSummary{
+		TotalCount:  s.TotalCount,
+		ActiveCount: s.ActiveCount,
+		Size:        s.SizeBytes,
+		Reclaimable: s.Reclaimable,
+	}
+}
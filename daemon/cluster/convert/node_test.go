@@ -0,0 +1,49 @@
+package convert
+
+import (
+	"testing"
+
+	swarmapi "github.com/docker/swarmkit/api"
+)
+
+func TestNodeFromGRPCDiskUsage(t *testing.T) {
+	node := NodeFromGRPC(swarmapi.Node{
+		ID: "id",
+		Description: &swarmapi.NodeDescription{
+			Engine: &swarmapi.EngineDescription{
+				Labels: map[string]string{"foo": "bar"},
+			},
+			DiskUsage: &swarmapi.NodeDiskUsage{
+				Images:     &swarmapi.DiskUsageSummary{TotalCount: 2, ActiveCount: 1, SizeBytes: 2000, Reclaimable: 1000},
+				Containers: &swarmapi.DiskUsageSummary{TotalCount: 1, ActiveCount: 1, SizeBytes: 100},
+			},
+		},
+	})
+
+	usage := node.Description.DiskUsage
+	if usage == nil {
+		t.Fatal("expected the disk usage of the node")
+	}
+	if usage.Images.TotalCount != 2 || usage.Images.ActiveCount != 1 || usage.Images.Size != 2000 || usage.Images.Reclaimable != 1000 {
+		t.Fatalf("unexpected images disk usage %+v", usage.Images)
+	}
+	if usage.Containers.TotalCount != 1 || usage.Containers.Size != 100 {
+		t.Fatalf("unexpected containers disk usage %+v", usage.Containers)
+	}
+	if usage.Volumes.TotalCount != 0 || usage.Volumes.Size != 0 {
+		t.Fatalf("unexpected volumes disk usage %+v", usage.Volumes)
+	}
+	if len(node.Description.Engine.Labels) != 1 || node.Description.Engine.Labels["foo"] != "bar" {
+		t.Fatalf("unexpected engine labels %v", node.Description.Engine.Labels)
+	}
+}
+
+func TestNodeFromGRPCNoDiskUsage(t *testing.T) {
+	node := NodeFromGRPC(swarmapi.Node{
+		ID:          "id",
+		Description: &swarmapi.NodeDescription{},
+	})
+	if node.Description.DiskUsage != nil {
+		t.Fatalf("expected no disk usage, got %+v", node.Description.DiskUsage)
+	}
+}
@@ -100,6 +100,9 @@ func serviceSpecFromGRPC(spec *swarmapi.ServiceSpec) *types.ServiceSpec {
 		if spec.Update.Monitor != nil {
 			convertedSpec.UpdateConfig.Monitor, _ = gogotypes.DurationFromProto(spec.Update.Monitor)
 		}
+		if spec.Update.HealthTimeout != nil {
+			convertedSpec.UpdateConfig.HealthTimeout, _ = gogotypes.DurationFromProto(spec.Update.HealthTimeout)
+		}
 
 		switch spec.Update.FailureAction {
 		case swarmapi.UpdateConfig_PAUSE:
@@ -107,6 +110,13 @@ func serviceSpecFromGRPC(spec *swarmapi.ServiceSpec) *types.ServiceSpec {
 		case swarmapi.UpdateConfig_CONTINUE:
 			convertedSpec.UpdateConfig.FailureAction = types.UpdateFailureActionContinue
 		}
+
+		switch spec.Update.Order {
+		case swarmapi.UpdateConfig_STOP_FIRST:
+			convertedSpec.UpdateConfig.Order = types.UpdateOrderStopFirst
+		case swarmapi.UpdateConfig_START_FIRST:
+			convertedSpec.UpdateConfig.Order = types.UpdateOrderStartFirst
+		}
 	}
 
 	// Mode
@@ -168,6 +178,7 @@ func ServiceSpecToGRPC(s types.ServiceSpec) (swarmapi.ServiceSpec, error) {
 	if s.TaskTemplate.Placement != nil {
 		spec.Task.Placement = &swarmapi.Placement{
 			Constraints: s.TaskTemplate.Placement.Constraints,
+			PinSlots:    s.TaskTemplate.Placement.PinSlots,
 		}
 	}
 
@@ -181,15 +192,31 @@ func ServiceSpecToGRPC(s types.ServiceSpec) (swarmapi.ServiceSpec, error) {
 		default:
 			return swarmapi.ServiceSpec{}, fmt.Errorf("unrecongized update failure action %s", s.UpdateConfig.FailureAction)
 		}
+		var order swarmapi.UpdateConfig_UpdateOrder
+		switch s.UpdateConfig.Order {
+		case types.UpdateOrderStopFirst, "":
+			order = swarmapi.UpdateConfig_STOP_FIRST
+		case types.UpdateOrderStartFirst:
+			order = swarmapi.UpdateConfig_START_FIRST
+		default:
+			return swarmapi.ServiceSpec{}, fmt.Errorf("unrecognized update order %s", s.UpdateConfig.Order)
+		}
 		spec.Update = &swarmapi.UpdateConfig{
 			Parallelism:     s.UpdateConfig.Parallelism,
 			Delay:           s.UpdateConfig.Delay,
 			FailureAction:   failureAction,
 			MaxFailureRatio: s.UpdateConfig.MaxFailureRatio,
+			Order:           order,
 		}
 		if s.UpdateConfig.Monitor != 0 {
 			spec.Update.Monitor = gogotypes.DurationProto(s.UpdateConfig.Monitor)
 		}
+		if s.UpdateConfig.HealthTimeout < 0 {
+			return swarmapi.ServiceSpec{}, fmt.Errorf("invalid update health timeout: %s", s.UpdateConfig.HealthTimeout)
+		}
+		if s.UpdateConfig.HealthTimeout != 0 {
+			spec.Update.HealthTimeout = gogotypes.DurationProto(s.UpdateConfig.HealthTimeout)
+		}
 	}
 
 	if s.EndpointSpec != nil {
@@ -346,6 +373,7 @@ func placementFromGRPC(p *swarmapi.Placement) *types.Placement {
 	if p != nil {
 		r = &types.Placement{}
 		r.Constraints = p.Constraints
+		r.PinSlots = p.PinSlots
 	}
 
 	return r
@@ -0,0 +1,54 @@
+package convert
+
+import (
+	"testing"
+	"time"
+
+	swarmtypes "github.com/docker/docker/api/types/swarm"
+	swarmapi "github.com/docker/swarmkit/api"
+)
+
+func TestServiceSpecUpdateHealthTimeout(t *testing.T) {
+	spec := swarmtypes.ServiceSpec{
+		UpdateConfig: &swarmtypes.UpdateConfig{
+			HealthTimeout: 90 * time.Second,
+		},
+	}
+	grpcSpec, err := ServiceSpecToGRPC(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if grpcSpec.Update.HealthTimeout == nil || grpcSpec.Update.HealthTimeout.Seconds != 90 {
+		t.Fatalf("unexpected health timeout %v", grpcSpec.Update.HealthTimeout)
+	}
+	if len(grpcSpec.Annotations.Labels) != 0 {
+		t.Fatalf("unexpected labels %v", grpcSpec.Annotations.Labels)
+	}
+
+	// serviceSpecFromGRPC expects a container runtime
+	grpcSpec.Task.Runtime = &swarmapi.TaskSpec_Container{Container: &swarmapi.ContainerSpec{}}
+	converted := serviceSpecFromGRPC(&grpcSpec)
+	if converted.UpdateConfig.HealthTimeout != 90*time.Second {
+		t.Fatalf("expected health timeout of 90s, got %s", converted.UpdateConfig.HealthTimeout)
+	}
+
+	// no timeout
+	spec.UpdateConfig.HealthTimeout = 0
+	if grpcSpec, err = ServiceSpecToGRPC(spec); err != nil {
+		t.Fatal(err)
+	}
+	if grpcSpec.Update.HealthTimeout != nil {
+		t.Fatalf("expected no health timeout, got %v", grpcSpec.Update.HealthTimeout)
+	}
+}
+
+func TestServiceSpecInvalidUpdateHealthTimeout(t *testing.T) {
+	spec := swarmtypes.ServiceSpec{
+		UpdateConfig: &swarmtypes.UpdateConfig{
+			HealthTimeout: -time.Second,
+		},
+	}
+	if _, err := ServiceSpecToGRPC(spec); err == nil {
+		t.Fatal("expected an error for a negative health timeout")
+	}
+}
@@ -56,6 +56,7 @@ func TaskFromGRPC(t swarmapi.Task) types.Task {
 		task.Status.ContainerStatus.ContainerID = containerStatus.ContainerID
 		task.Status.ContainerStatus.PID = int(containerStatus.PID)
 		task.Status.ContainerStatus.ExitCode = int(containerStatus.ExitCode)
+		task.Status.ContainerStatus.Health = containerStatus.Health
 	}
 
 	// NetworksAttachments
@@ -565,6 +565,9 @@ func parseContainerStatus(ctnr types.ContainerJSON) (*api.ContainerStatus, error
 		PID:         int32(ctnr.State.Pid),
 		ExitCode:    int32(ctnr.State.ExitCode),
 	}
+	if ctnr.State.Health != nil {
+		status.Health = ctnr.State.Health.Status
+	}
 
 	return status, nil
 }
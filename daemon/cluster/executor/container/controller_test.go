@@ -0,0 +1,39 @@
+package container
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+)
+
+func TestParseContainerStatusHealth(t *testing.T) {
+	ctnr := types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			ID: "id",
+			State: &types.ContainerState{
+				Pid:    42,
+				Health: &types.Health{Status: types.Healthy},
+			},
+		},
+	}
+	status, err := parseContainerStatus(ctnr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.ContainerID != "id" || status.PID != 42 {
+		t.Fatalf("unexpected container status %v", status)
+	}
+	if status.Health != types.Healthy {
+		t.Fatalf("expected health %q, got %q", types.Healthy, status.Health)
+	}
+
+	// a container without healthcheck has no health
+	ctnr.State.Health = nil
+	status, err = parseContainerStatus(ctnr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Health != "" {
+		t.Fatalf("expected no health, got %q", status.Health)
+	}
+}
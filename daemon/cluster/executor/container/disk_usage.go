@@ -0,0 +1,105 @@
+package container
+
+import (
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/swarmkit/api"
+)
+
+// diskUsageRefreshPeriod is the minimum time between two computations of the
+// disk usage of the node. Computing it requires walking the containers'
+// filesystems and the volumes, which is too expensive to do every time the
+// agent asks for the node description.
+const diskUsageRefreshPeriod = 5 * time.Minute
+
+// nodeDiskUsage returns the last computed disk usage of the node, or nil if
+// it hasn't been computed yet. It never waits for the computation: if the
+// cached value is too old, it is computed again in the background and
+// reported by a later node description.
+func (e *executor) nodeDiskUsage() *api.NodeDiskUsage {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.diskUsageRefreshing && time.Now().After(e.diskUsageExpires) {
+		e.diskUsageRefreshing = true
+		go e.refreshDiskUsage()
+	}
+	return e.diskUsage.Copy()
+}
+
+// refreshDiskUsage computes the disk usage of the node and caches it.
+func (e *executor) refreshDiskUsage() {
+	var usage *api.NodeDiskUsage
+	du, err := e.backend.SystemDiskUsage()
+	if err != nil {
+		logrus.WithError(err).Warn("failed to compute the disk usage of the node")
+	} else {
+		usage = summarizeDiskUsage(du)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if usage != nil {
+		e.diskUsage = usage
+	}
+	// wait for the next period after a failure too, instead of retrying on
+	// every node description
+	e.diskUsageExpires = time.Now().Add(diskUsageRefreshPeriod)
+	e.diskUsageRefreshing = false
+}
+
+// summarizeDiskUsage computes the summary reported for a node from the disk
+// usage of its daemon, the same way `docker system df` does.
+func summarizeDiskUsage(du *types.DiskUsage) *api.NodeDiskUsage {
+	images := &api.DiskUsageSummary{}
+	var usedLayersSize int64
+	images.TotalCount = int64(len(du.Images))
+	images.SizeBytes = du.LayersSize
+	for _, i := range du.Images {
+		if i.Containers > 0 {
+			images.ActiveCount++
+			if i.VirtualSize != -1 && i.SharedSize != -1 {
+				usedLayersSize += i.VirtualSize - i.SharedSize
+			}
+		}
+	}
+	images.Reclaimable = du.LayersSize - usedLayersSize
+
+	containers := &api.DiskUsageSummary{}
+	containers.TotalCount = int64(len(du.Containers))
+	for _, c := range du.Containers {
+		containers.SizeBytes += c.SizeRw
+		switch c.State {
+		case "running", "paused", "restarting":
+			containers.ActiveCount++
+		default:
+			containers.Reclaimable += c.SizeRw
+		}
+	}
+
+	volumes := &api.DiskUsageSummary{}
+	volumes.TotalCount = int64(len(du.Volumes))
+	for _, v := range du.Volumes {
+		if v.UsageData == nil {
+			continue
+		}
+		if v.UsageData.RefCount > 0 {
+			volumes.ActiveCount++
+		}
+		if v.UsageData.Size == -1 {
+			continue
+		}
+		volumes.SizeBytes += v.UsageData.Size
+		if v.UsageData.RefCount == 0 {
+			volumes.Reclaimable += v.UsageData.Size
+		}
+	}
+
+	return &api.NodeDiskUsage{
+		Images:     images,
+		Containers: containers,
+		Volumes:    volumes,
+	}
+}
@@ -0,0 +1,93 @@
+package container
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	executorpkg "github.com/docker/docker/daemon/cluster/executor"
+)
+
+type diskUsageBackend struct {
+	executorpkg.Backend
+	calls chan struct{}
+	usage chan *types.DiskUsage
+}
+
+func (b *diskUsageBackend) SystemDiskUsage() (*types.DiskUsage, error) {
+	b.calls <- struct{}{}
+	return <-b.usage, nil
+}
+
+func TestSummarizeDiskUsage(t *testing.T) {
+	usage := summarizeDiskUsage(&types.DiskUsage{
+		LayersSize: 3000,
+		Images: []*types.ImageSummary{
+			{Containers: 1, VirtualSize: 2000, SharedSize: 500},
+			{Containers: 0, VirtualSize: 1000, SharedSize: 500},
+			{Containers: 2, VirtualSize: -1, SharedSize: -1},
+		},
+		Containers: []*types.Container{
+			{State: "running", SizeRw: 10},
+			{State: "paused", SizeRw: 20},
+			{State: "exited", SizeRw: 40},
+		},
+		Volumes: []*types.Volume{
+			{UsageData: &types.VolumeUsageData{RefCount: 1, Size: 100}},
+			{UsageData: &types.VolumeUsageData{RefCount: 0, Size: 200}},
+			{UsageData: &types.VolumeUsageData{RefCount: 0, Size: -1}},
+			{},
+		},
+	})
+
+	if i := usage.Images; i.TotalCount != 3 || i.ActiveCount != 2 || i.SizeBytes != 3000 || i.Reclaimable != 1500 {
+		t.Fatalf("unexpected images disk usage %v", i)
+	}
+	if c := usage.Containers; c.TotalCount != 3 || c.ActiveCount != 2 || c.SizeBytes != 70 || c.Reclaimable != 40 {
+		t.Fatalf("unexpected containers disk usage %v", c)
+	}
+	if v := usage.Volumes; v.TotalCount != 4 || v.ActiveCount != 1 || v.SizeBytes != 300 || v.Reclaimable != 200 {
+		t.Fatalf("unexpected volumes disk usage %v", v)
+	}
+}
+
+func TestNodeDiskUsageDoesNotBlock(t *testing.T) {
+	backend := &diskUsageBackend{
+		calls: make(chan struct{}, 1),
+		usage: make(chan *types.DiskUsage),
+	}
+	e := &executor{backend: backend}
+
+	// the first call starts the computation, and returns without waiting for it
+	if usage := e.nodeDiskUsage(); usage != nil {
+		t.Fatalf("expected no disk usage before it is computed, got %v", usage)
+	}
+	<-backend.calls
+
+	// a computation is already in progress, so no other one is started
+	if usage := e.nodeDiskUsage(); usage != nil {
+		t.Fatalf("expected no disk usage while it is computed, got %v", usage)
+	}
+
+	backend.usage <- &types.DiskUsage{LayersSize: 1000}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		usage := e.nodeDiskUsage()
+		if usage != nil {
+			if usage.Images.SizeBytes != 1000 {
+				t.Fatalf("unexpected disk usage %v", usage)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("the disk usage was not computed in time")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	select {
+	case <-backend.calls:
+		t.Fatal("the disk usage was computed again before it expired")
+	default:
+	}
+}
@@ -3,6 +3,8 @@ package container
 import (
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
@@ -19,6 +21,13 @@ import (
 type executor struct {
 	backend executorpkg.Backend
 	secrets exec.SecretsManager
+
+	// mu protects the cached disk usage of the node, which is computed in
+	// the background.
+	mu                  sync.Mutex
+	diskUsage           *api.NodeDiskUsage
+	diskUsageExpires    time.Time
+	diskUsageRefreshing bool
 }
 
 // NewExecutor returns an executor from the docker client.
@@ -108,6 +117,7 @@ func (e *executor) Describe(ctx context.Context) (*api.NodeDescription, error) {
 			NanoCPUs:    int64(info.NCPU) * 1e9,
 			MemoryBytes: info.MemTotal,
 		},
+		DiskUsage: e.nodeDiskUsage(),
 	}
 
 	return description, nil
@@ -2,6 +2,7 @@ package daemon
 
 import (
 	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/opts"
+	"github.com/docker/docker/pkg/authorization"
 	"github.com/docker/docker/pkg/discovery"
 	"github.com/docker/docker/registry"
 	"github.com/imdario/mergo"
@@ -47,10 +49,20 @@ const (
 // Use this to differentiate these options
 // with others like the ones in CommonTLSOptions.
 var flatOptions = map[string]bool{
-	"cluster-store-opts": true,
-	"log-opts":           true,
-	"runtimes":           true,
-	"default-ulimits":    true,
+	"cluster-store-opts":   true,
+	"log-opts":             true,
+	"runtimes":             true,
+	"default-ulimits":      true,
+	"authorization-policy": true,
+	"audit-log-opts":       true,
+	"events-journal-opts":  true,
+}
+
+// fileOnlyOptions contains configuration keys
+// that can only be set in the configuration file,
+// because they don't have an equivalent flag.
+var fileOnlyOptions = map[string]bool{
+	"listeners": true,
 }
 
 // LogConfig represents the default log configuration.
@@ -77,6 +89,31 @@ type CommonTLSOptions struct {
 	KeyFile  string `json:"tlskey,omitempty"`
 }
 
+// ListenerConfig defines the configuration of an API listener that is
+// created in addition to the ones of the hosts. Unlike the hosts, each
+// listener has its own TLS and authorization settings.
+type ListenerConfig struct {
+	Host      string `json:"host"`
+	TLS       bool   `json:"tls,omitempty"`
+	TLSVerify bool   `json:"tlsverify,omitempty"`
+	CommonTLSOptions
+
+	// ReadOnly restricts the listener to the requests that don't modify
+	// the state of the daemon.
+	ReadOnly bool `json:"read-only,omitempty"`
+
+	// AuthorizationPlugins and AuthorizationPolicy are consulted for the
+	// requests received on the listener, after the ones of the daemon.
+	AuthorizationPlugins []string              `json:"authorization-plugins,omitempty"`
+	AuthorizationPolicy  *authorization.Policy `json:"authorization-policy,omitempty"`
+}
+
+// HasAuthorization returns true if the listener has its own authorization
+// plugins or policy.
+func (l *ListenerConfig) HasAuthorization() bool {
+	return len(l.AuthorizationPlugins) > 0 || l.AuthorizationPolicy != nil
+}
+
 // CommonConfig defines the configuration of a docker daemon which is
 // common across platforms.
 // It includes json tags to deserialize configuration from a file
@@ -93,6 +130,8 @@ type CommonConfig struct {
 	GraphDriver          string              `json:"storage-driver,omitempty"`
 	GraphOptions         []string            `json:"storage-opts,omitempty"`
 	Labels               []string            `json:"labels,omitempty"`
+	MigrateGraphDriver   string              `json:"migrate-storage-driver,omitempty"`
+	MigrateGraphOptions  []string            `json:"migrate-storage-opts,omitempty"`
 	Mtu                  int                 `json:"mtu,omitempty"`
 	Pidfile              string              `json:"pidfile,omitempty"`
 	RawLogs              bool                `json:"raw-logs,omitempty"`
@@ -128,10 +167,49 @@ type CommonConfig struct {
 	// may take place at a time for each push.
 	MaxConcurrentUploads *int `json:"max-concurrent-uploads,omitempty"`
 
+	// PushCompression is the compression of the layers for each push,
+	// gzip or zstd.
+	PushCompression string `json:"push-compression,omitempty"`
+
+	// PushCompressionLevel is the gzip compression level of the layers
+	// for each push.
+	PushCompressionLevel *int `json:"push-compression-level,omitempty"`
+
+	// PushCompressionThreads is the number of threads compressing each
+	// layer for each push.
+	PushCompressionThreads int `json:"push-compression-threads,omitempty"`
+
+	// PushBlobCache determines whether the compressed layers are kept
+	// after a push, so that they are not compressed again when they are
+	// pushed to another registry.
+	PushBlobCache bool `json:"push-blob-cache,omitempty"`
+
 	// ShutdownTimeout is the timeout value (in seconds) the daemon will wait for the container
 	// to stop when daemon is being shutdown
 	ShutdownTimeout int `json:"shutdown-timeout,omitempty"`
 
+	// AuthorizationPolicy is the role based access control policy enforced
+	// on the API requests, before the authorization plugins are consulted.
+	AuthorizationPolicy *authorization.Policy `json:"authorization-policy,omitempty"`
+
+	// AuditLog is the destination of the audit log of the API requests,
+	// either a file or "syslog". The audit log is disabled if it is empty.
+	AuditLog string `json:"audit-log,omitempty"`
+
+	// AuditLogOpts are the options of the audit log.
+	AuditLogOpts map[string]string `json:"audit-log-opts,omitempty"`
+
+	// EventsJournal enables storing the events on disk, so that they can
+	// be replayed after a restart of the daemon.
+	EventsJournal bool `json:"events-journal,omitempty"`
+
+	// EventsJournalOpts are the retention options of the events journal.
+	EventsJournalOpts map[string]string `json:"events-journal-opts,omitempty"`
+
+	// Listeners are the API listeners created in addition to the ones of
+	// the hosts. They can only be set in the configuration file.
+	Listeners []ListenerConfig `json:"listeners,omitempty"`
+
 	Debug     bool     `json:"debug,omitempty"`
 	Hosts     []string `json:"hosts,omitempty"`
 	LogLevel  string   `json:"log-level,omitempty"`
@@ -161,18 +239,25 @@ type CommonConfig struct {
 
 // InstallCommonFlags adds flags to the pflag.FlagSet to configure the daemon
 func (config *Config) InstallCommonFlags(flags *pflag.FlagSet) {
-	var maxConcurrentDownloads, maxConcurrentUploads int
+	var maxConcurrentDownloads, maxConcurrentUploads, pushCompressionLevel int
 
 	config.ServiceOptions.InstallCliFlags(flags)
 
 	flags.Var(opts.NewNamedListOptsRef("storage-opts", &config.GraphOptions, nil), "storage-opt", "Storage driver options")
 	flags.Var(opts.NewNamedListOptsRef("authorization-plugins", &config.AuthorizationPlugins, nil), "authorization-plugin", "Authorization plugins to load")
+	flags.Var(opts.NewNamedAuthorizationPolicyOpt("authorization-policy", &config.AuthorizationPolicy), "authorization-policy", "Role based authorization policy file")
+	flags.StringVar(&config.AuditLog, "audit-log", "", "Write an audit log of the API requests to a file or to syslog")
+	flags.Var(opts.NewNamedMapOpts("audit-log-opts", config.AuditLogOpts, nil), "audit-log-opt", "Audit log options")
+	flags.BoolVar(&config.EventsJournal, "events-journal", false, "Store the events on disk")
+	flags.Var(opts.NewNamedMapOpts("events-journal-opts", config.EventsJournalOpts, nil), "events-journal-opt", "Events journal retention options")
 	flags.Var(opts.NewNamedListOptsRef("exec-opts", &config.ExecOptions, nil), "exec-opt", "Runtime execution options")
 	flags.StringVarP(&config.Pidfile, "pidfile", "p", defaultPidFile, "Path to use for daemon PID file")
 	flags.StringVarP(&config.Root, "graph", "g", defaultGraph, "Root of the Docker runtime")
 	flags.BoolVarP(&config.AutoRestart, "restart", "r", true, "--restart on the daemon has been deprecated in favor of --restart policies on docker run")
 	flags.MarkDeprecated("restart", "Please use a restart policy on docker run")
 	flags.StringVarP(&config.GraphDriver, "storage-driver", "s", "", "Storage driver to use")
+	flags.StringVar(&config.MigrateGraphDriver, "migrate-storage-driver", "", "Migrate the images and containers of a previous storage driver on startup")
+	flags.Var(opts.NewNamedListOptsRef("migrate-storage-opts", &config.MigrateGraphOptions, nil), "migrate-storage-opt", "Options of the previous storage driver to migrate from")
 	flags.IntVar(&config.Mtu, "mtu", 0, "Set the containers network MTU")
 	flags.BoolVar(&config.RawLogs, "raw-logs", false, "Full timestamps without ANSI coloring")
 	// FIXME: why the inconsistency between "hosts" and "sockets"?
@@ -188,6 +273,10 @@ func (config *Config) InstallCommonFlags(flags *pflag.FlagSet) {
 	flags.StringVar(&config.CorsHeaders, "api-cors-header", "", "Set CORS headers in the Engine API")
 	flags.IntVar(&maxConcurrentDownloads, "max-concurrent-downloads", defaultMaxConcurrentDownloads, "Set the max concurrent downloads for each pull")
 	flags.IntVar(&maxConcurrentUploads, "max-concurrent-uploads", defaultMaxConcurrentUploads, "Set the max concurrent uploads for each push")
+	flags.StringVar(&config.PushCompression, "push-compression", "gzip", "Set the compression of the layers for each push (gzip or zstd)")
+	flags.IntVar(&pushCompressionLevel, "push-compression-level", gzip.DefaultCompression, "Set the gzip compression level of the layers for each push")
+	flags.IntVar(&config.PushCompressionThreads, "push-compression-threads", 1, "Set the number of threads compressing each layer for each push")
+	flags.BoolVar(&config.PushBlobCache, "push-blob-cache", false, "Keep the compressed layers to push them again without compressing them")
 	flags.IntVar(&config.ShutdownTimeout, "shutdown-timeout", defaultShutdownTimeout, "Set the default shutdown timeout")
 
 	flags.StringVar(&config.SwarmDefaultAdvertiseAddr, "swarm-default-advertise-addr", "", "Set default address or interface for swarm advertised address")
@@ -197,6 +286,7 @@ func (config *Config) InstallCommonFlags(flags *pflag.FlagSet) {
 
 	config.MaxConcurrentDownloads = &maxConcurrentDownloads
 	config.MaxConcurrentUploads = &maxConcurrentUploads
+	config.PushCompressionLevel = &pushCompressionLevel
 }
 
 // IsValueSet returns true if a configuration value
@@ -214,6 +304,8 @@ func NewConfig() *Config {
 	config := Config{}
 	config.LogConfig.Config = make(map[string]string)
 	config.ClusterOpts = make(map[string]string)
+	config.AuditLogOpts = make(map[string]string)
+	config.EventsJournalOpts = make(map[string]string)
 
 	if runtime.GOOS != "linux" {
 		config.V2Only = true
@@ -415,6 +507,9 @@ func findConfigurationConflicts(config map[string]interface{}, flags *pflag.Flag
 	// 1. Search keys from the file that we don't recognize as flags.
 	unknownKeys := make(map[string]interface{})
 	for key, value := range config {
+		if fileOnlyOptions[key] {
+			continue
+		}
 		if flag := flags.Lookup(key); flag == nil {
 			unknownKeys[key] = value
 		}
@@ -497,6 +592,20 @@ func ValidateConfiguration(config *Config) error {
 		}
 	}
 
+	// validate AuthorizationPolicy
+	if config.AuthorizationPolicy != nil {
+		if err := config.AuthorizationPolicy.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// validate Listeners
+	for _, l := range config.Listeners {
+		if err := validateListener(l); err != nil {
+			return err
+		}
+	}
+
 	// validate MaxConcurrentDownloads
 	if config.IsValueSet("max-concurrent-downloads") && config.MaxConcurrentDownloads != nil && *config.MaxConcurrentDownloads < 0 {
 		return fmt.Errorf("invalid max concurrent downloads: %d", *config.MaxConcurrentDownloads)
@@ -507,6 +616,21 @@ func ValidateConfiguration(config *Config) error {
 		return fmt.Errorf("invalid max concurrent uploads: %d", *config.MaxConcurrentUploads)
 	}
 
+	// validate PushCompression
+	if _, err := pushCompression(config.PushCompression); err != nil {
+		return err
+	}
+
+	// validate PushCompressionLevel
+	if config.PushCompressionLevel != nil && !validCompressionLevel(*config.PushCompressionLevel) {
+		return fmt.Errorf("invalid push compression level: %d", *config.PushCompressionLevel)
+	}
+
+	// validate PushCompressionThreads
+	if config.PushCompressionThreads < 0 {
+		return fmt.Errorf("invalid push compression threads: %d", config.PushCompressionThreads)
+	}
+
 	// validate that "default" runtime is not reset
 	if runtimes := config.GetAllRuntimes(); len(runtimes) > 0 {
 		if _, ok := runtimes[stockRuntimeName]; ok {
@@ -523,3 +647,22 @@ func ValidateConfiguration(config *Config) error {
 
 	return nil
 }
+
+// validateListener validates the configuration of a listener.
+func validateListener(l ListenerConfig) error {
+	if l.Host == "" {
+		return errors.New("invalid listener: the host is empty")
+	}
+	if _, err := opts.ParseHost(l.TLS || l.TLSVerify, l.Host); err != nil {
+		return fmt.Errorf("invalid listener %s: %v", l.Host, err)
+	}
+	if (l.TLS || l.TLSVerify) && (l.CertFile == "" || l.KeyFile == "") {
+		return fmt.Errorf("invalid listener %s: TLS requires tlscert and tlskey", l.Host)
+	}
+	if l.AuthorizationPolicy != nil {
+		if err := l.AuthorizationPolicy.Validate(); err != nil {
+			return fmt.Errorf("invalid listener %s: %v", l.Host, err)
+		}
+	}
+	return nil
+}
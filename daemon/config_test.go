@@ -139,6 +139,15 @@ func TestFindConfigurationConflictsWithUnknownKeys(t *testing.T) {
 	}
 }
 
+func TestFindConfigurationConflictsWithFileOnlyOptions(t *testing.T) {
+	config := map[string]interface{}{"listeners": []interface{}{map[string]interface{}{"host": "tcp://127.0.0.1:2345"}}}
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+
+	if err := findConfigurationConflicts(config, flags); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestFindConfigurationConflictsWithMergedValues(t *testing.T) {
 	var hosts []string
 	config := map[string]interface{}{"hosts": "tcp://127.0.0.1:2345"}
@@ -227,3 +236,82 @@ func TestValidateConfiguration(t *testing.T) {
 		t.Fatal("expected error, got nil")
 	}
 }
+
+func TestValidateConfigurationPushCompression(t *testing.T) {
+	for _, level := range []int{-2, -1, 0, 9} {
+		c := &Config{
+			CommonConfig: CommonConfig{
+				PushCompressionLevel: &level,
+			},
+		}
+		if err := ValidateConfiguration(c); err != nil {
+			t.Fatalf("expected no error for level %d, got error %v", level, err)
+		}
+	}
+
+	for _, level := range []int{-3, 10} {
+		c := &Config{
+			CommonConfig: CommonConfig{
+				PushCompressionLevel: &level,
+			},
+		}
+		if err := ValidateConfiguration(c); err == nil {
+			t.Fatalf("expected error for level %d, got nil", level)
+		}
+	}
+
+	c := &Config{
+		CommonConfig: CommonConfig{
+			PushCompressionThreads: -1,
+		},
+	}
+	if err := ValidateConfiguration(c); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	for _, compression := range []string{"", "gzip", "zstd"} {
+		c := &Config{
+			CommonConfig: CommonConfig{
+				PushCompression: compression,
+			},
+		}
+		if err := ValidateConfiguration(c); err != nil {
+			t.Fatalf("expected no error for compression %q, got error %v", compression, err)
+		}
+	}
+
+	c = &Config{
+		CommonConfig: CommonConfig{
+			PushCompression: "xz",
+		},
+	}
+	if err := ValidateConfiguration(c); err == nil {
+		t.Fatal("expected error for compression xz, got nil")
+	}
+}
+
+func TestValidateConfigurationListeners(t *testing.T) {
+	valid := []ListenerConfig{
+		{Host: "tcp://127.0.0.1:2375", ReadOnly: true},
+		{Host: "unix:///var/run/docker-ro.sock"},
+		{Host: "tcp://0.0.0.0:2376", TLSVerify: true, CommonTLSOptions: CommonTLSOptions{CertFile: "cert.pem", KeyFile: "key.pem"}},
+	}
+	for _, l := range valid {
+		config := &Config{CommonConfig: CommonConfig{Listeners: []ListenerConfig{l}}}
+		if err := ValidateConfiguration(config); err != nil {
+			t.Fatalf("expected no error for listener %s, got error %v", l.Host, err)
+		}
+	}
+
+	invalid := []ListenerConfig{
+		{},
+		{Host: "foo://bar"},
+		{Host: "tcp://0.0.0.0:2376", TLS: true},
+	}
+	for _, l := range invalid {
+		config := &Config{CommonConfig: CommonConfig{Listeners: []ListenerConfig{l}}}
+		if err := ValidateConfiguration(config); err == nil {
+			t.Fatalf("expected error for listener %q, got nil", l.Host)
+		}
+	}
+}
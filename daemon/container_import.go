@@ -0,0 +1,226 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/Sirupsen/logrus"
+	apierrors "github.com/docker/docker/api/errors"
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/chrootarchive"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/docker/docker/pkg/symlink"
+	"github.com/docker/docker/volume"
+	volumestore "github.com/docker/docker/volume/store"
+)
+
+// ContainerImport creates a container from the archive of a full export of a
+// container read from in. The container is named name, or like the exported
+// container if name is empty. The image of the container must exist.
+func (daemon *Daemon) ContainerImport(name string, in io.Reader) (containertypes.ContainerCreateCreatedBody, error) {
+	if runtime.GOOS == "windows" {
+		return containertypes.ContainerCreateCreatedBody{}, fmt.Errorf("the daemon on this platform does not support import of a container")
+	}
+
+	tmpDir, err := ioutil.TempDir("", "docker-import-")
+	if err != nil {
+		return containertypes.ContainerCreateCreatedBody{}, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err := chrootarchive.Untar(in, tmpDir, nil); err != nil {
+		return containertypes.ContainerCreateCreatedBody{}, err
+	}
+
+	configPath, err := bundlePath(tmpDir, bundleConfigFileName)
+	if err != nil {
+		return containertypes.ContainerCreateCreatedBody{}, err
+	}
+	dt, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return containertypes.ContainerCreateCreatedBody{}, apierrors.NewBadRequestError(fmt.Errorf("invalid container archive: %s not found, the container must be exported with --full", bundleConfigFileName))
+		}
+		return containertypes.ContainerCreateCreatedBody{}, err
+	}
+	var bundle containerBundle
+	if err := json.Unmarshal(dt, &bundle); err != nil {
+		return containertypes.ContainerCreateCreatedBody{}, apierrors.NewBadRequestError(fmt.Errorf("invalid container archive: %v", err))
+	}
+	if bundle.Config == nil {
+		return containertypes.ContainerCreateCreatedBody{}, apierrors.NewBadRequestError(fmt.Errorf("invalid container archive: no container configuration"))
+	}
+	if name == "" {
+		name = bundle.Name
+	}
+
+	// The changes to the filesystem only apply to the image of the exported
+	// container
+	if _, err := daemon.GetImage(bundle.ImageID.String()); err != nil {
+		return containertypes.ContainerCreateCreatedBody{}, apierrors.NewRequestNotFoundError(fmt.Errorf("image %s (%s) of the container not found, pull it before importing the container", bundle.Config.Image, bundle.ImageID))
+	}
+	if id, err := daemon.GetImageID(bundle.Config.Image); err != nil || id != bundle.ImageID {
+		bundle.Config.Image = bundle.ImageID.String()
+	}
+
+	var (
+		warnings       []string
+		createdVolumes = make(map[string]bool)
+	)
+	cleanupVolumes := func() {
+		for name := range createdVolumes {
+			if err := daemon.VolumeRm(name, true); err != nil {
+				logrus.Warnf("Failed to remove volume %s of imported container: %v", name, err)
+			}
+		}
+	}
+
+	// Create the named volumes with the driver, options and labels of the
+	// exported volumes. The data of the existing volumes is kept.
+	for _, v := range bundle.Volumes {
+		if v.Anonymous || createdVolumes[v.Name] {
+			continue
+		}
+		if _, err := daemon.volumes.Get(v.Name); err == nil {
+			if v.Data {
+				warnings = append(warnings, fmt.Sprintf("Volume %s already exists, its data was not imported.", v.Name))
+			}
+			continue
+		} else if !volumestore.IsNotExist(err) {
+			cleanupVolumes()
+			return containertypes.ContainerCreateCreatedBody{}, err
+		}
+		if _, err := daemon.VolumeCreate(v.Name, v.Driver, v.Options, v.Labels); err != nil {
+			cleanupVolumes()
+			return containertypes.ContainerCreateCreatedBody{}, err
+		}
+		createdVolumes[v.Name] = true
+	}
+
+	ccr, err := daemon.ContainerCreate(types.ContainerCreateConfig{
+		Name:       name,
+		Config:     bundle.Config,
+		HostConfig: bundle.HostConfig,
+	})
+	if err != nil {
+		cleanupVolumes()
+		return ccr, err
+	}
+	ccr.Warnings = append(ccr.Warnings, warnings...)
+
+	container, err := daemon.GetContainer(ccr.ID)
+	if err == nil {
+		err = daemon.importContainerBundle(container, tmpDir, bundle.Volumes, createdVolumes)
+	}
+	if err != nil {
+		if err := daemon.ContainerRm(ccr.ID, &types.ContainerRmConfig{ForceRemove: true, RemoveVolume: true}); err != nil {
+			logrus.Warnf("Failed to remove imported container %s: %v", ccr.ID, err)
+		}
+		cleanupVolumes()
+		return containertypes.ContainerCreateCreatedBody{}, fmt.Errorf("Error importing container: %v", err)
+	}
+	return ccr, nil
+}
+
+// importContainerBundle applies the changes to the filesystem of the
+// exported container in dir to the container, and restores the data of its
+// anonymous volumes and of the named volumes in createdVolumes.
+func (daemon *Daemon) importContainerBundle(container *container.Container, dir string, volumes []containerBundleVolume, createdVolumes map[string]bool) error {
+	rootFSPath, err := bundlePath(dir, bundleRootFSFileName)
+	if err != nil {
+		return err
+	}
+	if err := daemon.importRWLayer(container, rootFSPath); err != nil {
+		return err
+	}
+
+	for _, v := range volumes {
+		if !v.Data || (!v.Anonymous && !createdVolumes[v.Name]) {
+			continue
+		}
+		m, ok := container.MountPoints[v.Destination]
+		if !ok || m.Volume == nil || (v.Anonymous && m.Spec.Source != "") {
+			// The volume is not mounted in the same way in the new
+			// container
+			continue
+		}
+		volumePath, err := bundlePath(dir, filepath.Join(bundleVolumesDir, v.Name+".tar"))
+		if err != nil {
+			return err
+		}
+		if err := daemon.importVolume(m.Volume, volumePath); err != nil {
+			return fmt.Errorf("error importing volume %s: %v", v.Name, err)
+		}
+	}
+	return nil
+}
+
+// importRWLayer applies the changes to the filesystem of a container in the
+// file at path to the container.
+func (daemon *Daemon) importRWLayer(container *container.Container, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := daemon.Mount(container); err != nil {
+		return err
+	}
+	defer daemon.Unmount(container)
+
+	uidMaps, gidMaps := daemon.GetUIDGIDMaps()
+	_, err = chrootarchive.ApplyUncompressedLayer(container.BaseFS, f, &archive.TarOptions{
+		UIDMaps: uidMaps,
+		GIDMaps: gidMaps,
+	})
+	return err
+}
+
+// importVolume replaces the data of the volume v with the data in the file
+// at path.
+func (daemon *Daemon) importVolume(v volume.Volume, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	id := stringid.GenerateNonCryptoID()
+	volumePath, err := v.Mount(id)
+	if err != nil {
+		return err
+	}
+	defer v.Unmount(id)
+
+	// Remove the data copied from the image when the volume was created
+	entries, err := ioutil.ReadDir(volumePath)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if err := os.RemoveAll(filepath.Join(volumePath, e.Name())); err != nil {
+			return err
+		}
+	}
+
+	uidMaps, gidMaps := daemon.GetUIDGIDMaps()
+	return chrootarchive.Untar(f, volumePath, &archive.TarOptions{
+		UIDMaps: uidMaps,
+		GIDMaps: gidMaps,
+	})
+}
+
+// bundlePath returns the path of the file name of the archive of a full
+// export extracted in dir. Symbolic links are evaluated in the scope of dir.
+func bundlePath(dir, name string) (string, error) {
+	return symlink.FollowSymlinkInScope(filepath.Join(dir, name), dir)
+}
@@ -30,6 +30,7 @@ import (
 	_ "github.com/docker/docker/daemon/graphdriver/register"
 	"github.com/docker/docker/daemon/initlayer"
 	"github.com/docker/docker/daemon/stats"
+	"github.com/docker/docker/distribution"
 	dmetadata "github.com/docker/docker/distribution/metadata"
 	"github.com/docker/docker/distribution/xfer"
 	"github.com/docker/docker/dockerversion"
@@ -80,6 +81,7 @@ type Daemon struct {
 	downloadManager           *xfer.LayerDownloadManager
 	uploadManager             *xfer.LayerUploadManager
 	distributionMetadataStore dmetadata.Store
+	blobCache                 *distribution.BlobCache
 	trustKey                  libtrust.PrivateKey
 	idIndex                   *truncindex.TruncIndex
 	configStore               *Config
@@ -224,6 +226,10 @@ func (daemon *Daemon) restore() error {
 					}
 				}
 
+				if err := daemon.restoreImageMounts(c); err != nil {
+					logrus.Warnf("Failed to restore the image mounts of container %s: %v", c.ID, err)
+				}
+
 				c.ResetRestartManager(false)
 				if !c.HostConfig.NetworkMode.IsContainer() && c.IsRunning() {
 					options, err := daemon.buildSandboxOptions(c)
@@ -639,7 +645,22 @@ func NewDaemon(config *Config, registryService registry.Service, containerdRemot
 		return nil, err
 	}
 
-	eventsService := events.New()
+	blobCacheRoot := filepath.Join(imageRoot, "blobcache")
+	if config.PushBlobCache {
+		if d.blobCache, err = distribution.NewBlobCache(blobCacheRoot); err != nil {
+			return nil, err
+		}
+		if err := d.pruneBlobCache(); err != nil {
+			return nil, err
+		}
+	} else if err := os.RemoveAll(blobCacheRoot); err != nil {
+		return nil, err
+	}
+
+	eventsService, err := newEventsService(config)
+	if err != nil {
+		return nil, err
+	}
 
 	referenceStore, err := reference.NewReferenceStore(filepath.Join(imageRoot, "repositories.json"))
 	if err != nil {
@@ -697,6 +718,12 @@ func NewDaemon(config *Config, registryService registry.Service, containerdRemot
 		return nil, err
 	}
 
+	if config.MigrateGraphDriver != "" {
+		if err := d.migrateGraphDriver(config); err != nil {
+			return nil, fmt.Errorf("Storage driver migration failed: %v", err)
+		}
+	}
+
 	if err := d.restore(); err != nil {
 		return nil, err
 	}
@@ -841,6 +868,12 @@ func (daemon *Daemon) Shutdown() error {
 		daemon.netController.Stop()
 	}
 
+	if daemon.EventsService != nil {
+		if err := daemon.EventsService.Close(); err != nil {
+			logrus.Errorf("Error closing the events journal: %v", err)
+		}
+	}
+
 	if err := daemon.cleanupMounts(); err != nil {
 		return err
 	}
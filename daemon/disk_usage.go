@@ -9,18 +9,15 @@ import (
 	"github.com/docker/docker/layer"
 	"github.com/docker/docker/pkg/directory"
 	"github.com/docker/docker/volume"
-	"github.com/opencontainers/go-digest"
 )
 
+// getLayerRefs returns the number of images and containers using each layer.
+// A layer is released when the images and the containers using it are
+// deleted.
 func (daemon *Daemon) getLayerRefs() map[layer.ChainID]int {
 	tmpImages := daemon.imageStore.Map()
 	layerRefs := map[layer.ChainID]int{}
-	for id, img := range tmpImages {
-		dgst := digest.Digest(id)
-		if len(daemon.referenceStore.References(dgst)) == 0 && len(daemon.imageStore.Children(id)) != 0 {
-			continue
-		}
-
+	for _, img := range tmpImages {
 		rootFS := *img.RootFS
 		rootFS.DiffIDs = nil
 		for _, id := range img.RootFS.DiffIDs {
@@ -30,6 +27,17 @@ func (daemon *Daemon) getLayerRefs() map[layer.ChainID]int {
 		}
 	}
 
+	// The writable layer of a container keeps the layers of its image even
+	// if the image was deleted.
+	for _, c := range daemon.List() {
+		if c.RWLayer == nil {
+			continue
+		}
+		for l := c.RWLayer.Parent(); l != nil; l = l.Parent() {
+			layerRefs[l.ChainID()]++
+		}
+	}
+
 	return layerRefs
 }
 
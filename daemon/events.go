@@ -1,6 +1,8 @@
 package daemon
 
 import (
+	"fmt"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -8,6 +10,7 @@ import (
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/container"
 	daemonevents "github.com/docker/docker/daemon/events"
+	"github.com/docker/go-units"
 	"github.com/docker/libnetwork"
 )
 
@@ -115,6 +118,14 @@ func (daemon *Daemon) SubscribeToEvents(since, until time.Time, filter filters.A
 	return daemon.EventsService.SubscribeTopic(since, until, ef)
 }
 
+// ReplayEvents calls fn with the record of events emitted between since and
+// until, read from the events journal if it is enabled, and returns a
+// channel to stream new events from.
+func (daemon *Daemon) ReplayEvents(since, until time.Time, filter filters.Args, fn func(events.Message) error) (chan interface{}, error) {
+	ef := daemonevents.NewFilter(filter)
+	return daemon.EventsService.ReplayTopic(since, until, ef, fn)
+}
+
 // UnsubscribeFromEvents stops the event subscription for a client by closing the
 // channel where the daemon sends events to.
 func (daemon *Daemon) UnsubscribeFromEvents(listener chan interface{}) {
@@ -130,3 +141,39 @@ func copyAttributes(attributes, labels map[string]string) {
 		attributes[k] = v
 	}
 }
+
+// newEventsService creates the events service of the daemon, storing the
+// events in a journal under the daemon root if it is enabled.
+func newEventsService(config *Config) (*daemonevents.Events, error) {
+	if !config.EventsJournal {
+		return daemonevents.New(), nil
+	}
+
+	journalConfig := daemonevents.JournalConfig{
+		Root: filepath.Join(config.Root, "events"),
+	}
+	for key, value := range config.EventsJournalOpts {
+		switch key {
+		case "max-size":
+			size, err := units.RAMInBytes(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid events journal max-size %q: %v", value, err)
+			}
+			journalConfig.MaxSize = size
+		case "max-age":
+			age, err := time.ParseDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid events journal max-age %q: %v", value, err)
+			}
+			journalConfig.MaxAge = age
+		default:
+			return nil, fmt.Errorf("unknown events journal opt '%s'", key)
+		}
+	}
+
+	journal, err := daemonevents.NewJournal(journalConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error opening the events journal: %v", err)
+	}
+	return daemonevents.NewWithJournal(journal), nil
+}
@@ -4,6 +4,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Sirupsen/logrus"
 	eventtypes "github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/pkg/pubsub"
 )
@@ -18,6 +19,17 @@ type Events struct {
 	mu     sync.Mutex
 	events []eventtypes.Message
 	pub    *pubsub.Publisher
+
+	journal     *Journal
+	journalCh   chan journalEntry
+	journalDone chan struct{}
+}
+
+// journalEntry is either an event to write to the journal, or a request
+// for a snapshot of the journal once the events before it are written.
+type journalEntry struct {
+	message  eventtypes.Message
+	snapshot chan []journalSegment
 }
 
 // New returns new *Events instance
@@ -28,6 +40,31 @@ func New() *Events {
 	}
 }
 
+// NewWithJournal returns new *Events instance that also stores the events
+// in journal, and replays them from it. The events are written to the
+// journal in the background, so that a slow disk doesn't hold up Log.
+func NewWithJournal(journal *Journal) *Events {
+	e := New()
+	e.journal = journal
+	e.journalCh = make(chan journalEntry, bufferSize)
+	e.journalDone = make(chan struct{})
+	go e.writeJournal(journal, e.journalCh)
+	return e
+}
+
+func (e *Events) writeJournal(journal *Journal, entries <-chan journalEntry) {
+	defer close(e.journalDone)
+	for entry := range entries {
+		if entry.snapshot != nil {
+			entry.snapshot <- journal.snapshot()
+			continue
+		}
+		if err := journal.Write(entry.message); err != nil {
+			logrus.Errorf("Error writing event to the events journal: %v", err)
+		}
+	}
+}
+
 // Subscribe adds new listener to events, returns slice of 64 stored
 // last events, a channel in which you can expect new events (in form
 // of interface{}, so you need type assertion), and a function to call
@@ -53,23 +90,80 @@ func (e *Events) SubscribeTopic(since, until time.Time, ef *Filter) ([]eventtype
 	eventSubscribers.Inc()
 	e.mu.Lock()
 
-	var topic func(m interface{}) bool
-	if ef != nil && ef.filter.Len() > 0 {
-		topic = func(m interface{}) bool { return ef.Include(m.(eventtypes.Message)) }
-	}
-
+	topic := topicFilter(ef)
 	buffered := e.loadBufferedEvents(since, until, topic)
+	ch := e.subscribe(topic)
 
-	var ch chan interface{}
-	if topic != nil {
-		ch = e.pub.SubscribeTopic(topic)
-	} else {
-		// Subscribe to all events if there are no filters
-		ch = e.pub.Subscribe()
+	e.mu.Unlock()
+	return buffered, ch
+}
+
+// ReplayTopic adds new listener to events like SubscribeTopic, but calls fn
+// with the stored events emitted between since and until instead of
+// returning them. The events are read from the journal if there is one, and
+// from the 64 stored last events otherwise. If fn returns an error, the
+// listener is evicted and the error is returned.
+func (e *Events) ReplayTopic(since, until time.Time, ef *Filter, fn func(eventtypes.Message) error) (chan interface{}, error) {
+	eventSubscribers.Inc()
+	e.mu.Lock()
+
+	topic := topicFilter(ef)
+	var snapshot chan []journalSegment
+	if e.journalCh != nil && (!since.IsZero() || !until.IsZero()) {
+		// The journal is read once unlocked, up to the events logged
+		// before the subscription
+		snapshot = make(chan []journalSegment, 1)
+		select {
+		case e.journalCh <- journalEntry{snapshot: snapshot}:
+		default:
+			logrus.Error("Events journal is falling behind, only replaying the events in memory")
+			snapshot = nil
+		}
 	}
+	var buffered []eventtypes.Message
+	if snapshot == nil {
+		buffered = e.loadBufferedEvents(since, until, topic)
+	}
+	ch := e.subscribe(topic)
 
 	e.mu.Unlock()
-	return buffered, ch
+
+	if snapshot != nil {
+		var fnErr error
+		err := readJournal(<-snapshot, since, until, topic, func(m eventtypes.Message) error {
+			fnErr = fn(m)
+			return fnErr
+		})
+		if fnErr != nil {
+			e.Evict(ch)
+			return nil, fnErr
+		}
+		if err != nil {
+			logrus.Errorf("Error reading the events journal, some events were not replayed: %v", err)
+		}
+	}
+	for _, m := range buffered {
+		if err := fn(m); err != nil {
+			e.Evict(ch)
+			return nil, err
+		}
+	}
+	return ch, nil
+}
+
+func topicFilter(ef *Filter) func(interface{}) bool {
+	if ef == nil || ef.filter.Len() == 0 {
+		return nil
+	}
+	return func(m interface{}) bool { return ef.Include(m.(eventtypes.Message)) }
+}
+
+func (e *Events) subscribe(topic func(interface{}) bool) chan interface{} {
+	if topic != nil {
+		return e.pub.SubscribeTopic(topic)
+	}
+	// Subscribe to all events if there are no filters
+	return e.pub.Subscribe()
 }
 
 // Evict evicts listener from pubsub
@@ -103,6 +197,13 @@ func (e *Events) Log(action, eventType string, actor eventtypes.Actor) {
 	}
 
 	e.mu.Lock()
+	if e.journalCh != nil {
+		select {
+		case e.journalCh <- journalEntry{message: jm}:
+		default:
+			logrus.Errorf("Events journal is falling behind, dropping %s %s event from it", jm.Type, jm.Action)
+		}
+	}
 	if len(e.events) == cap(e.events) {
 		// discard oldest event
 		copy(e.events, e.events[1:])
@@ -114,6 +215,24 @@ func (e *Events) Log(action, eventType string, actor eventtypes.Actor) {
 	e.pub.Publish(jm)
 }
 
+// Close closes the events journal, if any, once the events logged before
+// are written to it.
+func (e *Events) Close() error {
+	e.mu.Lock()
+	journal := e.journal
+	if journal == nil {
+		e.mu.Unlock()
+		return nil
+	}
+	close(e.journalCh)
+	e.journal = nil
+	e.journalCh = nil
+	e.mu.Unlock()
+
+	<-e.journalDone
+	return journal.Close()
+}
+
 // SubscribersCount returns number of event listeners
 func (e *Events) SubscribersCount() int {
 	return e.pub.Len()
@@ -0,0 +1,276 @@
+package events
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	eventtypes "github.com/docker/docker/api/types/events"
+)
+
+const (
+	// DefaultJournalMaxSize is the default maximum size of the journal.
+	DefaultJournalMaxSize = 100 * 1024 * 1024 // 100MB
+
+	// journalSegments is the number of segments the journal is split in.
+	// Whole segments are removed when the journal reaches its maximum
+	// size, so a smaller segment size drops less history at a time.
+	journalSegments = 8
+
+	journalSegmentPrefix = "events-"
+	journalSegmentSuffix = ".log"
+)
+
+// JournalConfig is the configuration of a Journal.
+type JournalConfig struct {
+	// Root is the directory the journal is stored in.
+	Root string
+	// MaxSize is the maximum size of the journal on disk.
+	MaxSize int64
+	// MaxAge is the maximum age of the events kept in the journal. Events
+	// are kept regardless of their age if it is zero.
+	MaxAge time.Duration
+}
+
+// Journal stores the events on disk, so that they can be replayed after
+// they were dropped from memory, or after a restart of the daemon.
+//
+// The journal is a series of segment files of JSON encoded events, named
+// after the time of their first event. Journal is not safe for concurrent
+// use; Events only uses it from the goroutine writing the events.
+type Journal struct {
+	config   JournalConfig
+	segments []journalSegment
+	f        *os.File
+}
+
+type journalSegment struct {
+	start int64 // TimeNano of the first event of the segment
+	path  string
+	size  int64
+}
+
+// NewJournal opens, or creates, the journal stored in config.Root.
+func NewJournal(config JournalConfig) (*Journal, error) {
+	if config.MaxSize <= 0 {
+		config.MaxSize = DefaultJournalMaxSize
+	}
+	if err := os.MkdirAll(config.Root, 0700); err != nil {
+		return nil, err
+	}
+
+	files, err := ioutil.ReadDir(config.Root)
+	if err != nil {
+		return nil, err
+	}
+	j := &Journal{config: config}
+	for _, fi := range files {
+		name := fi.Name()
+		if fi.IsDir() || !strings.HasPrefix(name, journalSegmentPrefix) || !strings.HasSuffix(name, journalSegmentSuffix) {
+			continue
+		}
+		start, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimPrefix(name, journalSegmentPrefix), journalSegmentSuffix), 10, 64)
+		if err != nil {
+			logrus.Warnf("Ignoring unexpected file in the events journal: %s", name)
+			continue
+		}
+		j.segments = append(j.segments, journalSegment{start: start, path: filepath.Join(config.Root, name), size: fi.Size()})
+	}
+	sort.Sort(bySegmentStart(j.segments))
+
+	if len(j.segments) > 0 {
+		last := j.segments[len(j.segments)-1]
+		complete, err := endsWithNewline(last.path, last.size)
+		if err != nil {
+			return nil, err
+		}
+		// start a new segment rather than appending to an event that was
+		// partially written before a crash
+		if complete {
+			j.f, err = os.OpenFile(last.path, os.O_WRONLY|os.O_APPEND, 0600)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	j.prune(time.Now())
+	return j, nil
+}
+
+// Write appends an event to the journal, starting a new segment if the
+// current one is full.
+func (j *Journal) Write(m eventtypes.Message) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+
+	if j.f == nil || j.segments[len(j.segments)-1].size+int64(len(b)) > j.config.MaxSize/journalSegments {
+		if err := j.rotate(m.TimeNano); err != nil {
+			return err
+		}
+	}
+
+	n, err := j.f.Write(b)
+	j.segments[len(j.segments)-1].size += int64(n)
+	return err
+}
+
+// Close closes the journal.
+func (j *Journal) Close() error {
+	if j.f == nil {
+		return nil
+	}
+	return j.f.Close()
+}
+
+func (j *Journal) rotate(start int64) error {
+	if len(j.segments) > 0 && j.segments[len(j.segments)-1].start >= start {
+		// keep the segments ordered if the clock went backwards
+		start = j.segments[len(j.segments)-1].start + 1
+	}
+	path := filepath.Join(j.config.Root, fmt.Sprintf("%s%d%s", journalSegmentPrefix, start, journalSegmentSuffix))
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	if j.f != nil {
+		j.f.Close()
+	}
+	j.f = f
+	j.segments = append(j.segments, journalSegment{start: start, path: path})
+	j.prune(time.Unix(0, start))
+	return nil
+}
+
+// prune removes the oldest segments while the journal is larger than its
+// maximum size, or while they only hold events older than its maximum age.
+// The current segment is never removed.
+func (j *Journal) prune(now time.Time) {
+	var size int64
+	for _, s := range j.segments {
+		size += s.size
+	}
+
+	var cutoff int64
+	if j.config.MaxAge > 0 {
+		cutoff = now.Add(-j.config.MaxAge).UnixNano()
+	}
+
+	for len(j.segments) > 1 {
+		oldest := j.segments[0]
+		// the events of a segment are older than the start of the next one
+		expired := cutoff > 0 && j.segments[1].start < cutoff
+		// leave room for the current segment to fill up
+		if size+j.config.MaxSize/journalSegments <= j.config.MaxSize && !expired {
+			break
+		}
+		if err := os.Remove(oldest.path); err != nil && !os.IsNotExist(err) {
+			logrus.Errorf("Error removing events journal segment %s: %v", oldest.path, err)
+			break
+		}
+		size -= oldest.size
+		j.segments = j.segments[1:]
+	}
+}
+
+// snapshot returns a copy of the list of segments, to be read while the
+// journal is being written. Only the events written before the snapshot
+// are read from it.
+func (j *Journal) snapshot() []journalSegment {
+	segments := make([]journalSegment, len(j.segments))
+	copy(segments, j.segments)
+	return segments
+}
+
+// readJournal calls fn with the events of segments emitted between since
+// and until that match topic if it is not nil, in order. It stops at the
+// first error returned by fn.
+func readJournal(segments []journalSegment, since, until time.Time, topic func(interface{}) bool, fn func(eventtypes.Message) error) error {
+	var sinceNanoUnix, untilNanoUnix int64
+	if !since.IsZero() {
+		sinceNanoUnix = since.UnixNano()
+	}
+	if !until.IsZero() {
+		untilNanoUnix = until.UnixNano()
+	}
+
+	for i, s := range segments {
+		if i+1 < len(segments) && segments[i+1].start < sinceNanoUnix {
+			// all the events of this segment are too old
+			continue
+		}
+		if untilNanoUnix > 0 && s.start > untilNanoUnix {
+			break
+		}
+
+		if err := readJournalSegment(s, sinceNanoUnix, untilNanoUnix, topic, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readJournalSegment(s journalSegment, since, until int64, topic func(interface{}) bool, fn func(eventtypes.Message) error) error {
+	f, err := os.Open(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// the segment was pruned while reading the journal
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(io.LimitReader(f, s.size))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var m eventtypes.Message
+		if err := json.Unmarshal(scanner.Bytes(), &m); err != nil {
+			// skip events partially written before a crash
+			continue
+		}
+		if m.TimeNano < since || (until > 0 && m.TimeNano > until) {
+			continue
+		}
+		if topic == nil || topic(m) {
+			if err := fn(m); err != nil {
+				return err
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+func endsWithNewline(path string, size int64) (bool, error) {
+	if size == 0 {
+		return true, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	b := make([]byte, 1)
+	if _, err := f.ReadAt(b, size-1); err != nil {
+		return false, err
+	}
+	return b[0] == '\n', nil
+}
+
+type bySegmentStart []journalSegment
+
+func (s bySegmentStart) Len() int           { return len(s) }
+func (s bySegmentStart) Less(i, j int) bool { return s[i].start < s[j].start }
+func (s bySegmentStart) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
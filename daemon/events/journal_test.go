@@ -0,0 +1,222 @@
+package events
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	eventtypes "github.com/docker/docker/api/types/events"
+)
+
+func newTestJournal(t *testing.T, config JournalConfig) (*Journal, func()) {
+	root, err := ioutil.TempDir("", "events-journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.Root = root
+	j, err := NewJournal(config)
+	if err != nil {
+		os.RemoveAll(root)
+		t.Fatal(err)
+	}
+	return j, func() {
+		j.Close()
+		os.RemoveAll(root)
+	}
+}
+
+func journalMessage(action string, t time.Time) eventtypes.Message {
+	return eventtypes.Message{
+		Action:   action,
+		Type:     eventtypes.ContainerEventType,
+		Actor:    eventtypes.Actor{ID: "cont"},
+		Time:     t.Unix(),
+		TimeNano: t.UnixNano(),
+	}
+}
+
+func replayAll(t *testing.T, e *Events, since, until time.Time) ([]eventtypes.Message, chan interface{}) {
+	var messages []eventtypes.Message
+	l, err := e.ReplayTopic(since, until, nil, func(m eventtypes.Message) error {
+		messages = append(messages, m)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return messages, l
+}
+
+func readAll(t *testing.T, j *Journal, since, until time.Time) []eventtypes.Message {
+	var messages []eventtypes.Message
+	err := readJournal(j.snapshot(), since, until, nil, func(m eventtypes.Message) error {
+		messages = append(messages, m)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return messages
+}
+
+func TestEventsJournalReplay(t *testing.T) {
+	j, cleanup := newTestJournal(t, JournalConfig{})
+	defer cleanup()
+
+	e := NewWithJournal(j)
+	for i := 0; i < eventsLimit*2; i++ {
+		e.Log("action", eventtypes.ContainerEventType, eventtypes.Actor{ID: "cont"})
+	}
+
+	// the oldest events are no longer in memory, but are replayed from the
+	// journal
+	since := time.Unix(0, 0)
+	messages, l := replayAll(t, e, since, time.Time{})
+	defer e.Evict(l)
+	if len(messages) != eventsLimit*2 {
+		t.Fatalf("expected %d events, got %d", eventsLimit*2, len(messages))
+	}
+	for i := 1; i < len(messages); i++ {
+		if messages[i].TimeNano < messages[i-1].TimeNano {
+			t.Fatalf("expected the events to be replayed in order, got %v before %v", messages[i-1], messages[i])
+		}
+	}
+
+	// the journal is replayed after a restart
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+	j, err := NewJournal(JournalConfig{Root: j.config.Root})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e = NewWithJournal(j)
+	defer e.Close()
+	messages, l = replayAll(t, e, since, time.Time{})
+	defer e.Evict(l)
+	if len(messages) != eventsLimit*2 {
+		t.Fatalf("expected %d events after restart, got %d", eventsLimit*2, len(messages))
+	}
+}
+
+func TestEventsJournalSinceUntil(t *testing.T) {
+	j, cleanup := newTestJournal(t, JournalConfig{})
+	defer cleanup()
+
+	start := time.Now().Add(-time.Hour)
+	for i := 0; i < 10; i++ {
+		if err := j.Write(journalMessage("action", start.Add(time.Duration(i)*time.Minute))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	messages := readAll(t, j, start.Add(2*time.Minute), start.Add(5*time.Minute))
+	if len(messages) != 4 {
+		t.Fatalf("expected 4 events, got %d", len(messages))
+	}
+	if messages[0].TimeNano != start.Add(2*time.Minute).UnixNano() {
+		t.Fatalf("unexpected first event: %v", messages[0])
+	}
+}
+
+func TestEventsJournalRetention(t *testing.T) {
+	size := int64(len(`{"Type":"container","Action":"action","Actor":{"ID":"cont","Attributes":null},"time":0,"timeNano":0}`))
+
+	// a segment holds about 4 events
+	j, cleanup := newTestJournal(t, JournalConfig{MaxSize: 40 * size, MaxAge: time.Hour})
+	defer cleanup()
+
+	start := time.Now().Add(-3 * time.Hour)
+	for i := 0; i < 120; i++ {
+		if err := j.Write(journalMessage("action", start.Add(time.Duration(i)*time.Minute))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var total int64
+	for _, s := range j.segments {
+		total += s.size
+	}
+	if total > j.config.MaxSize {
+		t.Fatalf("expected the journal to be smaller than %d, got %d", j.config.MaxSize, total)
+	}
+	if len(j.segments) < 2 {
+		t.Fatalf("expected the journal to keep several segments, got %d", len(j.segments))
+	}
+
+	messages := readAll(t, j, time.Unix(0, 0), time.Time{})
+	last := start.Add(119 * time.Minute)
+	if first := time.Unix(0, messages[0].TimeNano); last.Sub(first) > time.Hour+10*time.Minute {
+		t.Fatalf("expected events older than an hour to be pruned, oldest is %v", last.Sub(first))
+	}
+}
+
+func TestEventsJournalReplayPending(t *testing.T) {
+	j, cleanup := newTestJournal(t, JournalConfig{})
+	defer cleanup()
+
+	// the writer is held up, so the events pile up on their way to the
+	// journal, beyond the ones kept in memory
+	e := New()
+	e.journal = j
+	e.journalCh = make(chan journalEntry, bufferSize)
+	e.journalDone = make(chan struct{})
+	for i := 0; i < eventsLimit*2; i++ {
+		e.Log("action", eventtypes.ContainerEventType, eventtypes.Actor{ID: "cont"})
+	}
+	go e.writeJournal(j, e.journalCh)
+
+	messages, l := replayAll(t, e, time.Unix(0, 0), time.Time{})
+	defer e.Evict(l)
+	if len(messages) != eventsLimit*2 {
+		t.Fatalf("expected the events pending for the journal to be replayed, got %d", len(messages))
+	}
+
+	// the events logged after the subscription are only sent to the
+	// listener
+	e.Log("after", eventtypes.ContainerEventType, eventtypes.Actor{ID: "cont"})
+	if m := (<-l).(eventtypes.Message); m.Action != "after" {
+		t.Fatalf("unexpected event: %v", m)
+	}
+
+	// Close waits for the pending events to be written
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+	j, err := NewJournal(JournalConfig{Root: j.config.Root})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer j.Close()
+	if messages := readAll(t, j, time.Unix(0, 0), time.Time{}); len(messages) != eventsLimit*2+1 {
+		t.Fatalf("expected %d events in the journal, got %d", eventsLimit*2+1, len(messages))
+	}
+}
+
+func TestEventsJournalReplayError(t *testing.T) {
+	j, cleanup := newTestJournal(t, JournalConfig{})
+	defer cleanup()
+
+	e := NewWithJournal(j)
+	defer e.Close()
+	for i := 0; i < 10; i++ {
+		e.Log("action", eventtypes.ContainerEventType, eventtypes.Actor{ID: "cont"})
+	}
+
+	var count int
+	_, err := e.ReplayTopic(time.Unix(0, 0), time.Time{}, nil, func(m eventtypes.Message) error {
+		count++
+		if count == 3 {
+			return errors.New("client gone")
+		}
+		return nil
+	})
+	if err == nil || count != 3 {
+		t.Fatalf("expected the replay to stop at the first error, got %v after %d events", err, count)
+	}
+	if n := e.SubscribersCount(); n != 0 {
+		t.Fatalf("expected the listener to be evicted, got %d listeners", n)
+	}
+}
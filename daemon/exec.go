@@ -3,7 +3,12 @@ package daemon
 import (
 	"fmt"
 	"io"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"golang.org/x/net/context"
@@ -18,12 +23,17 @@ import (
 	"github.com/docker/docker/libcontainerd"
 	"github.com/docker/docker/pkg/pools"
 	"github.com/docker/docker/pkg/signal"
+	"github.com/docker/docker/pkg/system"
 	"github.com/docker/docker/pkg/term"
 )
 
 // Seconds to wait after sending TERM before trying KILL
 const termProcessTimeout = 10
 
+// execRetention is how long finished execs are kept by the daemon, so that
+// they can still be inspected and listed after they exited.
+const execRetention = 15 * time.Minute
+
 func (d *Daemon) registerExecCommand(container *container.Container, config *exec.Config) {
 	// Storing execs in container in order to kill them gracefully whenever the container is stopped or removed.
 	container.ExecCommands.Add(config.ID, config)
@@ -99,6 +109,16 @@ func (d *Daemon) ContainerExecCreate(name string, config *types.ExecConfig) (str
 		return "", err
 	}
 
+	if config.WorkingDir != "" {
+		config.WorkingDir = filepath.FromSlash(config.WorkingDir) // Ensure in platform semantics
+		if !system.IsAbs(config.WorkingDir) {
+			return "", fmt.Errorf("the working directory '%s' is invalid, it needs to be an absolute path", config.WorkingDir)
+		}
+	}
+	if config.Init && !cntr.HasInit {
+		return "", fmt.Errorf("Cannot run an init in the exec: container %s was not started with an init", cntr.ID)
+	}
+
 	cmd := strslice.StrSlice(config.Cmd)
 	entrypoint, args := d.getEntrypointAndArgs(strslice.StrSlice{}, cmd)
 
@@ -122,6 +142,8 @@ func (d *Daemon) ContainerExecCreate(name string, config *types.ExecConfig) (str
 	execConfig.Tty = config.Tty
 	execConfig.Privileged = config.Privileged
 	execConfig.User = config.User
+	execConfig.WorkingDir = config.WorkingDir
+	execConfig.Init = config.Init
 
 	linkedEnv, err := d.setupLinkedContainers(cntr)
 	if err != nil {
@@ -170,6 +192,7 @@ func (d *Daemon) ContainerExecStart(ctx context.Context, name string, stdin io.R
 			ec.Running = false
 			exitCode := 126
 			ec.ExitCode = &exitCode
+			ec.FinishedAt = time.Now().UTC()
 		}
 	}()
 	ec.Unlock()
@@ -230,6 +253,7 @@ func (d *Daemon) ContainerExecStart(ctx context.Context, name string, stdin io.R
 	}
 	ec.Lock()
 	ec.Pid = systemPid
+	ec.StartedAt = time.Now().UTC()
 	ec.Unlock()
 
 	select {
@@ -255,8 +279,87 @@ func (d *Daemon) ContainerExecStart(ctx context.Context, name string, stdin io.R
 	return nil
 }
 
+// ContainerExecKill sends a signal to the process of a running exec
+// instance. SIGKILL is sent if no signal is given.
+func (d *Daemon) ContainerExecKill(name string, sig uint64) error {
+	ec, err := d.getExecConfig(name)
+	if err != nil {
+		return err
+	}
+
+	if sig == 0 {
+		sig = uint64(syscall.SIGKILL)
+	}
+	if !signal.ValidSignalForPlatform(syscall.Signal(sig)) {
+		return fmt.Errorf("The %s daemon does not support signal %d", runtime.GOOS, sig)
+	}
+
+	ec.Lock()
+	running := ec.Running && ec.Pid != 0
+	ec.Unlock()
+	if !running {
+		return errors.NewRequestConflictError(fmt.Errorf("Exec %s is not running", ec.ID))
+	}
+
+	c := d.containers.Get(ec.ContainerID)
+	if c == nil {
+		return errExecNotFound(name)
+	}
+	logrus.Debugf("Sending signal %d to exec %s in container %s", sig, ec.ID, c.ID)
+	if err := d.containerd.SignalProcess(c.ID, ec.ID, int(sig)); err != nil {
+		return fmt.Errorf("Cannot kill exec %s: %v", ec.ID, err)
+	}
+
+	d.LogContainerEventWithAttributes(c, "exec_kill: "+ec.Entrypoint+" "+strings.Join(ec.Args, " "), map[string]string{
+		"execID": ec.ID,
+		"signal": strconv.Itoa(int(sig)),
+	})
+	return nil
+}
+
+// ContainerExecList returns the execs of a container that are running, or
+// that were recently run, ordered by creation time.
+func (d *Daemon) ContainerExecList(name string) ([]*types.ExecSummary, error) {
+	c, err := d.GetContainer(name)
+	if err != nil {
+		return nil, err
+	}
+
+	execs := []*types.ExecSummary{}
+	for _, ec := range d.execCommands.Commands() {
+		if ec.ContainerID != c.ID {
+			continue
+		}
+		ec.Lock()
+		execs = append(execs, &types.ExecSummary{
+			ID:         ec.ID,
+			Cmd:        append([]string{ec.Entrypoint}, ec.Args...),
+			User:       ec.User,
+			WorkingDir: ec.WorkingDir,
+			Privileged: ec.Privileged,
+			Tty:        ec.Tty,
+			Running:    ec.Running,
+			ExitCode:   ec.ExitCode,
+			Pid:        ec.Pid,
+			CreatedAt:  ec.CreatedAt,
+			StartedAt:  ec.StartedAt,
+			FinishedAt: ec.FinishedAt,
+		})
+		ec.Unlock()
+	}
+	sort.Sort(byExecCreated(execs))
+	return execs, nil
+}
+
+type byExecCreated []*types.ExecSummary
+
+func (r byExecCreated) Len() int           { return len(r) }
+func (r byExecCreated) Swap(i, j int)      { r[i], r[j] = r[j], r[i] }
+func (r byExecCreated) Less(i, j int) bool { return r[i].CreatedAt.Before(r[j].CreatedAt) }
+
 // execCommandGC runs a ticker to clean up the daemon references
-// of exec configs that are no longer part of the container.
+// of exec configs that are no longer part of the container. Finished
+// execs are kept for execRetention.
 func (d *Daemon) execCommandGC() {
 	for range time.Tick(5 * time.Minute) {
 		var (
@@ -268,7 +371,7 @@ func (d *Daemon) execCommandGC() {
 				cleaned++
 				d.execCommands.Delete(id)
 			} else {
-				if _, exists := liveExecCommands[id]; !exists {
+				if _, exists := liveExecCommands[id]; !exists && time.Since(config.FinishedAt) > execRetention {
 					config.CanRemove = true
 				}
 			}
@@ -3,6 +3,7 @@ package exec
 import (
 	"runtime"
 	"sync"
+	"time"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/container/stream"
@@ -31,7 +32,12 @@ type Config struct {
 	Privileged   bool
 	User         string
 	Env          []string
+	WorkingDir   string
+	Init         bool
 	Pid          int
+	CreatedAt    time.Time
+	StartedAt    time.Time
+	FinishedAt   time.Time
 }
 
 // NewConfig initializes the a new exec configuration
@@ -39,6 +45,7 @@ func NewConfig() *Config {
 	return &Config{
 		ID:           stringid.GenerateNonCryptoID(),
 		StreamConfig: stream.NewConfig(),
+		CreatedAt:    time.Now().UTC(),
 	}
 }
 
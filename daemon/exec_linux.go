@@ -23,5 +23,13 @@ func execSetPlatformOpt(c *container.Container, ec *exec.Config, p *libcontainer
 	if ec.Privileged {
 		p.Capabilities = caps.GetAllCapabilities()
 	}
+	if ec.WorkingDir != "" {
+		p.Cwd = &ec.WorkingDir
+	}
+	if ec.Init {
+		// the init is not the pid 1 of the container, so it must register
+		// as a subreaper to reap the zombie processes of the exec
+		p.Args = append([]string{"/dev/init", "-s", "--"}, p.Args...)
+	}
 	return nil
 }
@@ -0,0 +1,123 @@
+package daemon
+
+import (
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	eventtypes "github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/events"
+	"github.com/docker/docker/daemon/exec"
+	"github.com/docker/docker/libcontainerd"
+)
+
+type signalClient struct {
+	libcontainerd.Client
+	containerID string
+	processID   string
+	sig         int
+}
+
+func (c *signalClient) SignalProcess(containerID string, processFriendlyName string, sig int) error {
+	c.containerID, c.processID, c.sig = containerID, processFriendlyName, sig
+	return nil
+}
+
+func newExecKillDaemon(t *testing.T) (*Daemon, *exec.Config, *signalClient) {
+	c := &container.Container{
+		CommonContainer: container.CommonContainer{
+			ID:           "container_id",
+			Name:         "/container_name",
+			Config:       &containertypes.Config{},
+			State:        &container.State{Running: true},
+			ExecCommands: exec.NewStore(),
+		},
+	}
+	ec := exec.NewConfig()
+	ec.ID = "exec_id"
+	ec.ContainerID = c.ID
+	ec.Entrypoint = "sh"
+	ec.Args = []string{"-c", "sleep 60"}
+
+	client := &signalClient{}
+	d := &Daemon{
+		containers:    container.NewMemoryStore(),
+		execCommands:  exec.NewStore(),
+		containerd:    client,
+		EventsService: events.New(),
+	}
+	d.containers.Add(c.ID, c)
+	d.registerExecCommand(c, ec)
+	return d, ec, client
+}
+
+func TestContainerExecKill(t *testing.T) {
+	d, ec, client := newExecKillDaemon(t)
+	ec.Running = true
+	ec.Pid = 42
+
+	_, l, _ := d.EventsService.Subscribe()
+	defer d.EventsService.Evict(l)
+
+	if err := d.ContainerExecKill("exec_id", uint64(syscall.SIGTERM)); err != nil {
+		t.Fatal(err)
+	}
+	if client.containerID != "container_id" || client.processID != "exec_id" || client.sig != int(syscall.SIGTERM) {
+		t.Fatalf("unexpected signal sent: %+v", client)
+	}
+
+	select {
+	case ev := <-l:
+		m := ev.(eventtypes.Message)
+		if m.Action != "exec_kill: sh -c sleep 60" {
+			t.Fatalf("unexpected event action: %q", m.Action)
+		}
+		if m.Actor.Attributes["execID"] != "exec_id" || m.Actor.Attributes["signal"] != "15" {
+			t.Fatalf("unexpected event attributes: %v", m.Actor.Attributes)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected an exec_kill event")
+	}
+
+	// SIGKILL is sent by default
+	if err := d.ContainerExecKill("exec_id", 0); err != nil {
+		t.Fatal(err)
+	}
+	if client.sig != int(syscall.SIGKILL) {
+		t.Fatalf("expected SIGKILL, got %d", client.sig)
+	}
+}
+
+func TestContainerExecKillNotRunning(t *testing.T) {
+	d, _, client := newExecKillDaemon(t)
+
+	err := d.ContainerExecKill("exec_id", 0)
+	if err == nil || !strings.Contains(err.Error(), "is not running") {
+		t.Fatalf("expected a not running error, got %v", err)
+	}
+	if client.processID != "" {
+		t.Fatal("expected no signal to be sent")
+	}
+}
+
+func TestContainerExecKillErrors(t *testing.T) {
+	d, ec, _ := newExecKillDaemon(t)
+	ec.Running = true
+	ec.Pid = 42
+
+	err := d.ContainerExecKill("unknown", 0)
+	if err == nil || !strings.Contains(err.Error(), "No such exec instance") {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+
+	// the error of a paused container is kept
+	c := d.containers.Get("container_id")
+	c.State.Paused = true
+	err = d.ContainerExecKill("exec_id", 0)
+	if err == nil || err.Error() != errExecPaused("container_id").Error() {
+		t.Fatalf("expected the paused container error, got %v", err)
+	}
+}
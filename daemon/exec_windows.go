@@ -10,5 +10,6 @@ func execSetPlatformOpt(c *container.Container, ec *exec.Config, p *libcontainer
 	// Process arguments need to be escaped before sending to OCI.
 	p.Args = escapeArgs(p.Args)
 	p.User.Username = ec.User
+	p.Cwd = ec.WorkingDir
 	return nil
 }
@@ -1,18 +1,63 @@
 package daemon
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"runtime"
+	"strings"
 
+	containertypes "github.com/docker/docker/api/types/container"
+	mounttypes "github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/container"
+	"github.com/docker/docker/image"
 	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/ioutils"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/docker/docker/pkg/system"
+	"github.com/docker/docker/volume"
 )
 
+const (
+	bundleConfigFileName = "container.json"
+	bundleRootFSFileName = "rootfs.tar"
+	bundleVolumesDir     = "volumes"
+)
+
+// containerBundle is the configuration of a container in the archive of a
+// full export.
+type containerBundle struct {
+	Name       string
+	ImageID    image.ID
+	Config     *containertypes.Config
+	HostConfig *containertypes.HostConfig
+	Volumes    []containerBundleVolume
+}
+
+// containerBundleVolume is a volume mounted in a container in the archive of
+// a full export.
+type containerBundleVolume struct {
+	Name        string
+	Driver      string
+	Destination string
+	Options     map[string]string `json:",omitempty"`
+	Labels      map[string]string `json:",omitempty"`
+	// Anonymous is true if the volume was created for the container
+	// without a name.
+	Anonymous bool
+	// Data is true if the data of the volume is in the archive.
+	Data bool
+}
+
 // ContainerExport writes the contents of the container to the given
-// writer. An error is returned if the container cannot be found.
-func (daemon *Daemon) ContainerExport(name string, out io.Writer) error {
+// writer. If full is true, the configuration of the container and the
+// changes to its filesystem are written instead, with the data of its
+// volumes if volumes is true, so that the container can be imported with
+// ContainerImport. An error is returned if the container cannot be found.
+func (daemon *Daemon) ContainerExport(name string, full, volumes bool, out io.Writer) error {
 	if runtime.GOOS == "windows" {
 		return fmt.Errorf("the daemon on this platform does not support export of a container")
 	}
@@ -22,6 +67,13 @@ func (daemon *Daemon) ContainerExport(name string, out io.Writer) error {
 		return err
 	}
 
+	if full {
+		if err := daemon.containerExportFull(container, volumes, out); err != nil {
+			return fmt.Errorf("Error exporting container %s: %v", name, err)
+		}
+		return nil
+	}
+
 	data, err := daemon.containerExport(container)
 	if err != nil {
 		return fmt.Errorf("Error exporting container %s: %v", name, err)
@@ -58,3 +110,145 @@ func (daemon *Daemon) containerExport(container *container.Container) (io.ReadCl
 	daemon.LogContainerEvent(container, "export")
 	return arch, err
 }
+
+// containerExportFull writes an archive with the configuration of the
+// container, the changes to its filesystem and optionally the data of its
+// volumes to out.
+func (daemon *Daemon) containerExportFull(container *container.Container, volumes bool, out io.Writer) error {
+	tmpDir, err := ioutil.TempDir("", "docker-export-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	config, vols, err := daemon.containerBundleJSON(container, volumes)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(tmpDir, bundleConfigFileName), config, 0600); err != nil {
+		return err
+	}
+
+	for _, v := range vols {
+		if err := daemon.exportVolume(v, filepath.Join(tmpDir, bundleVolumesDir, v.Name()+".tar")); err != nil {
+			return fmt.Errorf("error exporting volume %s: %v", v.Name(), err)
+		}
+	}
+
+	if err := daemon.exportRWLayer(container, filepath.Join(tmpDir, bundleRootFSFileName)); err != nil {
+		return err
+	}
+
+	arch, err := archive.Tar(tmpDir, archive.Uncompressed)
+	if err != nil {
+		return err
+	}
+	defer arch.Close()
+
+	if _, err := io.Copy(out, arch); err != nil {
+		return err
+	}
+	daemon.LogContainerEvent(container, "export")
+	return nil
+}
+
+// containerBundleJSON returns the configuration of the container in the
+// archive of a full export. If volumes is true, it also returns the volumes
+// of the container, whose data is in the archive.
+func (daemon *Daemon) containerBundleJSON(container *container.Container, volumes bool) ([]byte, []volume.Volume, error) {
+	container.Lock()
+	defer container.Unlock()
+
+	bundle := containerBundle{
+		Name:       strings.TrimPrefix(container.Name, "/"),
+		ImageID:    container.ImageID,
+		Config:     container.Config,
+		HostConfig: container.HostConfig,
+	}
+
+	var vols []volume.Volume
+	for _, m := range container.MountPoints {
+		// The volumes mounted from other containers have no type, they
+		// are exported with these containers
+		if m.Type != mounttypes.TypeVolume {
+			continue
+		}
+		v, err := daemon.volumes.Get(m.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+		bundleVolume := containerBundleVolume{
+			Name:        v.Name(),
+			Driver:      v.DriverName(),
+			Destination: m.Destination,
+			// The volumes specified by name have a source
+			Anonymous: m.Spec.Source == "",
+			Data:      volumes,
+		}
+		if dv, ok := v.(volume.DetailedVolume); ok {
+			bundleVolume.Options = dv.Options()
+			bundleVolume.Labels = dv.Labels()
+		}
+		bundle.Volumes = append(bundle.Volumes, bundleVolume)
+		if volumes {
+			vols = append(vols, v)
+		}
+	}
+
+	dt, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, nil, err
+	}
+	return dt, vols, nil
+}
+
+// exportRWLayer writes the changes to the filesystem of the container to the
+// file at path.
+func (daemon *Daemon) exportRWLayer(container *container.Container, path string) error {
+	data, err := container.RWLayer.TarStream()
+	if err != nil {
+		return err
+	}
+	defer data.Close()
+
+	return writeTarFile(path, data)
+}
+
+// exportVolume writes the data of the volume v to the file at path.
+func (daemon *Daemon) exportVolume(v volume.Volume, path string) error {
+	id := stringid.GenerateNonCryptoID()
+	volumePath, err := v.Mount(id)
+	if err != nil {
+		return err
+	}
+	defer v.Unmount(id)
+
+	uidMaps, gidMaps := daemon.GetUIDGIDMaps()
+	data, err := archive.TarWithOptions(volumePath, &archive.TarOptions{
+		Compression: archive.Uncompressed,
+		UIDMaps:     uidMaps,
+		GIDMaps:     gidMaps,
+	})
+	if err != nil {
+		return err
+	}
+	defer data.Close()
+
+	return writeTarFile(path, data)
+}
+
+// writeTarFile writes the archive data to the file at path.
+func writeTarFile(path string, data io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	f, err := system.CreateSequential(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
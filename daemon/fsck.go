@@ -0,0 +1,118 @@
+package daemon
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
+	"github.com/opencontainers/go-digest"
+)
+
+// SystemFsck verifies the integrity of the layers of the daemon. If repair is
+// true, it removes the layers of the storage driver and the RW layers that
+// are not used, and the images that use corrupt layers. The images used by
+// containers are not removed. The references of the removed images are
+// returned, so that they can be pulled again.
+func (daemon *Daemon) SystemFsck(repair bool) (*types.FsckReport, error) {
+	verifyReport, err := daemon.layerStore.Verify()
+	if err != nil {
+		return nil, err
+	}
+
+	rep := &types.FsckReport{
+		DanglingLayers:   verifyReport.DanglingCacheIDs,
+		OrphanedRWLayers: verifyReport.OrphanedRWLayers,
+	}
+
+	allImages := daemon.imageStore.Map()
+	corruptImages := map[image.ID]*image.Image{}
+	for _, cl := range verifyReport.CorruptLayers {
+		item := types.FsckCorruptLayer{
+			ChainID: cl.ChainID.String(),
+			DiffID:  cl.DiffID.String(),
+			Error:   cl.Err.Error(),
+		}
+		for id, img := range allImages {
+			if imageUsesLayer(img, cl.ChainID) {
+				item.Images = append(item.Images, id.String())
+				corruptImages[id] = img
+			}
+		}
+		sort.Strings(item.Images)
+		rep.CorruptLayers = append(rep.CorruptLayers, item)
+	}
+
+	if !repair {
+		return rep, nil
+	}
+
+	if err := daemon.layerStore.Repair(verifyReport); err != nil {
+		return nil, err
+	}
+
+	// The images are removed from the ones with the most layers, so that the
+	// child images are removed before their parent
+	var ids []image.ID
+	for id := range corruptImages {
+		ids = append(ids, id)
+	}
+	sort.Sort(byLayerCount{ids, corruptImages})
+
+	for _, id := range ids {
+		// The image is used by the containers created from it, and by the
+		// ones which mount it
+		if c := daemon.getContainerUsingImage(id); c != nil {
+			rep.Warnings = append(rep.Warnings, fmt.Sprintf("image %s uses a corrupt layer but was not removed because it is used by container %s", id, c.ID))
+			continue
+		}
+
+		// The image may have been removed with one of its children
+		if _, err := daemon.imageStore.Get(id); err != nil {
+			continue
+		}
+
+		refs := daemon.referenceStore.References(digest.Digest(id))
+		deleted, err := daemon.ImageDelete(id.String(), true, true, false)
+		if err != nil {
+			logrus.Warnf("could not delete image %s: %v", id, err)
+			rep.Warnings = append(rep.Warnings, fmt.Sprintf("could not remove image %s: %v", id, err))
+			continue
+		}
+		rep.ImagesDeleted = append(rep.ImagesDeleted, deleted...)
+		for _, ref := range refs {
+			rep.RemovedReferences = append(rep.RemovedReferences, ref.String())
+		}
+	}
+
+	return rep, nil
+}
+
+// imageUsesLayer returns true if the layer with the given ChainID is one of
+// the layers of the image.
+func imageUsesLayer(img *image.Image, chainID layer.ChainID) bool {
+	if img.RootFS == nil {
+		return false
+	}
+	for i := range img.RootFS.DiffIDs {
+		if layer.CreateChainID(img.RootFS.DiffIDs[:i+1]) == chainID {
+			return true
+		}
+	}
+	return false
+}
+
+// byLayerCount is a temporary type used to sort images by their number of
+// layers, in decreasing order.
+type byLayerCount struct {
+	ids    []image.ID
+	images map[image.ID]*image.Image
+}
+
+func (r byLayerCount) Len() int      { return len(r.ids) }
+func (r byLayerCount) Swap(i, j int) { r.ids[i], r.ids[j] = r.ids[j], r.ids[i] }
+func (r byLayerCount) Less(i, j int) bool {
+	return len(r.images[r.ids[i]].RootFS.DiffIDs) > len(r.images[r.ids[j]].RootFS.DiffIDs)
+}
@@ -170,6 +170,11 @@ func supportsAufs() error {
 	return ErrAufsNotSupported
 }
 
+// ListLayers returns the IDs of the layers stored by the driver.
+func (a *Driver) ListLayers() ([]string, error) {
+	return loadIds(path.Join(a.rootPath(), "layers"))
+}
+
 func (a *Driver) rootPath() string {
 	return a.root
 }
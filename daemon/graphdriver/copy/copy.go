@@ -1,9 +1,13 @@
 // +build linux
 
-package overlay
+// Package copy implements the copy of the directories of the layers, which
+// is used by the graph drivers that can't share the content of a layer with
+// its parent.
+package copy
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"syscall"
@@ -14,13 +18,58 @@ import (
 	rsystem "github.com/opencontainers/runc/libcontainer/system"
 )
 
-type copyFlags int
+// Mode indicates whether to use hardlink or copy the content of the files
+type Mode int
 
 const (
-	copyHardlink copyFlags = 1 << iota
+	// Content copies the content of the files, or clones them copy-on-write
+	// when the filesystem supports it
+	Content Mode = iota
+	// Hardlink makes the files of the destination hard links to the files
+	// of the source
+	Hardlink
 )
 
-func copyRegular(srcPath, dstPath string, mode os.FileMode) error {
+// ficlone is the FICLONE ioctl, _IOW(0x94, 9, int). On the architectures
+// where it has another value, the ioctl fails and the files are copied.
+const ficlone = 0x40049409
+
+// cloneFile makes dst share the extents of src, copy-on-write.
+func cloneFile(dst, src *os.File) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, dst.Fd(), ficlone, src.Fd()); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// SupportsReflink returns true if the files of the directory dir can be
+// cloned copy-on-write, which is the case on btrfs and XFS with reflink.
+func SupportsReflink(dir string) bool {
+	src, err := ioutil.TempFile(dir, "reflink-check-")
+	if err != nil {
+		return false
+	}
+	defer os.Remove(src.Name())
+	defer src.Close()
+	if _, err := src.Write([]byte("reflink")); err != nil {
+		return false
+	}
+
+	dst, err := ioutil.TempFile(dir, "reflink-check-")
+	if err != nil {
+		return false
+	}
+	defer os.Remove(dst.Name())
+	defer dst.Close()
+
+	return cloneFile(dst, src) == nil
+}
+
+// copyRegular copies the content of a regular file. It is cloned if *clone
+// is true, and *clone is set to false if the file can't be cloned, for
+// example if the filesystem doesn't support it, so that the following files
+// are copied directly.
+func copyRegular(srcPath, dstPath string, mode os.FileMode, clone *bool) error {
 	srcFile, err := os.Open(srcPath)
 	if err != nil {
 		return err
@@ -33,6 +82,13 @@ func copyRegular(srcPath, dstPath string, mode os.FileMode) error {
 	}
 	defer dstFile.Close()
 
+	if *clone {
+		if err := cloneFile(dstFile, srcFile); err == nil {
+			return nil
+		}
+		*clone = false
+	}
+
 	_, err = pools.Copy(dstFile, srcFile)
 
 	return err
@@ -51,7 +107,14 @@ func copyXattr(srcPath, dstPath, attr string) error {
 	return nil
 }
 
-func copyDir(srcDir, dstDir string, flags copyFlags) error {
+// DirCopy copies the content of the directory srcDir to the directory
+// dstDir, which must exist. With the Content mode, the hard links between
+// the files of srcDir are kept.
+func DirCopy(srcDir, dstDir string, copyMode Mode) error {
+	clone := true
+	// Paths of the copied files with several links, by inode
+	copiedInodes := make(map[uint64]string)
+
 	err := filepath.Walk(srcDir, func(srcPath string, f os.FileInfo, err error) error {
 		if err != nil {
 			return err
@@ -64,9 +127,6 @@ func copyDir(srcDir, dstDir string, flags copyFlags) error {
 		}
 
 		dstPath := filepath.Join(dstDir, relPath)
-		if err != nil {
-			return err
-		}
 
 		stat, ok := f.Sys().(*syscall.Stat_t)
 		if !ok {
@@ -77,13 +137,21 @@ func copyDir(srcDir, dstDir string, flags copyFlags) error {
 
 		switch f.Mode() & os.ModeType {
 		case 0: // Regular file
-			if flags&copyHardlink != 0 {
+			if copyMode == Hardlink {
 				isHardlink = true
 				if err := os.Link(srcPath, dstPath); err != nil {
 					return err
 				}
+			} else if linkPath, ok := copiedInodes[stat.Ino]; ok && stat.Nlink > 1 {
+				isHardlink = true
+				if err := os.Link(linkPath, dstPath); err != nil {
+					return err
+				}
 			} else {
-				if err := copyRegular(srcPath, dstPath, f.Mode()); err != nil {
+				if stat.Nlink > 1 {
+					copiedInodes[stat.Ino] = dstPath
+				}
+				if err := copyRegular(srcPath, dstPath, f.Mode(), &clone); err != nil {
 					return err
 				}
 			}
@@ -0,0 +1,113 @@
+// +build linux
+
+package copy
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+func TestDirCopy(t *testing.T) {
+	src, err := ioutil.TempDir("", "copy-src-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(src)
+	dst, err := ioutil.TempDir("", "copy-dst-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dst)
+
+	if err := os.Mkdir(filepath.Join(src, "dir"), 0710); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(src, "dir", "file"), []byte("content"), 0640); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Link(filepath.Join(src, "dir", "file"), filepath.Join(src, "link")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("dir/file", filepath.Join(src, "symlink")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := DirCopy(src, dst, Content); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := ioutil.ReadFile(filepath.Join(dst, "dir", "file"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "content" {
+		t.Fatalf("Unexpected content %q", content)
+	}
+
+	fi, err := os.Stat(filepath.Join(dst, "dir", "file"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode().Perm() != 0640 {
+		t.Fatalf("Expected mode 0640, got %o", fi.Mode().Perm())
+	}
+	di, err := os.Stat(filepath.Join(dst, "dir"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if di.Mode().Perm() != 0710 {
+		t.Fatalf("Expected mode 0710, got %o", di.Mode().Perm())
+	}
+
+	li, err := os.Stat(filepath.Join(dst, "link"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Sys().(*syscall.Stat_t).Ino != li.Sys().(*syscall.Stat_t).Ino {
+		t.Fatal("Expected the hard link to be kept")
+	}
+
+	target, err := os.Readlink(filepath.Join(dst, "symlink"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if target != "dir/file" {
+		t.Fatalf("Unexpected symlink target %q", target)
+	}
+}
+
+func TestDirCopyHardlink(t *testing.T) {
+	src, err := ioutil.TempDir("", "copy-src-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(src)
+	dst, err := ioutil.TempDir("", "copy-dst-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dst)
+
+	if err := ioutil.WriteFile(filepath.Join(src, "file"), []byte("content"), 0640); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := DirCopy(src, dst, Hardlink); err != nil {
+		t.Fatal(err)
+	}
+
+	si, err := os.Stat(filepath.Join(src, "file"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	di, err := os.Stat(filepath.Join(dst, "file"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !os.SameFile(si, di) {
+		t.Fatal("Expected the file to be hard linked")
+	}
+}
@@ -0,0 +1,26 @@
+// +build !linux
+
+package copy
+
+import "fmt"
+
+// Mode indicates whether to use hardlink or copy the content of the files
+type Mode int
+
+const (
+	// Content copies the content of the files
+	Content Mode = iota
+	// Hardlink makes the files of the destination hard links to the files
+	// of the source
+	Hardlink
+)
+
+// SupportsReflink returns false, as files can only be cloned on Linux.
+func SupportsReflink(dir string) bool {
+	return false
+}
+
+// DirCopy is not supported on this platform.
+func DirCopy(srcDir, dstDir string, copyMode Mode) error {
+	return fmt.Errorf("directory copy is not supported on this platform")
+}
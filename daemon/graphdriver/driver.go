@@ -121,6 +121,13 @@ type DiffGetterDriver interface {
 	DiffGetter(id string) (FileGetCloser, error)
 }
 
+// LayerLister is the interface for drivers that can list the layers they
+// store, for finding the ones that are not used anymore.
+type LayerLister interface {
+	// ListLayers returns the IDs of the layers stored by the driver.
+	ListLayers() ([]string, error)
+}
+
 // FileGetCloser extends the storage.FileGetter interface with a Close method
 // for cleaning up.
 type FileGetCloser interface {
@@ -268,3 +275,25 @@ func scanPriorDrivers(root string) map[string]bool {
 	}
 	return driversMap
 }
+
+// ListDirs returns the names of the directories in dir, for the drivers that
+// store each layer in a directory named after its ID.
+func ListDirs(dir string) ([]string, error) {
+	f, err := os.Open(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	fis, err := f.Readdir(-1)
+	if err != nil {
+		return nil, err
+	}
+	var dirs []string
+	for _, fi := range fis {
+		if fi.IsDir() {
+			dirs = append(dirs, fi.Name())
+		}
+	}
+	return dirs, nil
+}
@@ -167,3 +167,12 @@ func (gdw *NaiveDiffDriver) DiffSize(id, parent string) (size int64, err error)
 
 	return archive.ChangesSize(layerFs, changes), nil
 }
+
+// ListLayers returns the IDs of the layers stored by the wrapped driver,
+// or ErrNotSupported if it can't list them.
+func (gdw *NaiveDiffDriver) ListLayers() ([]string, error) {
+	if lister, ok := gdw.ProtoDriver.(LayerLister); ok {
+		return lister.ListLayers()
+	}
+	return nil, ErrNotSupported
+}
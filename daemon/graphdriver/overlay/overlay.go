@@ -15,6 +15,7 @@ import (
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/daemon/graphdriver"
+	"github.com/docker/docker/daemon/graphdriver/copy"
 	"github.com/docker/docker/daemon/graphdriver/overlayutils"
 	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/fsutils"
@@ -62,6 +63,14 @@ func (d *naiveDiffDriverWithApply) ApplyDiff(id, parent string, diff io.Reader)
 	return b, err
 }
 
+// ListLayers returns the IDs of the layers stored by the driver.
+func (d *naiveDiffDriverWithApply) ListLayers() ([]string, error) {
+	if lister, ok := d.applyDiff.(graphdriver.LayerLister); ok {
+		return lister.ListLayers()
+	}
+	return nil, graphdriver.ErrNotSupported
+}
+
 // This backend uses the overlay union filesystem for containers
 // plus hard link file sharing for images.
 
@@ -325,13 +334,18 @@ func (d *Driver) Create(id, parent string, opts *graphdriver.CreateOpts) (retErr
 		return err
 	}
 
-	return copyDir(parentUpperDir, upperDir, 0)
+	return copy.DirCopy(parentUpperDir, upperDir, copy.Content)
 }
 
 func (d *Driver) dir(id string) string {
 	return path.Join(d.home, id)
 }
 
+// ListLayers returns the IDs of the layers stored by the driver.
+func (d *Driver) ListLayers() ([]string, error) {
+	return graphdriver.ListDirs(d.home)
+}
+
 // Remove cleans the directories that are created for this id.
 func (d *Driver) Remove(id string) error {
 	if err := os.RemoveAll(d.dir(id)); err != nil && !os.IsNotExist(err) {
@@ -438,7 +452,7 @@ func (d *Driver) ApplyDiff(id string, parent string, diff io.Reader) (size int64
 		}
 	}()
 
-	if err = copyDir(parentRootDir, tmpRootDir, copyHardlink); err != nil {
+	if err = copy.DirCopy(parentRootDir, tmpRootDir, copy.Hardlink); err != nil {
 		return 0, err
 	}
 
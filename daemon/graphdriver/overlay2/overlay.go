@@ -432,6 +432,21 @@ func (d *Driver) dir(id string) string {
 	return path.Join(d.home, id)
 }
 
+// ListLayers returns the IDs of the layers stored by the driver.
+func (d *Driver) ListLayers() ([]string, error) {
+	dirs, err := graphdriver.ListDirs(d.home)
+	if err != nil {
+		return nil, err
+	}
+	ids := dirs[:0]
+	for _, dir := range dirs {
+		if dir != linkDir {
+			ids = append(ids, dir)
+		}
+	}
+	return ids, nil
+}
+
 func (d *Driver) getLowerDirs(id string) ([]string, error) {
 	var lowersArray []string
 	lowers, err := ioutil.ReadFile(path.Join(d.dir(id), lowerFile))
@@ -0,0 +1,226 @@
+// +build linux freebsd
+
+package storagecheck
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/docker/docker/daemon/graphdriver"
+	"github.com/docker/docker/pkg/stringid"
+)
+
+// benchCreate benchmarks calls to create a layer on top of a layer.
+func benchCreate(driver graphdriver.Driver) (func(t *Timer) (int64, error), error) {
+	base := stringid.GenerateRandomID()
+	if err := driver.Create(base, "", nil); err != nil {
+		return nil, err
+	}
+	if err := addManyFiles(driver, base, 100, 3); err != nil {
+		return nil, err
+	}
+
+	return func(t *Timer) (int64, error) {
+		layer := stringid.GenerateRandomID()
+		if err := driver.Create(layer, base, nil); err != nil {
+			return 0, err
+		}
+		t.Stop()
+		defer t.Start()
+		return 0, driver.Remove(layer)
+	}, nil
+}
+
+// benchExists benchmarks calls to exist.
+func benchExists(driver graphdriver.Driver) (func(t *Timer) (int64, error), error) {
+	base := stringid.GenerateRandomID()
+	if err := driver.Create(base, "", nil); err != nil {
+		return nil, err
+	}
+
+	return func(t *Timer) (int64, error) {
+		if !driver.Exists(base) {
+			return 0, errors.New("newly created layer doesn't exist")
+		}
+		return 0, nil
+	}, nil
+}
+
+// benchGetEmpty benchmarks calls to get on an empty layer.
+func benchGetEmpty(driver graphdriver.Driver) (func(t *Timer) (int64, error), error) {
+	base := stringid.GenerateRandomID()
+	if err := driver.Create(base, "", nil); err != nil {
+		return nil, err
+	}
+
+	return func(t *Timer) (int64, error) {
+		_, err := driver.Get(base, "")
+		t.Stop()
+		defer t.Start()
+		if err != nil {
+			return 0, fmt.Errorf("error getting mount: %v", err)
+		}
+		if err := driver.Put(base); err != nil {
+			return 0, fmt.Errorf("error putting mount: %v", err)
+		}
+		return 0, nil
+	}, nil
+}
+
+// benchDiffBase benchmarks calls to diff on a root layer.
+func benchDiffBase(driver graphdriver.Driver) (func(t *Timer) (int64, error), error) {
+	base := stringid.GenerateRandomID()
+	if err := driver.Create(base, "", nil); err != nil {
+		return nil, err
+	}
+	if err := addFiles(driver, base, 3); err != nil {
+		return nil, err
+	}
+
+	return func(t *Timer) (int64, error) {
+		return readDiff(driver, base)
+	}, nil
+}
+
+// benchDiffN benchmarks calls to diff on two layers with a provided number
+// of files on the lower and upper layers.
+func benchDiffN(driver graphdriver.Driver, bottom, top int) (func(t *Timer) (int64, error), error) {
+	base := stringid.GenerateRandomID()
+	upper := stringid.GenerateRandomID()
+	if err := driver.Create(base, "", nil); err != nil {
+		return nil, err
+	}
+	if err := addManyFiles(driver, base, bottom, 3); err != nil {
+		return nil, err
+	}
+	if err := driver.Create(upper, base, nil); err != nil {
+		return nil, err
+	}
+	if err := addManyFiles(driver, upper, top, 6); err != nil {
+		return nil, err
+	}
+
+	return func(t *Timer) (int64, error) {
+		return readDiff(driver, upper)
+	}, nil
+}
+
+// benchDiffApplyN benchmarks calls to diff and apply together.
+func benchDiffApplyN(driver graphdriver.Driver, fileCount int) (func(t *Timer) (int64, error), error) {
+	base := stringid.GenerateRandomID()
+	upper := stringid.GenerateRandomID()
+	if err := driver.Create(base, "", nil); err != nil {
+		return nil, err
+	}
+	if err := addManyFiles(driver, base, fileCount, 3); err != nil {
+		return nil, err
+	}
+	if err := driver.Create(upper, base, nil); err != nil {
+		return nil, err
+	}
+	if err := addManyFiles(driver, upper, fileCount, 6); err != nil {
+		return nil, err
+	}
+
+	return func(t *Timer) (int64, error) {
+		t.Stop()
+		diff := stringid.GenerateRandomID()
+		if err := driver.Create(diff, base, nil); err != nil {
+			return 0, err
+		}
+		if err := checkManyFiles(driver, diff, fileCount, 3); err != nil {
+			return 0, err
+		}
+		t.Start()
+
+		arch, err := driver.Diff(upper, "")
+		if err != nil {
+			return 0, err
+		}
+		applyDiffSize, err := driver.ApplyDiff(diff, "", arch)
+		arch.Close()
+		if err != nil {
+			return 0, err
+		}
+
+		t.Stop()
+		defer t.Start()
+		if err := checkManyFiles(driver, diff, fileCount, 6); err != nil {
+			return 0, err
+		}
+		return applyDiffSize, nil
+	}, nil
+}
+
+// benchDeepLayerDiff benchmarks calls to diff on top of a given number of
+// layers.
+func benchDeepLayerDiff(driver graphdriver.Driver, layerCount int) (func(t *Timer) (int64, error), error) {
+	base := stringid.GenerateRandomID()
+	if err := driver.Create(base, "", nil); err != nil {
+		return nil, err
+	}
+	if err := addFiles(driver, base, 50); err != nil {
+		return nil, err
+	}
+	topLayer, err := addManyLayers(driver, base, layerCount)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(t *Timer) (int64, error) {
+		return readDiff(driver, topLayer)
+	}, nil
+}
+
+// benchDeepLayerRead benchmarks calls to read a file under a given number
+// of layers.
+func benchDeepLayerRead(driver graphdriver.Driver, layerCount int) (func(t *Timer) (int64, error), error) {
+	base := stringid.GenerateRandomID()
+	if err := driver.Create(base, "", nil); err != nil {
+		return nil, err
+	}
+	content := []byte("test content")
+	if err := addFile(driver, base, "testfile.txt", content); err != nil {
+		return nil, err
+	}
+	topLayer, err := addManyLayers(driver, base, layerCount)
+	if err != nil {
+		return nil, err
+	}
+
+	// The layer is mounted during the whole benchmark, and unmounted when
+	// the driver is cleaned up
+	root, err := driver.Get(topLayer, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return func(t *Timer) (int64, error) {
+		c, err := ioutil.ReadFile(filepath.Join(root, "testfile.txt"))
+		if err != nil {
+			return 0, err
+		}
+		if !bytes.Equal(c, content) {
+			return 0, fmt.Errorf("wrong content in file %v, expected %v", c, content)
+		}
+		return int64(len(c)), nil
+	}, nil
+}
+
+// readDiff reads the diff of a layer with its parent, and returns its size.
+func readDiff(driver graphdriver.Driver, layer string) (int64, error) {
+	arch, err := driver.Diff(layer, "")
+	if err != nil {
+		return 0, err
+	}
+	defer arch.Close()
+	n, err := io.Copy(ioutil.Discard, arch)
+	if err != nil {
+		return 0, fmt.Errorf("error copying archive: %v", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,295 @@
+// +build linux freebsd
+
+package storagecheck
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"syscall"
+
+	"github.com/docker/docker/daemon/graphdriver"
+	"github.com/docker/docker/pkg/stringid"
+)
+
+// checkCreateEmpty creates a new layer and verifies it is empty and has the
+// right metadata, then removes it.
+func checkCreateEmpty(driver graphdriver.Driver) error {
+	if err := driver.Create("empty", "", nil); err != nil {
+		return err
+	}
+
+	if !driver.Exists("empty") {
+		return errors.New("newly created layer doesn't exist")
+	}
+
+	dir, err := driver.Get("empty", "")
+	if err != nil {
+		return err
+	}
+	if err := verifyFile(dir, 0755|os.ModeDir, 0, 0); err != nil {
+		driver.Put("empty")
+		return err
+	}
+	fis, err := readDir(dir)
+	driver.Put("empty")
+	if err != nil {
+		return err
+	}
+	if len(fis) != 0 {
+		return errors.New("new layer not empty")
+	}
+
+	if err := driver.Remove("empty"); err != nil {
+		return err
+	}
+	if driver.Exists("empty") {
+		return errors.New("removed layer still exists")
+	}
+	return nil
+}
+
+// checkCreateBase creates a layer with files and verifies them.
+func checkCreateBase(driver graphdriver.Driver) error {
+	if err := createBase(driver, "Base"); err != nil {
+		return err
+	}
+	if err := verifyBase(driver, "Base"); err != nil {
+		return err
+	}
+	return driver.Remove("Base")
+}
+
+// checkCreateSnap creates a layer with files and a layer on top of it, and
+// verifies the files in the top layer.
+func checkCreateSnap(driver graphdriver.Driver) error {
+	if err := createBase(driver, "Base"); err != nil {
+		return err
+	}
+	if err := driver.Create("Snap", "Base", nil); err != nil {
+		return err
+	}
+	if err := verifyBase(driver, "Snap"); err != nil {
+		return err
+	}
+	if err := driver.Remove("Snap"); err != nil {
+		return err
+	}
+	return driver.Remove("Base")
+}
+
+// checkDeepLayerRead reads a file from a lower layer under a given number
+// of layers.
+func checkDeepLayerRead(driver graphdriver.Driver, layerCount int) error {
+	base := stringid.GenerateRandomID()
+	if err := driver.Create(base, "", nil); err != nil {
+		return err
+	}
+
+	content := []byte("test content")
+	if err := addFile(driver, base, "testfile.txt", content); err != nil {
+		return err
+	}
+
+	topLayer, err := addManyLayers(driver, base, layerCount)
+	if err != nil {
+		return err
+	}
+
+	if err := checkManyLayers(driver, topLayer, layerCount); err != nil {
+		return err
+	}
+	return checkFile(driver, topLayer, "testfile.txt", content)
+}
+
+// checkDiffApply checks that diffing a layer and applying the diff produces
+// the same layer.
+func checkDiffApply(driver graphdriver.Driver, fileCount int) error {
+	base := stringid.GenerateRandomID()
+	upper := stringid.GenerateRandomID()
+	deleteFile := "file-remove.txt"
+	deleteFileContent := []byte("This file should get removed in upper!")
+	deleteDir := "var/lib"
+
+	if err := driver.Create(base, "", nil); err != nil {
+		return err
+	}
+	if err := addManyFiles(driver, base, fileCount, 3); err != nil {
+		return err
+	}
+	if err := addFile(driver, base, deleteFile, deleteFileContent); err != nil {
+		return err
+	}
+	if err := addDirectory(driver, base, deleteDir); err != nil {
+		return err
+	}
+
+	if err := driver.Create(upper, base, nil); err != nil {
+		return err
+	}
+	if err := addManyFiles(driver, upper, fileCount, 6); err != nil {
+		return err
+	}
+	if err := removeAll(driver, upper, deleteFile, deleteDir); err != nil {
+		return err
+	}
+
+	diffSize, err := driver.DiffSize(upper, "")
+	if err != nil {
+		return err
+	}
+
+	diff := stringid.GenerateRandomID()
+	if err := driver.Create(diff, base, nil); err != nil {
+		return err
+	}
+	if err := checkManyFiles(driver, diff, fileCount, 3); err != nil {
+		return err
+	}
+	if err := checkFile(driver, diff, deleteFile, deleteFileContent); err != nil {
+		return err
+	}
+
+	arch, err := driver.Diff(upper, base)
+	if err != nil {
+		return err
+	}
+	buf := bytes.NewBuffer(nil)
+	if _, err := buf.ReadFrom(arch); err != nil {
+		arch.Close()
+		return err
+	}
+	if err := arch.Close(); err != nil {
+		return err
+	}
+
+	applyDiffSize, err := driver.ApplyDiff(diff, base, bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		return err
+	}
+	if applyDiffSize != diffSize {
+		return fmt.Errorf("apply diff size different, got %d, expected %d", applyDiffSize, diffSize)
+	}
+
+	if err := checkManyFiles(driver, diff, fileCount, 6); err != nil {
+		return err
+	}
+	if err := checkFileRemoved(driver, diff, deleteFile); err != nil {
+		return err
+	}
+	return checkFileRemoved(driver, diff, deleteDir)
+}
+
+// checkChanges checks that the changes computed on a layer match the
+// changes made.
+func checkChanges(driver graphdriver.Driver) error {
+	base := stringid.GenerateRandomID()
+	upper := stringid.GenerateRandomID()
+	if err := driver.Create(base, "", nil); err != nil {
+		return err
+	}
+	if err := addManyFiles(driver, base, 20, 3); err != nil {
+		return err
+	}
+
+	if err := driver.Create(upper, base, nil); err != nil {
+		return err
+	}
+	expectedChanges, err := changeManyFiles(driver, upper, 20, 6)
+	if err != nil {
+		return err
+	}
+
+	changes, err := driver.Changes(upper, base)
+	if err != nil {
+		return err
+	}
+	return compareChanges(expectedChanges, changes)
+}
+
+func verifyFile(path string, mode os.FileMode, uid, gid uint32) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	if fi.Mode()&os.ModeType != mode&os.ModeType {
+		return fmt.Errorf("expected %s type 0x%x, got 0x%x", path, mode&os.ModeType, fi.Mode()&os.ModeType)
+	}
+	if fi.Mode()&os.ModePerm != mode&os.ModePerm {
+		return fmt.Errorf("expected %s mode %o, got %o", path, mode&os.ModePerm, fi.Mode()&os.ModePerm)
+	}
+	if fi.Mode()&os.ModeSticky != mode&os.ModeSticky {
+		return fmt.Errorf("expected %s sticky 0x%x, got 0x%x", path, mode&os.ModeSticky, fi.Mode()&os.ModeSticky)
+	}
+	if fi.Mode()&os.ModeSetuid != mode&os.ModeSetuid {
+		return fmt.Errorf("expected %s setuid 0x%x, got 0x%x", path, mode&os.ModeSetuid, fi.Mode()&os.ModeSetuid)
+	}
+	if fi.Mode()&os.ModeSetgid != mode&os.ModeSetgid {
+		return fmt.Errorf("expected %s setgid 0x%x, got 0x%x", path, mode&os.ModeSetgid, fi.Mode()&os.ModeSetgid)
+	}
+
+	if stat, ok := fi.Sys().(*syscall.Stat_t); ok {
+		if stat.Uid != uid {
+			return fmt.Errorf("%s not owned by uid %d", path, uid)
+		}
+		if stat.Gid != gid {
+			return fmt.Errorf("%s not owned by gid %d", path, gid)
+		}
+	}
+	return nil
+}
+
+func createBase(driver graphdriver.Driver, name string) error {
+	// We need to be able to set any perms
+	oldmask := syscall.Umask(0)
+	defer syscall.Umask(oldmask)
+
+	if err := driver.CreateReadWrite(name, "", nil); err != nil {
+		return err
+	}
+
+	dir, err := driver.Get(name, "")
+	if err != nil {
+		return err
+	}
+	defer driver.Put(name)
+
+	subdir := path.Join(dir, "a subdir")
+	if err := os.Mkdir(subdir, 0705|os.ModeSticky); err != nil {
+		return err
+	}
+	if err := os.Chown(subdir, 1, 2); err != nil {
+		return err
+	}
+
+	file := path.Join(dir, "a file")
+	return ioutil.WriteFile(file, []byte("Some data"), 0222|os.ModeSetuid)
+}
+
+func verifyBase(driver graphdriver.Driver, name string) error {
+	dir, err := driver.Get(name, "")
+	if err != nil {
+		return err
+	}
+	defer driver.Put(name)
+
+	if err := verifyFile(path.Join(dir, "a subdir"), 0705|os.ModeDir|os.ModeSticky, 1, 2); err != nil {
+		return err
+	}
+	if err := verifyFile(path.Join(dir, "a file"), 0222|os.ModeSetuid, 0, 0); err != nil {
+		return err
+	}
+
+	fis, err := readDir(dir)
+	if err != nil {
+		return err
+	}
+	if len(fis) != 2 {
+		return errors.New("unexpected files in base layer")
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+// +build linux freebsd
+
+// Package storagecheck runs conformance checks and benchmarks against a
+// storage driver, on the filesystem it is used on. It is the counterpart of
+// the graphtest package outside of go test.
+package storagecheck
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/docker/docker/daemon/graphdriver"
+)
+
+// benchTime is the minimum time during which each benchmark runs, and
+// benchTimeout the time after which it stops even if the preparation of
+// its operations took most of the time.
+const (
+	benchTime    = time.Second
+	benchTimeout = 10 * time.Second
+)
+
+// Check is a conformance check of a storage driver.
+type Check struct {
+	Name string
+	Run  func(driver graphdriver.Driver) error
+}
+
+// Benchmark is a benchmark of a storage driver. Setup prepares the layers
+// of the benchmark, and returns the operation which is timed. The operation
+// returns the number of bytes it processed, if relevant.
+type Benchmark struct {
+	Name  string
+	Setup func(driver graphdriver.Driver) (func(t *Timer) (int64, error), error)
+}
+
+// Timer times the operations of a benchmark. An operation can stop it
+// while it prepares or checks something which must not be timed.
+type Timer struct {
+	start   time.Time
+	elapsed time.Duration
+}
+
+// Start starts the timer.
+func (t *Timer) Start() {
+	t.start = time.Now()
+}
+
+// Stop stops the timer.
+func (t *Timer) Stop() {
+	t.elapsed += time.Since(t.start)
+}
+
+// Checks are the conformance checks run by Run.
+var Checks = []Check{
+	{"CreateEmpty", checkCreateEmpty},
+	{"CreateBase", checkCreateBase},
+	{"CreateSnap", checkCreateSnap},
+	{"DeepLayerRead", func(driver graphdriver.Driver) error {
+		return checkDeepLayerRead(driver, 128)
+	}},
+	{"DiffApply", func(driver graphdriver.Driver) error {
+		return checkDiffApply(driver, 10)
+	}},
+	{"Changes", checkChanges},
+}
+
+// Benchmarks are the benchmarks run by Run.
+var Benchmarks = []Benchmark{
+	{"Create", benchCreate},
+	{"Exists", benchExists},
+	{"GetEmpty", benchGetEmpty},
+	{"DiffBase", benchDiffBase},
+	{"DiffSmallUpper", func(driver graphdriver.Driver) (func(t *Timer) (int64, error), error) {
+		return benchDiffN(driver, 10, 10)
+	}},
+	{"DiffHugeUpper", func(driver graphdriver.Driver) (func(t *Timer) (int64, error), error) {
+		return benchDiffN(driver, 10, 10000)
+	}},
+	{"DiffHugeLower", func(driver graphdriver.Driver) (func(t *Timer) (int64, error), error) {
+		return benchDiffN(driver, 10000, 10)
+	}},
+	{"DiffApply100", func(driver graphdriver.Driver) (func(t *Timer) (int64, error), error) {
+		return benchDiffApplyN(driver, 100)
+	}},
+	{"DeepLayerDiff20", func(driver graphdriver.Driver) (func(t *Timer) (int64, error), error) {
+		return benchDeepLayerDiff(driver, 20)
+	}},
+	{"DeepLayerRead20", func(driver graphdriver.Driver) (func(t *Timer) (int64, error), error) {
+		return benchDeepLayerRead(driver, 20)
+	}},
+}
+
+// Run runs the conformance checks, and the benchmarks if bench is true,
+// against the storage driver drivername. Each check and benchmark uses a
+// new driver, in a temporary directory in root. The results are written to
+// w. It returns an error if a check or a benchmark failed.
+func Run(w io.Writer, root, drivername string, driverOptions []string, bench bool) error {
+	if err := os.MkdirAll(root, 0700); err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 20, 1, 3, ' ', 0)
+	fmt.Fprintln(tw, "TEST\tRESULT\tTIME")
+	var failed int
+	for _, check := range Checks {
+		start := time.Now()
+		err := withDriver(root, drivername, driverOptions, check.Run)
+		if isNotSupported(err) {
+			tw.Flush()
+			return fmt.Errorf("storage driver %s is not supported in %s: %v", drivername, root, err)
+		}
+
+		result := "PASS"
+		if err != nil {
+			result = "FAIL"
+			failed++
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", check.Name, result, time.Since(start)/time.Millisecond*time.Millisecond)
+		if err != nil {
+			fmt.Fprintf(tw, "    %v\n", err)
+		}
+	}
+	tw.Flush()
+
+	if failed > 0 {
+		return fmt.Errorf("%d conformance tests of storage driver %s failed", failed, drivername)
+	}
+	if !bench {
+		return nil
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(tw, "BENCHMARK\tOPERATIONS\tTIME/OP\tTHROUGHPUT")
+	for _, benchmark := range Benchmarks {
+		var (
+			n     int
+			t     Timer
+			bytes int64
+		)
+		err := withDriver(root, drivername, driverOptions, func(driver graphdriver.Driver) error {
+			op, err := benchmark.Setup(driver)
+			if err != nil {
+				return err
+			}
+			start := time.Now()
+			for t.elapsed < benchTime && (n == 0 || time.Since(start) < benchTimeout) {
+				t.Start()
+				b, err := op(&t)
+				t.Stop()
+				if err != nil {
+					return err
+				}
+				bytes += b
+				n++
+			}
+			return nil
+		})
+		if err != nil {
+			fmt.Fprintf(tw, "%s\tFAIL\t\t\n", benchmark.Name)
+			fmt.Fprintf(tw, "    %v\n", err)
+			failed++
+			continue
+		}
+		throughput := ""
+		if bytes > 0 {
+			throughput = fmt.Sprintf("%.2f MB/s", float64(bytes)/1e6/t.elapsed.Seconds())
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\n", benchmark.Name, n, t.elapsed/time.Duration(n), throughput)
+	}
+	tw.Flush()
+
+	if failed > 0 {
+		return fmt.Errorf("%d benchmarks of storage driver %s failed", failed, drivername)
+	}
+	return nil
+}
+
+// withDriver calls f with a new driver in a temporary directory of root,
+// and removes the driver when f returns.
+func withDriver(root, drivername string, driverOptions []string, f func(driver graphdriver.Driver) error) (err error) {
+	dir, err := ioutil.TempDir(root, "storage-check-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	driver, err := graphdriver.GetDriver(drivername, nil, graphdriver.Options{DriverOptions: driverOptions, Root: dir})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cleanupErr := driver.Cleanup(); err == nil {
+			err = cleanupErr
+		}
+	}()
+
+	return f(driver)
+}
+
+func isNotSupported(err error) bool {
+	return err == graphdriver.ErrNotSupported || err == graphdriver.ErrPrerequisites || err == graphdriver.ErrIncompatibleFS
+}
@@ -0,0 +1,85 @@
+// +build linux freebsd
+
+package storagecheck
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/daemon/graphdriver"
+	_ "github.com/docker/docker/daemon/graphdriver/vfs"
+)
+
+func TestRunVfs(t *testing.T) {
+	root, err := ioutil.TempDir("", "storage-check-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	var out bytes.Buffer
+	if err := Run(&out, root, "vfs", nil, false); err != nil {
+		t.Fatalf("%v\n%s", err, out.String())
+	}
+	for _, check := range Checks {
+		if !strings.Contains(out.String(), check.Name+" ") {
+			t.Fatalf("expected the result of %s, got:\n%s", check.Name, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "FAIL") {
+		t.Fatalf("unexpected failure:\n%s", out.String())
+	}
+
+	// The drivers are removed
+	fis, err := ioutil.ReadDir(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fis) != 0 {
+		t.Fatalf("expected the drivers to be removed, got %d files", len(fis))
+	}
+}
+
+func TestRunBenchmark(t *testing.T) {
+	root, err := ioutil.TempDir("", "storage-check-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	err = withDriver(root, "vfs", nil, func(driver graphdriver.Driver) error {
+		op, err := benchDiffBase(driver)
+		if err != nil {
+			return err
+		}
+		var timer Timer
+		timer.Start()
+		n, err := op(&timer)
+		timer.Stop()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			t.Fatal("expected the size of the diff")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRunUnknownDriver(t *testing.T) {
+	root, err := ioutil.TempDir("", "storage-check-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	if err := Run(ioutil.Discard, root, "unknown", nil, false); err == nil {
+		t.Fatal("expected an error for an unknown driver")
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 
 	"github.com/docker/docker/daemon/graphdriver"
+	"github.com/docker/docker/daemon/graphdriver/copy"
 	"github.com/docker/docker/pkg/chrootarchive"
 	"github.com/docker/docker/pkg/idtools"
 
@@ -36,26 +38,32 @@ func Init(home string, options []string, uidMaps, gidMaps []idtools.IDMap) (grap
 	if err := idtools.MkdirAllAs(home, 0700, rootUID, rootGID); err != nil {
 		return nil, err
 	}
+	d.reflink = copy.SupportsReflink(home)
 	return graphdriver.NewNaiveDiffDriver(d, uidMaps, gidMaps), nil
 }
 
 // Driver holds information about the driver, home directory of the driver.
 // Driver implements graphdriver.ProtoDriver. It uses only basic vfs operations.
-// In order to support layering, files are copied from the parent layer into the new layer. There is no copy-on-write support.
+// In order to support layering, files are copied from the parent layer into the new layer.
+// If the backing filesystem supports it, the files are cloned copy-on-write instead of copied.
 // Driver must be wrapped in NaiveDiffDriver to be used as a graphdriver.Driver
 type Driver struct {
 	home    string
 	uidMaps []idtools.IDMap
 	gidMaps []idtools.IDMap
+	reflink bool
 }
 
 func (d *Driver) String() string {
 	return "vfs"
 }
 
-// Status is used for implementing the graphdriver.ProtoDriver interface. VFS does not currently have any status information.
+// Status is used for implementing the graphdriver.ProtoDriver interface. It
+// reports whether the files are cloned copy-on-write.
 func (d *Driver) Status() [][2]string {
-	return nil
+	return [][2]string{
+		{"Supports reflink", strconv.FormatBool(d.reflink)},
+	}
 }
 
 // GetMetadata is used for implementing the graphdriver.ProtoDriver interface. VFS does not currently have any meta data.
@@ -102,16 +110,21 @@ func (d *Driver) Create(id, parent string, opts *graphdriver.CreateOpts) error {
 	if err != nil {
 		return fmt.Errorf("%s: %s", parent, err)
 	}
-	if err := CopyWithTar(parentDir, dir); err != nil {
-		return err
+	if d.reflink {
+		return copy.DirCopy(parentDir, dir, copy.Content)
 	}
-	return nil
+	return CopyWithTar(parentDir, dir)
 }
 
 func (d *Driver) dir(id string) string {
 	return filepath.Join(d.home, "dir", filepath.Base(id))
 }
 
+// ListLayers returns the IDs of the layers stored by the driver.
+func (d *Driver) ListLayers() ([]string, error) {
+	return graphdriver.ListDirs(filepath.Join(d.home, "dir"))
+}
+
 // Remove deletes the content from the directory for a given id.
 func (d *Driver) Remove(id string) error {
 	if err := os.RemoveAll(d.dir(id)); err != nil && !os.IsNotExist(err) {
@@ -0,0 +1,209 @@
+package daemon
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/ioutils"
+	"github.com/docker/docker/reference"
+	"github.com/opencontainers/go-digest"
+)
+
+// migrateGraphDriver migrates the layers, the images and the containers of
+// the storage driver config.MigrateGraphDriver to the storage driver of the
+// daemon. It must run before the containers are restored. The layers keep
+// their ChainID, so the images keep their ID. The data of the previous storage
+// driver is not removed, and the migration can be run again if it was
+// interrupted: the layers, images and containers that were already migrated
+// are skipped.
+func (daemon *Daemon) migrateGraphDriver(config *Config) error {
+	driverName := daemon.GraphDriverName()
+	if config.MigrateGraphDriver == driverName {
+		return fmt.Errorf("cannot migrate from storage driver %s to itself", driverName)
+	}
+
+	containers, err := daemon.containersToMigrate(config.MigrateGraphDriver)
+	if err != nil {
+		return err
+	}
+
+	srcLayerStore, err := layer.NewStoreFromOptions(layer.StoreOptions{
+		StorePath:                 config.Root,
+		MetadataStorePathTemplate: filepath.Join(config.Root, "image", "%s", "layerdb"),
+		GraphDriver:               config.MigrateGraphDriver,
+		GraphDriverOptions:        config.MigrateGraphOptions,
+		UIDMaps:                   daemon.uidMaps,
+		GIDMaps:                   daemon.gidMaps,
+		PluginGetter:              daemon.PluginStore,
+		ExperimentalEnabled:       config.Experimental,
+	})
+	if err != nil {
+		return err
+	}
+	defer srcLayerStore.Cleanup()
+
+	srcImageRoot := filepath.Join(config.Root, "image", srcLayerStore.DriverName())
+	imageRoot := filepath.Join(config.Root, "image", driverName)
+	logrus.Infof("Migrating from storage driver %s to %s", srcLayerStore.DriverName(), driverName)
+
+	ifs, err := image.NewFSStoreBackend(filepath.Join(srcImageRoot, "imagedb"))
+	if err != nil {
+		return err
+	}
+	srcImageStore, err := image.NewImageStore(ifs, srcLayerStore)
+	if err != nil {
+		return err
+	}
+	srcReferenceStore, err := reference.NewReferenceStore(filepath.Join(srcImageRoot, "repositories.json"))
+	if err != nil {
+		return err
+	}
+
+	// The migrated layers are referenced until the images are created
+	migratedLayers, err := layer.MigrateLayers(srcLayerStore, daemon.layerStore)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		for _, l := range migratedLayers {
+			daemon.layerStore.Release(l)
+		}
+	}()
+
+	if err := migrateImages(srcImageStore, daemon.imageStore, srcReferenceStore, daemon.referenceStore); err != nil {
+		return err
+	}
+
+	if err := copyMissingFiles(filepath.Join(srcImageRoot, "distribution"), filepath.Join(imageRoot, "distribution")); err != nil {
+		return fmt.Errorf("error migrating the distribution metadata: %v", err)
+	}
+
+	for _, c := range containers {
+		logrus.Infof("Migrating container %s", c.ID)
+		rwLayerOpts := &layer.CreateRWLayerOpts{
+			MountLabel: c.MountLabel,
+			InitFunc:   daemon.getLayerInit(),
+			StorageOpt: c.HostConfig.StorageOpt,
+		}
+		if err := layer.MigrateRWLayer(srcLayerStore, daemon.layerStore, c.ID, rwLayerOpts); err != nil {
+			return fmt.Errorf("error migrating container %s: %v", c.ID, err)
+		}
+		c.Driver = driverName
+		if err := c.ToDisk(); err != nil {
+			return err
+		}
+	}
+
+	logrus.Infof("Migration from storage driver %s to %s done, the data of %s can be removed", srcLayerStore.DriverName(), driverName, srcLayerStore.DriverName())
+	return nil
+}
+
+// containersToMigrate returns the containers created with the storage driver
+// driverName. It fails if one of them is running, as its RW layer can't be
+// migrated while it is in use.
+func (daemon *Daemon) containersToMigrate(driverName string) ([]*container.Container, error) {
+	dir, err := ioutil.ReadDir(daemon.repository)
+	if err != nil {
+		return nil, err
+	}
+
+	var containers []*container.Container
+	for _, v := range dir {
+		c, err := daemon.load(v.Name())
+		if err != nil {
+			logrus.Errorf("Failed to load container %v: %v", v.Name(), err)
+			continue
+		}
+		if c.Driver != driverName && (c.Driver != "" || driverName != "aufs") {
+			continue
+		}
+		if c.IsRunning() {
+			return nil, fmt.Errorf("container %s is running, stop it to migrate it", c.ID)
+		}
+		containers = append(containers, c)
+	}
+	return containers, nil
+}
+
+// migrateImages creates the images of the store src in the store dst, with
+// their parent and their references. The references that already exist in
+// dst are kept.
+func migrateImages(src, dst image.Store, srcRefs, dstRefs reference.Store) error {
+	images := src.Map()
+	for id, img := range images {
+		if _, err := dst.Get(id); err == nil {
+			continue
+		}
+		newID, err := dst.Create(img.RawJSON())
+		if err != nil {
+			return fmt.Errorf("error migrating image %s: %v", id, err)
+		}
+		if newID != id {
+			return fmt.Errorf("image %s was migrated as %s", id, newID)
+		}
+	}
+
+	for id := range images {
+		if parent, err := src.GetParent(id); err == nil {
+			if err := dst.SetParent(id, parent); err != nil {
+				return fmt.Errorf("error migrating the parent of image %s: %v", id, err)
+			}
+		}
+
+		for _, ref := range srcRefs.References(digest.Digest(id)) {
+			if _, err := dstRefs.Get(ref); err == nil {
+				logrus.Warnf("Reference %s already exists, it is not migrated", ref.String())
+				continue
+			}
+			var err error
+			if canonical, ok := ref.(reference.Canonical); ok {
+				err = dstRefs.AddDigest(canonical, digest.Digest(id), false)
+			} else {
+				err = dstRefs.AddTag(ref, digest.Digest(id), false)
+			}
+			if err != nil {
+				return fmt.Errorf("error migrating reference %s: %v", ref.String(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// copyMissingFiles copies the regular files of the directory src which don't
+// exist in the directory dst.
+func copyMissingFiles(src, dst string) error {
+	return filepath.Walk(src, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == src {
+				return nil
+			}
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		if fi.IsDir() {
+			return os.MkdirAll(target, fi.Mode().Perm())
+		}
+		if !fi.Mode().IsRegular() {
+			return nil
+		}
+		if _, err := os.Lstat(target); err == nil {
+			return nil
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return ioutils.AtomicWriteFile(target, data, fi.Mode().Perm())
+	})
+}
@@ -5,10 +5,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/api/errors"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
 	"github.com/docker/docker/pkg/stringid"
 	"github.com/docker/docker/reference"
 )
@@ -58,19 +60,35 @@ const (
 // meaning any delete conflicts will cause the image to not be deleted and the
 // conflict will not be reported.
 //
+// If dryRun is true, nothing is deleted, and the returned records are the
+// references which would be untagged and the images and layers which would be
+// deleted.
+//
 // FIXME: remove ImageDelete's dependency on Daemon, then move to the graph
 // package. This would require that we no longer need the daemon to determine
 // whether images are being used by a stopped or running container.
-func (daemon *Daemon) ImageDelete(imageRef string, force, prune bool) ([]types.ImageDeleteResponseItem, error) {
+func (daemon *Daemon) ImageDelete(imageRef string, force, prune, dryRun bool) ([]types.ImageDeleteResponseItem, error) {
+	if dryRun {
+		return daemon.imageDelete(imageRef, force, prune, daemon.newImageDeleteDryRun())
+	}
+	return daemon.imageDelete(imageRef, force, prune, nil)
+}
+
+// imageDelete deletes the image referenced by imageRef as ImageDelete. If
+// dryRun is not nil, the deletion is only simulated and recorded in dryRun.
+func (daemon *Daemon) imageDelete(imageRef string, force, prune bool, dryRun *imageDeleteDryRun) ([]types.ImageDeleteResponseItem, error) {
 	start := time.Now()
 	records := []types.ImageDeleteResponseItem{}
 
 	imgID, err := daemon.GetImageID(imageRef)
+	if err == nil && dryRun != nil && dryRun.deletedImages[imgID] {
+		err = ErrImageDoesNotExist{imageRef}
+	}
 	if err != nil {
 		return nil, daemon.imageNotExistToErrcode(err)
 	}
 
-	repoRefs := daemon.referenceStore.References(imgID.Digest())
+	repoRefs := daemon.imageReferences(imgID, dryRun)
 
 	var removedRepositoryRef bool
 	if !isImageIDPrefix(imgID.String(), imageRef) {
@@ -94,17 +112,19 @@ func (daemon *Daemon) ImageDelete(imageRef string, force, prune bool) ([]types.I
 			return nil, err
 		}
 
-		parsedRef, err = daemon.removeImageRef(parsedRef)
+		parsedRef, err = daemon.removeImageRef(parsedRef, dryRun)
 		if err != nil {
 			return nil, err
 		}
 
 		untaggedRecord := types.ImageDeleteResponseItem{Untagged: parsedRef.String()}
 
-		daemon.LogImageEvent(imgID.String(), imgID.String(), "untag")
+		if dryRun == nil {
+			daemon.LogImageEvent(imgID.String(), imgID.String(), "untag")
+		}
 		records = append(records, untaggedRecord)
 
-		repoRefs = daemon.referenceStore.References(imgID.Digest())
+		repoRefs = daemon.imageReferences(imgID, dryRun)
 
 		// If a tag reference was removed and the only remaining
 		// references to the same repository are digest references,
@@ -122,7 +142,7 @@ func (daemon *Daemon) ImageDelete(imageRef string, force, prune bool) ([]types.I
 				remainingRefs := []reference.Named{}
 				for _, repoRef := range repoRefs {
 					if _, repoRefIsCanonical := repoRef.(reference.Canonical); repoRefIsCanonical && parsedRef.Name() == repoRef.Name() {
-						if _, err := daemon.removeImageRef(repoRef); err != nil {
+						if _, err := daemon.removeImageRef(repoRef, dryRun); err != nil {
 							return records, err
 						}
 
@@ -152,29 +172,33 @@ func (daemon *Daemon) ImageDelete(imageRef string, force, prune bool) ([]types.I
 			if !force {
 				c |= conflictSoft &^ conflictActiveReference
 			}
-			if conflict := daemon.checkImageDeleteConflict(imgID, c); conflict != nil {
+			if conflict := daemon.checkImageDeleteConflict(imgID, c, dryRun); conflict != nil {
 				return nil, conflict
 			}
 
 			for _, repoRef := range repoRefs {
-				parsedRef, err := daemon.removeImageRef(repoRef)
+				parsedRef, err := daemon.removeImageRef(repoRef, dryRun)
 				if err != nil {
 					return nil, err
 				}
 
 				untaggedRecord := types.ImageDeleteResponseItem{Untagged: parsedRef.String()}
 
-				daemon.LogImageEvent(imgID.String(), imgID.String(), "untag")
+				if dryRun == nil {
+					daemon.LogImageEvent(imgID.String(), imgID.String(), "untag")
+				}
 				records = append(records, untaggedRecord)
 			}
 		}
 	}
 
-	if err := daemon.imageDeleteHelper(imgID, &records, force, prune, removedRepositoryRef); err != nil {
+	if err := daemon.imageDeleteHelper(imgID, &records, force, prune, removedRepositoryRef, dryRun); err != nil {
 		return nil, err
 	}
 
-	imageActions.WithValues("delete").UpdateSince(start)
+	if dryRun == nil {
+		imageActions.WithValues("delete").UpdateSince(start)
+	}
 
 	return records, nil
 }
@@ -222,7 +246,7 @@ func isImageIDPrefix(imageID, possiblePrefix string) bool {
 // imageID. Returns nil if there is no such container.
 func (daemon *Daemon) getContainerUsingImage(imageID image.ID) *container.Container {
 	return daemon.containers.First(func(c *container.Container) bool {
-		return c.ImageID == imageID
+		return containerUsesImage(c, imageID)
 	})
 }
 
@@ -231,8 +255,11 @@ func (daemon *Daemon) getContainerUsingImage(imageID image.ID) *container.Contai
 // repositoryRef must not be an image ID but a repository name followed by an
 // optional tag or digest reference. If tag or digest is omitted, the default
 // tag is used. Returns the resolved image reference and an error.
-func (daemon *Daemon) removeImageRef(ref reference.Named) (reference.Named, error) {
+func (daemon *Daemon) removeImageRef(ref reference.Named, dryRun *imageDeleteDryRun) (reference.Named, error) {
 	ref = reference.WithDefaultTag(ref)
+	if dryRun != nil {
+		return ref, dryRun.removeReference(ref)
+	}
 	// Ignore the boolean value returned, as far as we're concerned, this
 	// is an idempotent operation and it's okay if the reference didn't
 	// exist in the first place.
@@ -246,18 +273,20 @@ func (daemon *Daemon) removeImageRef(ref reference.Named) (reference.Named, erro
 // on the first encountered error. Removed references are logged to this
 // daemon's event service. An "Untagged" types.ImageDeleteResponseItem is added to the
 // given list of records.
-func (daemon *Daemon) removeAllReferencesToImageID(imgID image.ID, records *[]types.ImageDeleteResponseItem) error {
-	imageRefs := daemon.referenceStore.References(imgID.Digest())
+func (daemon *Daemon) removeAllReferencesToImageID(imgID image.ID, records *[]types.ImageDeleteResponseItem, dryRun *imageDeleteDryRun) error {
+	imageRefs := daemon.imageReferences(imgID, dryRun)
 
 	for _, imageRef := range imageRefs {
-		parsedRef, err := daemon.removeImageRef(imageRef)
+		parsedRef, err := daemon.removeImageRef(imageRef, dryRun)
 		if err != nil {
 			return err
 		}
 
 		untaggedRecord := types.ImageDeleteResponseItem{Untagged: parsedRef.String()}
 
-		daemon.LogImageEvent(imgID.String(), imgID.String(), "untag")
+		if dryRun == nil {
+			daemon.LogImageEvent(imgID.String(), imgID.String(), "untag")
+		}
 		*records = append(*records, untaggedRecord)
 	}
 
@@ -294,16 +323,17 @@ func (idc *imageDeleteConflict) Error() string {
 // and untagged references are appended to the given records. If any error or
 // conflict is encountered, it will be returned immediately without deleting
 // the image. If quiet is true, any encountered conflicts will be ignored and
-// the function will return nil immediately without deleting the image.
-func (daemon *Daemon) imageDeleteHelper(imgID image.ID, records *[]types.ImageDeleteResponseItem, force, prune, quiet bool) error {
+// the function will return nil immediately without deleting the image. If
+// dryRun is not nil, the deletion is only simulated.
+func (daemon *Daemon) imageDeleteHelper(imgID image.ID, records *[]types.ImageDeleteResponseItem, force, prune, quiet bool, dryRun *imageDeleteDryRun) error {
 	// First, determine if this image has any conflicts. Ignore soft conflicts
 	// if force is true.
 	c := conflictHard
 	if !force {
 		c |= conflictSoft
 	}
-	if conflict := daemon.checkImageDeleteConflict(imgID, c); conflict != nil {
-		if quiet && (!daemon.imageIsDangling(imgID) || conflict.used) {
+	if conflict := daemon.checkImageDeleteConflict(imgID, c, dryRun); conflict != nil {
+		if quiet && (!daemon.imageIsDangling(imgID, dryRun) || conflict.used) {
 			// Ignore conflicts UNLESS the image is "dangling" or not being used in
 			// which case we want the user to know.
 			return nil
@@ -321,19 +351,37 @@ func (daemon *Daemon) imageDeleteHelper(imgID image.ID, records *[]types.ImageDe
 	}
 
 	// Delete all repository tag/digest references to this image.
-	if err := daemon.removeAllReferencesToImageID(imgID, records); err != nil {
+	if err := daemon.removeAllReferencesToImageID(imgID, records, dryRun); err != nil {
 		return err
 	}
 
-	removedLayers, err := daemon.imageStore.Delete(imgID)
-	if err != nil {
-		return err
+	var removedLayers []layer.Metadata
+	if dryRun != nil {
+		removedLayers, err = dryRun.deleteImage(daemon.imageStore, imgID)
+		if err != nil {
+			return err
+		}
+	} else {
+		removedLayers, err = daemon.imageStore.Delete(imgID)
+		if err != nil {
+			return err
+		}
+		if daemon.blobCache != nil {
+			for _, removedLayer := range removedLayers {
+				if err := daemon.blobCache.Remove(removedLayer.DiffID); err != nil {
+					logrus.Warnf("Failed to remove layer %s from the blob cache: %v", removedLayer.DiffID, err)
+				}
+			}
+		}
+		daemon.LogImageEvent(imgID.String(), imgID.String(), "delete")
 	}
 
-	daemon.LogImageEvent(imgID.String(), imgID.String(), "delete")
 	*records = append(*records, types.ImageDeleteResponseItem{Deleted: imgID.String()})
 	for _, removedLayer := range removedLayers {
-		*records = append(*records, types.ImageDeleteResponseItem{Deleted: removedLayer.ChainID.String()})
+		*records = append(*records, types.ImageDeleteResponseItem{
+			Deleted: removedLayer.ChainID.String(),
+			Size:    removedLayer.DiffSize,
+		})
 	}
 
 	if !prune || parent == "" {
@@ -345,7 +393,7 @@ func (daemon *Daemon) imageDeleteHelper(imgID image.ID, records *[]types.ImageDe
 	// either running or stopped).
 	// Do not force prunings, but do so quietly (stopping on any encountered
 	// conflicts).
-	return daemon.imageDeleteHelper(parent, records, false, true, true)
+	return daemon.imageDeleteHelper(parent, records, false, true, true, dryRun)
 }
 
 // checkImageDeleteConflict determines whether there are any conflicts
@@ -354,9 +402,9 @@ func (daemon *Daemon) imageDeleteHelper(imgID image.ID, records *[]types.ImageDe
 // using the image. A soft conflict is any tags/digest referencing the given
 // image or any stopped container using the image. If ignoreSoftConflicts is
 // true, this function will not check for soft conflict conditions.
-func (daemon *Daemon) checkImageDeleteConflict(imgID image.ID, mask conflictType) *imageDeleteConflict {
+func (daemon *Daemon) checkImageDeleteConflict(imgID image.ID, mask conflictType, dryRun *imageDeleteDryRun) *imageDeleteConflict {
 	// Check if the image has any descendant images.
-	if mask&conflictDependentChild != 0 && len(daemon.imageStore.Children(imgID)) > 0 {
+	if mask&conflictDependentChild != 0 && len(daemon.imageChildren(imgID, dryRun)) > 0 {
 		return &imageDeleteConflict{
 			hard:    true,
 			imgID:   imgID,
@@ -367,7 +415,7 @@ func (daemon *Daemon) checkImageDeleteConflict(imgID image.ID, mask conflictType
 	if mask&conflictRunningContainer != 0 {
 		// Check if any running container is using the image.
 		running := func(c *container.Container) bool {
-			return c.IsRunning() && c.ImageID == imgID
+			return c.IsRunning() && containerUsesImage(c, imgID)
 		}
 		if container := daemon.containers.First(running); container != nil {
 			return &imageDeleteConflict{
@@ -380,7 +428,7 @@ func (daemon *Daemon) checkImageDeleteConflict(imgID image.ID, mask conflictType
 	}
 
 	// Check if any repository tags/digest reference this image.
-	if mask&conflictActiveReference != 0 && len(daemon.referenceStore.References(imgID.Digest())) > 0 {
+	if mask&conflictActiveReference != 0 && len(daemon.imageReferences(imgID, dryRun)) > 0 {
 		return &imageDeleteConflict{
 			imgID:   imgID,
 			message: "image is referenced in multiple repositories",
@@ -390,7 +438,7 @@ func (daemon *Daemon) checkImageDeleteConflict(imgID image.ID, mask conflictType
 	if mask&conflictStoppedContainer != 0 {
 		// Check if any stopped containers reference this image.
 		stopped := func(c *container.Container) bool {
-			return !c.IsRunning() && c.ImageID == imgID
+			return !c.IsRunning() && containerUsesImage(c, imgID)
 		}
 		if container := daemon.containers.First(stopped); container != nil {
 			return &imageDeleteConflict{
@@ -407,6 +455,114 @@ func (daemon *Daemon) checkImageDeleteConflict(imgID image.ID, mask conflictType
 // imageIsDangling returns whether the given image is "dangling" which means
 // that there are no repository references to the given image and it has no
 // child images.
-func (daemon *Daemon) imageIsDangling(imgID image.ID) bool {
-	return !(len(daemon.referenceStore.References(imgID.Digest())) > 0 || len(daemon.imageStore.Children(imgID)) > 0)
+func (daemon *Daemon) imageIsDangling(imgID image.ID, dryRun *imageDeleteDryRun) bool {
+	return !(len(daemon.imageReferences(imgID, dryRun)) > 0 || len(daemon.imageChildren(imgID, dryRun)) > 0)
+}
+
+// imageReferences returns the repository tag/digest references to the given
+// image, without the references removed by the dry run if dryRun is not nil.
+func (daemon *Daemon) imageReferences(imgID image.ID, dryRun *imageDeleteDryRun) []reference.Named {
+	refs := daemon.referenceStore.References(imgID.Digest())
+	if dryRun == nil {
+		return refs
+	}
+	var remaining []reference.Named
+	for _, ref := range refs {
+		if !dryRun.removedRefs[ref.String()] {
+			remaining = append(remaining, ref)
+		}
+	}
+	return remaining
+}
+
+// imageChildren returns the child images of the given image, without the
+// images deleted by the dry run if dryRun is not nil.
+func (daemon *Daemon) imageChildren(imgID image.ID, dryRun *imageDeleteDryRun) []image.ID {
+	children := daemon.imageStore.Children(imgID)
+	if dryRun == nil {
+		return children
+	}
+	var remaining []image.ID
+	for _, child := range children {
+		if !dryRun.deletedImages[child] {
+			remaining = append(remaining, child)
+		}
+	}
+	return remaining
+}
+
+// imageDeleteDryRun records the references and the images which would be
+// removed by a deletion, without removing them from the stores. The
+// following steps of the deletion see the stores as if they were removed.
+type imageDeleteDryRun struct {
+	removedRefs   map[string]bool
+	deletedImages map[image.ID]bool
+	layerRefs     map[layer.ChainID]int
+	layers        map[layer.ChainID]layer.Layer
+}
+
+func (daemon *Daemon) newImageDeleteDryRun() *imageDeleteDryRun {
+	return &imageDeleteDryRun{
+		removedRefs:   make(map[string]bool),
+		deletedImages: make(map[image.ID]bool),
+		layerRefs:     daemon.getLayerRefs(),
+		layers:        daemon.layerStore.Map(),
+	}
+}
+
+// removeReference records the removal of ref. As the reference store, it
+// fails if the reference was already removed.
+func (d *imageDeleteDryRun) removeReference(ref reference.Named) error {
+	if d.removedRefs[ref.String()] {
+		return reference.ErrDoesNotExist
+	}
+	d.removedRefs[ref.String()] = true
+	return nil
+}
+
+// deleteImage records the deletion of the image, and returns the layers
+// which would be released because they are not referenced anymore by an
+// image or a container, starting with the top layer.
+func (d *imageDeleteDryRun) deleteImage(is image.Store, imgID image.ID) ([]layer.Metadata, error) {
+	img, err := is.Get(imgID)
+	if err != nil {
+		return nil, err
+	}
+	d.deletedImages[imgID] = true
+
+	var chainIDs []layer.ChainID
+	rootFS := *img.RootFS
+	rootFS.DiffIDs = nil
+	for _, diffID := range img.RootFS.DiffIDs {
+		rootFS.Append(diffID)
+		chainIDs = append(chainIDs, rootFS.ChainID())
+	}
+
+	var removed []layer.Metadata
+	for i := len(chainIDs) - 1; i >= 0; i-- {
+		chid := chainIDs[i]
+		d.layerRefs[chid]--
+		if d.layerRefs[chid] > 0 {
+			continue
+		}
+		l, ok := d.layers[chid]
+		if !ok {
+			continue
+		}
+		size, err := l.Size()
+		if err != nil {
+			return nil, err
+		}
+		diffSize, err := l.DiffSize()
+		if err != nil {
+			return nil, err
+		}
+		removed = append(removed, layer.Metadata{
+			ChainID:  chid,
+			DiffID:   l.DiffID(),
+			Size:     size,
+			DiffSize: diffSize,
+		})
+	}
+	return removed, nil
 }
@@ -4,6 +4,7 @@ import (
 	"io"
 
 	"github.com/docker/docker/image/tarexport"
+	"github.com/docker/libtrust"
 )
 
 // ExportImage exports a list of images to the given output stream. The
@@ -18,8 +19,9 @@ func (daemon *Daemon) ExportImage(names []string, outStream io.Writer) error {
 
 // LoadImage uploads a set of images into the repository. This is the
 // complement of ImageExport.  The input stream is an uncompressed tar
-// ball containing images and metadata.
-func (daemon *Daemon) LoadImage(inTar io.ReadCloser, outStream io.Writer, quiet bool) error {
+// ball containing images and metadata. If verifyKey is not nil, the
+// tar ball must be signed by it.
+func (daemon *Daemon) LoadImage(inTar io.ReadCloser, outStream io.Writer, quiet bool, verifyKey libtrust.PublicKey) error {
 	imageExporter := tarexport.NewTarExporter(daemon.imageStore, daemon.layerStore, daemon.referenceStore, daemon)
-	return imageExporter.Load(inTar, outStream, quiet)
+	return imageExporter.Load(inTar, outStream, quiet, verifyKey)
 }
@@ -0,0 +1,158 @@
+package daemon
+
+import (
+	"path/filepath"
+	"sync"
+
+	mounttypes "github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/symlink"
+	"github.com/docker/docker/volume"
+)
+
+// imageMount mounts the filesystem of an image for a mount point of type
+// image. Each mount creates a reference on a RW layer on top of the layers of
+// the image, named after the ID of the mount point, which is mounted
+// read-only in the container. The RW layer is removed when its last
+// reference is released.
+type imageMount struct {
+	layerStore layer.Store
+	chainID    layer.ChainID
+	mountLabel string
+	subpath    string
+
+	mu   sync.Mutex
+	refs []layer.RWLayer
+}
+
+// Mount implements volume.ImageMounter.
+func (m *imageMount) Mount(id string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rwLayer, err := m.layerStore.GetRWLayer(id)
+	if err == layer.ErrMountDoesNotExist {
+		rwLayer, err = m.layerStore.CreateRWLayer(id, m.chainID, nil)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	root, err := rwLayer.Mount(m.mountLabel)
+	if err != nil {
+		m.layerStore.ReleaseRWLayer(rwLayer)
+		return "", err
+	}
+
+	path := root
+	if m.subpath != "" {
+		// The subpath is resolved in the scope of the filesystem of the image
+		path, err = symlink.FollowSymlinkInScope(filepath.Join(root, m.subpath), root)
+		if err != nil {
+			rwLayer.Unmount()
+			m.layerStore.ReleaseRWLayer(rwLayer)
+			return "", err
+		}
+	}
+
+	m.refs = append(m.refs, rwLayer)
+	return path, nil
+}
+
+// Unmount implements volume.ImageMounter.
+func (m *imageMount) Unmount(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var rwLayer layer.RWLayer
+	if n := len(m.refs); n > 0 {
+		rwLayer = m.refs[n-1]
+		m.refs = m.refs[:n-1]
+	} else {
+		// The image was mounted before the daemon restarted
+		var err error
+		rwLayer, err = m.layerStore.GetRWLayer(id)
+		if err == layer.ErrMountDoesNotExist {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := rwLayer.Unmount(); err != nil {
+		return err
+	}
+	_, err := m.layerStore.ReleaseRWLayer(rwLayer)
+	return err
+}
+
+// retain takes a reference on the RW layer of a mount done before the daemon
+// restarted, so that it is kept until the image is unmounted.
+func (m *imageMount) retain(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rwLayer, err := m.layerStore.GetRWLayer(id)
+	if err != nil {
+		return err
+	}
+	m.refs = append(m.refs, rwLayer)
+	return nil
+}
+
+// lazyInitializeImageMount initializes the image of a mount point of type
+// image if needed, as it is not stored with the container.
+func (daemon *Daemon) lazyInitializeImageMount(c *container.Container, m *volume.MountPoint) error {
+	if m.Type != mounttypes.TypeImage || m.Image != nil {
+		return nil
+	}
+
+	img, err := daemon.imageStore.Get(image.ID(m.Name))
+	if err != nil {
+		return err
+	}
+	mnt := &imageMount{
+		layerStore: daemon.layerStore,
+		chainID:    img.RootFS.ChainID(),
+		mountLabel: c.MountLabel,
+	}
+	if m.Spec.ImageOptions != nil {
+		mnt.subpath = m.Spec.ImageOptions.Subpath
+	}
+	m.Image = mnt
+	return nil
+}
+
+// restoreImageMounts takes a reference on the images mounted for a container
+// that kept running while the daemon restarted.
+func (daemon *Daemon) restoreImageMounts(c *container.Container) error {
+	for _, m := range c.MountPoints {
+		if m.Type != mounttypes.TypeImage || m.ID == "" {
+			continue
+		}
+		if err := daemon.lazyInitializeImageMount(c, m); err != nil {
+			return err
+		}
+		if err := m.Image.(*imageMount).retain(m.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// containerUsesImage returns true if the container was created from the image
+// with the given ID, or mounts it.
+func containerUsesImage(c *container.Container, imgID image.ID) bool {
+	if c.ImageID == imgID {
+		return true
+	}
+	for _, m := range c.MountPoints {
+		if m.Type == mounttypes.TypeImage && m.Name == imgID.String() {
+			return true
+		}
+	}
+	return false
+}
@@ -1,23 +1,38 @@
 package daemon
 
 import (
+	"compress/gzip"
+	"fmt"
 	"io"
 
 	"github.com/docker/distribution/manifest/schema2"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/distribution"
 	progressutils "github.com/docker/docker/distribution/utils"
+	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/progress"
 	"github.com/docker/docker/reference"
 	"golang.org/x/net/context"
 )
 
 // PushImage initiates a push operation on the repository named localName.
-func (daemon *Daemon) PushImage(ctx context.Context, image, tag string, metaHeaders map[string][]string, authConfig *types.AuthConfig, outStream io.Writer) error {
+// The layers are compressed with the compression level of the daemon if
+// compressionLevel is nil.
+func (daemon *Daemon) PushImage(ctx context.Context, image, tag string, compressionLevel *int, metaHeaders map[string][]string, authConfig *types.AuthConfig, outStream io.Writer) error {
 	ref, err := reference.ParseNamed(image)
 	if err != nil {
 		return err
 	}
+	if compressionLevel == nil {
+		compressionLevel = daemon.configStore.PushCompressionLevel
+	} else if !validCompressionLevel(*compressionLevel) {
+		return fmt.Errorf("invalid compression level: %d", *compressionLevel)
+	}
+	compression, err := pushCompression(daemon.configStore.PushCompression)
+	if err != nil {
+		return err
+	}
 	if tag != "" {
 		// Push by digest is not supported, so only tags are supported.
 		ref, err = reference.WithTag(ref, tag)
@@ -50,10 +65,14 @@ func (daemon *Daemon) PushImage(ctx context.Context, image, tag string, metaHead
 			ImageStore:       distribution.NewImageConfigStoreFromStore(daemon.imageStore),
 			ReferenceStore:   daemon.referenceStore,
 		},
-		ConfigMediaType: schema2.MediaTypeImageConfig,
-		LayerStore:      distribution.NewLayerProviderFromStore(daemon.layerStore),
-		TrustKey:        daemon.trustKey,
-		UploadManager:   daemon.uploadManager,
+		ConfigMediaType:    schema2.MediaTypeImageConfig,
+		LayerStore:         distribution.NewLayerProviderFromStore(daemon.layerStore),
+		TrustKey:           daemon.trustKey,
+		UploadManager:      daemon.uploadManager,
+		Compression:        compression,
+		CompressionLevel:   compressionLevel,
+		CompressionThreads: daemon.configStore.PushCompressionThreads,
+		BlobCache:          daemon.blobCache,
 	}
 
 	err = distribution.Push(ctx, ref, imagePushConfig)
@@ -61,3 +80,34 @@ func (daemon *Daemon) PushImage(ctx context.Context, image, tag string, metaHead
 	<-writesDone
 	return err
 }
+
+// pushCompression returns the compression of the pushed layers named by
+// name. The layers are gzip compressed if name is empty.
+func pushCompression(name string) (archive.Compression, error) {
+	switch name {
+	case "", "gzip":
+		return archive.Gzip, nil
+	case "zstd":
+		return archive.Zstd, nil
+	}
+	return archive.Uncompressed, fmt.Errorf("invalid push compression: %s", name)
+}
+
+// validCompressionLevel returns whether level is a valid gzip compression
+// level.
+func validCompressionLevel(level int) bool {
+	return level >= gzip.HuffmanOnly && level <= gzip.BestCompression
+}
+
+// pruneBlobCache removes the compressed layers of the blob cache which no
+// longer exist in the layer store.
+func (daemon *Daemon) pruneBlobCache() error {
+	diffIDs := make(map[layer.DiffID]struct{})
+	for _, l := range daemon.layerStore.Map() {
+		diffIDs[l.DiffID()] = struct{}{}
+	}
+	return daemon.blobCache.Prune(func(diffID layer.DiffID) bool {
+		_, ok := diffIDs[diffID]
+		return ok
+	})
+}
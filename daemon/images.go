@@ -182,7 +182,7 @@ func (daemon *Daemon) Images(imageFilters filters.Args, all bool, withExtraAttrs
 			// Get container count
 			newImage.Containers = 0
 			for _, c := range allContainers {
-				if c.ImageID == id {
+				if containerUsesImage(c, id) {
 					newImage.Containers++
 				}
 			}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	mounttypes "github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/container"
 	volumestore "github.com/docker/docker/volume/store"
 )
@@ -20,6 +21,14 @@ func (daemon *Daemon) prepareMountPoints(container *container.Container) error {
 func (daemon *Daemon) removeMountPoints(container *container.Container, rm bool) error {
 	var rmErrors []string
 	for _, m := range container.MountPoints {
+		if m.Type == mounttypes.TypeImage && m.ID != "" {
+			// Remove the RW layer of an image mount which was not unmounted
+			if rwLayer, err := daemon.layerStore.GetRWLayer(m.ID); err == nil {
+				if _, err := daemon.layerStore.ReleaseRWLayer(rwLayer); err != nil {
+					rmErrors = append(rmErrors, err.Error())
+				}
+			}
+		}
 		if m.Volume == nil {
 			continue
 		}
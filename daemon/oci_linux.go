@@ -618,6 +618,7 @@ func (daemon *Daemon) populateCommonSpec(s *specs.Spec, c *container.Container)
 	// only add the custom init if it is specified and the container is running in its
 	// own private pid namespace.  It does not make sense to add if it is running in the
 	// host namespace or another container's pid namespace where we already have an init
+	c.HasInit = false
 	if c.HostConfig.PidMode.IsPrivate() {
 		if (c.HostConfig.Init != nil && *c.HostConfig.Init) ||
 			(c.HostConfig.Init == nil && daemon.configStore.Init) {
@@ -641,6 +642,8 @@ func (daemon *Daemon) populateCommonSpec(s *specs.Spec, c *container.Container)
 				Source:      path,
 				Options:     []string{"bind", "ro"},
 			})
+			// exec instances can only run the init if it is mounted
+			c.HasInit = true
 		}
 	}
 	s.Process.Cwd = cwd
@@ -10,7 +10,6 @@ import (
 	"github.com/docker/docker/api/types/filters"
 	timetypes "github.com/docker/docker/api/types/time"
 	"github.com/docker/docker/image"
-	"github.com/docker/docker/layer"
 	"github.com/docker/docker/pkg/directory"
 	"github.com/docker/docker/reference"
 	"github.com/docker/docker/runconfig"
@@ -19,8 +18,10 @@ import (
 	"github.com/opencontainers/go-digest"
 )
 
-// ContainersPrune removes unused containers
-func (daemon *Daemon) ContainersPrune(pruneFilters filters.Args) (*types.ContainersPruneReport, error) {
+// ContainersPrune removes unused containers. If dryRun is true, the
+// containers are not removed, and the report lists the containers which
+// would be removed.
+func (daemon *Daemon) ContainersPrune(pruneFilters filters.Args, dryRun bool) (*types.ContainersPruneReport, error) {
 	rep := &types.ContainersPruneReport{}
 
 	until, err := getUntilFromPruneFilters(pruneFilters)
@@ -35,11 +36,13 @@ func (daemon *Daemon) ContainersPrune(pruneFilters filters.Args) (*types.Contain
 				continue
 			}
 			cSize, _ := daemon.getSize(c)
-			// TODO: sets RmLink to true?
-			err := daemon.ContainerRm(c.ID, &types.ContainerRmConfig{})
-			if err != nil {
-				logrus.Warnf("failed to prune container %s: %v", c.ID, err)
-				continue
+			if !dryRun {
+				// TODO: sets RmLink to true?
+				err := daemon.ContainerRm(c.ID, &types.ContainerRmConfig{})
+				if err != nil {
+					logrus.Warnf("failed to prune container %s: %v", c.ID, err)
+					continue
+				}
 			}
 			if cSize > 0 {
 				rep.SpaceReclaimed += uint64(cSize)
@@ -51,8 +54,9 @@ func (daemon *Daemon) ContainersPrune(pruneFilters filters.Args) (*types.Contain
 	return rep, nil
 }
 
-// VolumesPrune removes unused local volumes
-func (daemon *Daemon) VolumesPrune(pruneFilters filters.Args) (*types.VolumesPruneReport, error) {
+// VolumesPrune removes unused local volumes. If dryRun is true, the volumes
+// are not removed, and the report lists the volumes which would be removed.
+func (daemon *Daemon) VolumesPrune(pruneFilters filters.Args, dryRun bool) (*types.VolumesPruneReport, error) {
 	rep := &types.VolumesPruneReport{}
 
 	pruneVols := func(v volume.Volume) error {
@@ -64,10 +68,12 @@ func (daemon *Daemon) VolumesPrune(pruneFilters filters.Args) (*types.VolumesPru
 			if err != nil {
 				logrus.Warnf("could not determine size of volume %s: %v", name, err)
 			}
-			err = daemon.volumes.Remove(v)
-			if err != nil {
-				logrus.Warnf("could not remove volume %s: %v", name, err)
-				return nil
+			if !dryRun {
+				err = daemon.volumes.Remove(v)
+				if err != nil {
+					logrus.Warnf("could not remove volume %s: %v", name, err)
+					return nil
+				}
 			}
 			rep.SpaceReclaimed += uint64(vSize)
 			rep.VolumesDeleted = append(rep.VolumesDeleted, name)
@@ -81,8 +87,10 @@ func (daemon *Daemon) VolumesPrune(pruneFilters filters.Args) (*types.VolumesPru
 	return rep, err
 }
 
-// ImagesPrune removes unused images
-func (daemon *Daemon) ImagesPrune(pruneFilters filters.Args) (*types.ImagesPruneReport, error) {
+// ImagesPrune removes unused images. If dryRun is true, the images are not
+// removed, and the report lists the references which would be untagged and
+// the images and layers which would be deleted.
+func (daemon *Daemon) ImagesPrune(pruneFilters filters.Args, dryRun bool) (*types.ImagesPruneReport, error) {
 	rep := &types.ImagesPruneReport{}
 
 	danglingOnly := true
@@ -111,8 +119,12 @@ func (daemon *Daemon) ImagesPrune(pruneFilters filters.Args) (*types.ImagesPrune
 		imageRefs[c.ID] = true
 	}
 
+	var deleteDryRun *imageDeleteDryRun
+	if dryRun {
+		deleteDryRun = daemon.newImageDeleteDryRun()
+	}
+
 	// Filter intermediary images and get their unique size
-	allLayers := daemon.layerStore.Map()
 	topImages := map[image.ID]*image.Image{}
 	for id, img := range allImages {
 		dgst := digest.Digest(id)
@@ -151,7 +163,7 @@ func (daemon *Daemon) ImagesPrune(pruneFilters filters.Args) (*types.ImagesPrune
 
 			if shouldDelete {
 				for _, ref := range refs {
-					imgDel, err := daemon.ImageDelete(ref.String(), false, true)
+					imgDel, err := daemon.imageDelete(ref.String(), false, true, deleteDryRun)
 					if err != nil {
 						logrus.Warnf("could not delete reference %s: %v", ref.String(), err)
 						continue
@@ -160,7 +172,7 @@ func (daemon *Daemon) ImagesPrune(pruneFilters filters.Args) (*types.ImagesPrune
 				}
 			}
 		} else {
-			imgDel, err := daemon.ImageDelete(hex, false, true)
+			imgDel, err := daemon.imageDelete(hex, false, true, deleteDryRun)
 			if err != nil {
 				logrus.Warnf("could not delete image %s: %v", hex, err)
 				continue
@@ -173,17 +185,7 @@ func (daemon *Daemon) ImagesPrune(pruneFilters filters.Args) (*types.ImagesPrune
 
 	// Compute how much space was freed
 	for _, d := range rep.ImagesDeleted {
-		if d.Deleted != "" {
-			chid := layer.ChainID(d.Deleted)
-			if l, ok := allLayers[chid]; ok {
-				diffSize, err := l.DiffSize()
-				if err != nil {
-					logrus.Warnf("failed to get layer %s size: %v", chid, err)
-					continue
-				}
-				rep.SpaceReclaimed += uint64(diffSize)
-			}
-		}
+		rep.SpaceReclaimed += uint64(d.Size)
 	}
 
 	return rep, nil
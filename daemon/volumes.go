@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 
 	"github.com/Sirupsen/logrus"
@@ -114,7 +115,10 @@ func (daemon *Daemon) registerMountPoints(container *container.Container, hostCo
 				CopyData:    false,
 			}
 
-			if len(cp.Source) == 0 {
+			if m.Type == mounttypes.TypeImage {
+				cp.Type = m.Type
+				cp.RW = false
+			} else if len(cp.Source) == 0 {
 				v, err := daemon.volumes.GetWithRef(cp.Name, cp.Driver, container.ID)
 				if err != nil {
 					return err
@@ -198,6 +202,17 @@ func (daemon *Daemon) registerMountPoints(container *container.Container, hostCo
 			}
 		}
 
+		if mp.Type == mounttypes.TypeImage {
+			if runtime.GOOS == "windows" {
+				return dockererrors.NewBadRequestError(fmt.Errorf("image mounts are not supported on Windows"))
+			}
+			img, err := daemon.GetImage(cfg.Source)
+			if err != nil {
+				return err
+			}
+			mp.Name = img.ID().String()
+		}
+
 		binds[mp.Destination] = true
 		mountPoints[mp.Destination] = mp
 	}
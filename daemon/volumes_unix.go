@@ -42,6 +42,9 @@ func (daemon *Daemon) setupMounts(c *container.Container) ([]container.Mount, er
 		if err := daemon.lazyInitializeVolume(c.ID, m); err != nil {
 			return nil, err
 		}
+		if err := daemon.lazyInitializeImageMount(c, m); err != nil {
+			return nil, err
+		}
 		rootUID, rootGID := daemon.GetRemappedUIDGID()
 		path, err := m.Setup(c.MountLabel, rootUID, rootGID)
 		if err != nil {
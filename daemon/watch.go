@@ -0,0 +1,128 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/Sirupsen/logrus"
+	apierrors "github.com/docker/docker/api/errors"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/backend"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/pkg/ioutils"
+)
+
+// ContainersWatch writes the list of containers matching the list options of
+// config to its output stream, as "add" events followed by a "sync" event.
+// It then writes the changes to that list as they happen, until ctx is done.
+// The changes are detected from the container and network events, and the
+// containers are filtered in the same way as by Containers.
+func (daemon *Daemon) ContainersWatch(ctx context.Context, config *backend.ContainersWatchConfig) error {
+	options := config.ListOptions
+	if options.Limit > 0 || options.Since != "" || options.Before != "" || options.Filters.Include("since") || options.Filters.Include("before") {
+		return apierrors.NewBadRequestError(fmt.Errorf("the limit, since and before options are not supported when watching containers"))
+	}
+
+	// Subscribe before listing the containers, so that no change is missed
+	ef := filters.NewArgs()
+	ef.Add("type", events.ContainerEventType)
+	ef.Add("type", events.NetworkEventType)
+	_, l := daemon.SubscribeToEvents(time.Time{}, time.Time{}, ef)
+	defer daemon.UnsubscribeFromEvents(l)
+
+	// Errors in the options are returned before the stream starts
+	containers, err := daemon.Containers(options)
+	if err != nil {
+		return err
+	}
+
+	wf := ioutils.NewWriteFlusher(config.OutStream)
+	defer wf.Close()
+	wf.Flush()
+	enc := json.NewEncoder(wf)
+
+	watched := make(map[string]*types.Container, len(containers))
+	for _, c := range containers {
+		watched[c.ID] = c
+		if err := enc.Encode(&types.ContainerWatchEvent{Type: types.ContainerWatchAdd, Container: c}); err != nil {
+			return err
+		}
+	}
+	if err := enc.Encode(&types.ContainerWatchEvent{Type: types.ContainerWatchSync}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case ev := <-l:
+			jev, ok := ev.(events.Message)
+			if !ok {
+				logrus.Warnf("unexpected event message: %q", ev)
+				continue
+			}
+
+			id := jev.Actor.ID
+			if jev.Type == events.NetworkEventType {
+				if id = jev.Actor.Attributes["container"]; id == "" {
+					continue
+				}
+			}
+
+			var c *types.Container
+			if jev.Type != events.ContainerEventType || jev.Action != "destroy" {
+				if c, err = daemon.watchedContainer(options, id); err != nil {
+					return err
+				}
+			}
+
+			var watchEvent *types.ContainerWatchEvent
+			previous, seen := watched[id]
+			switch {
+			case c == nil && seen:
+				delete(watched, id)
+				watchEvent = &types.ContainerWatchEvent{Type: types.ContainerWatchDelete, Container: previous}
+			case c != nil && !seen:
+				watched[id] = c
+				watchEvent = &types.ContainerWatchEvent{Type: types.ContainerWatchAdd, Container: c}
+			case c != nil && !reflect.DeepEqual(c, previous):
+				watched[id] = c
+				watchEvent = &types.ContainerWatchEvent{Type: types.ContainerWatchUpdate, Container: c}
+			default:
+				continue
+			}
+			if err := enc.Encode(watchEvent); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			logrus.Debug("Client context cancelled, stop watching containers")
+			return nil
+		}
+	}
+}
+
+// watchedContainer returns the container with the given ID as in the list of
+// containers, or nil if it doesn't exist or doesn't match the list options.
+func (daemon *Daemon) watchedContainer(options *types.ContainerListOptions, id string) (*types.Container, error) {
+	container := daemon.containers.Get(id)
+	if container == nil {
+		return nil, nil
+	}
+
+	// The filters are folded for each change, so that the names of the
+	// containers and the ancestor images are up to date
+	ctx, err := daemon.foldFilter(options)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := daemon.reducePsContainer(container, ctx, daemon.transformContainer)
+	if err == errStopIteration {
+		return nil, nil
+	}
+	return c, err
+}
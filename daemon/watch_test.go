@@ -0,0 +1,140 @@
+package daemon
+
+import (
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/backend"
+	containertypes "github.com/docker/docker/api/types/container"
+	eventtypes "github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/events"
+	"github.com/docker/docker/daemon/network"
+	"github.com/docker/docker/pkg/registrar"
+	"github.com/docker/docker/pkg/truncindex"
+	"golang.org/x/net/context"
+)
+
+func newWatchDaemon() *Daemon {
+	return &Daemon{
+		containers:    container.NewMemoryStore(),
+		idIndex:       truncindex.NewTruncIndex([]string{}),
+		nameIndex:     registrar.NewRegistrar(),
+		EventsService: events.New(),
+	}
+}
+
+func addWatchedContainer(d *Daemon, id, team string) *container.Container {
+	c := &container.Container{
+		CommonContainer: container.CommonContainer{
+			ID:      id,
+			Name:    "/" + id,
+			ImageID: "sha256:image",
+			Config: &containertypes.Config{
+				Image:  "sha256:image",
+				Labels: map[string]string{"team": team},
+			},
+			HostConfig:      &containertypes.HostConfig{},
+			NetworkSettings: &network.Settings{},
+			State:           container.NewState(),
+		},
+	}
+	d.containers.Add(c.ID, c)
+	d.idIndex.Add(c.ID)
+	d.reserveName(c.ID, c.Name)
+	return c
+}
+
+func TestContainersWatch(t *testing.T) {
+	d := newWatchDaemon()
+	addWatchedContainer(d, "web1", "web")
+	addWatchedContainer(d, "db1", "db")
+
+	ef := filters.NewArgs()
+	ef.Add("label", "team=web")
+	r, w := io.Pipe()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- d.ContainersWatch(ctx, &backend.ContainersWatchConfig{
+			ListOptions: &types.ContainerListOptions{All: true, Filters: ef},
+			OutStream:   w,
+		})
+		w.Close()
+	}()
+
+	dec := json.NewDecoder(r)
+	expect := func(typ, id string) *types.Container {
+		var ev types.ContainerWatchEvent
+		if err := dec.Decode(&ev); err != nil {
+			t.Fatal(err)
+		}
+		if ev.Type != typ {
+			t.Fatalf("expected a %s event, got %+v", typ, ev)
+		}
+		if typ == types.ContainerWatchSync {
+			return nil
+		}
+		if ev.Container == nil || ev.Container.ID != id {
+			t.Fatalf("expected a %s event of %s, got %+v", typ, id, ev.Container)
+		}
+		return ev.Container
+	}
+	logEvent := func(action, id string) {
+		d.EventsService.Log(action, eventtypes.ContainerEventType, eventtypes.Actor{ID: id})
+	}
+
+	// the initial list only holds the matching containers
+	expect(types.ContainerWatchAdd, "web1")
+	expect(types.ContainerWatchSync, "")
+
+	// the changes of the containers that don't match are ignored
+	logEvent("start", "db1")
+
+	web2 := addWatchedContainer(d, "web2", "web")
+	logEvent("create", "web2")
+	if c := expect(types.ContainerWatchAdd, "web2"); c.State != "created" {
+		t.Fatalf("expected web2 to be created, got %s", c.State)
+	}
+
+	web2.State.SetRunning(42, true)
+	logEvent("start", "web2")
+	if c := expect(types.ContainerWatchUpdate, "web2"); c.State != "running" {
+		t.Fatalf("expected web2 to be running, got %s", c.State)
+	}
+
+	// events that don't change the container are not sent
+	logEvent("attach", "web2")
+
+	d.containers.Delete("web2")
+	logEvent("destroy", "web2")
+	expect(types.ContainerWatchDelete, "web2")
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the watch to stop when the context is done")
+	}
+}
+
+func TestContainersWatchInvalidOptions(t *testing.T) {
+	d := newWatchDaemon()
+	err := d.ContainersWatch(context.Background(), &backend.ContainersWatchConfig{
+		ListOptions: &types.ContainerListOptions{Limit: 1, Filters: filters.NewArgs()},
+		OutStream:   io.MultiWriter(),
+	})
+	if err == nil {
+		t.Fatal("expected an error for the limit option")
+	}
+	if d.EventsService.SubscribersCount() != 0 {
+		t.Fatal("expected no subscription to be left")
+	}
+}
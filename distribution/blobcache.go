@@ -0,0 +1,198 @@
+package distribution
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/distribution"
+	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/ioutils"
+	"github.com/opencontainers/go-digest"
+)
+
+// BlobCache stores the compressed blobs of the layers that were pushed with
+// their digest, so that the layers are not compressed again when they are
+// pushed to another registry. The blobs are stored by the DiffID of their
+// layer, their media type and their compression level.
+type BlobCache struct {
+	root string
+}
+
+// NewBlobCache returns a BlobCache storing the blobs in the directory root.
+func NewBlobCache(root string) (*BlobCache, error) {
+	// Remove the blobs which were not committed
+	if err := os.RemoveAll(filepath.Join(root, "tmp")); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Join(root, "tmp"), 0700); err != nil {
+		return nil, err
+	}
+	return &BlobCache{root: root}, nil
+}
+
+func (c *BlobCache) path(diffID layer.DiffID) string {
+	dgst := digest.Digest(diffID)
+	return filepath.Join(c.root, string(dgst.Algorithm()), dgst.Hex())
+}
+
+// blobPath returns the directory of the blob of the layer with the given
+// DiffID, compressed with the given media type and level.
+func (c *BlobCache) blobPath(diffID layer.DiffID, mediaType string, level int) string {
+	key := fmt.Sprintf("%s.level%d", strings.Replace(mediaType, "/", "_", -1), level)
+	return filepath.Join(c.path(diffID), key)
+}
+
+// Get returns the blob of the layer with the given DiffID, compressed with
+// the given media type and level, with its descriptor. The error satisfies
+// os.IsNotExist if the blob is not in the cache.
+func (c *BlobCache) Get(diffID layer.DiffID, mediaType string, level int) (io.ReadCloser, distribution.Descriptor, error) {
+	dir := c.blobPath(diffID, mediaType, level)
+	dt, err := ioutil.ReadFile(filepath.Join(dir, "digest"))
+	if err != nil {
+		return nil, distribution.Descriptor{}, err
+	}
+	dgst, err := digest.Parse(strings.TrimSpace(string(dt)))
+	if err != nil {
+		return nil, distribution.Descriptor{}, err
+	}
+	f, err := os.Open(filepath.Join(dir, "blob"))
+	if err != nil {
+		return nil, distribution.Descriptor{}, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, distribution.Descriptor{}, err
+	}
+	return f, distribution.Descriptor{MediaType: mediaType, Size: fi.Size(), Digest: dgst}, nil
+}
+
+// Remove removes the blobs of the layer with the given DiffID.
+func (c *BlobCache) Remove(diffID layer.DiffID) error {
+	return os.RemoveAll(c.path(diffID))
+}
+
+// Prune removes the blobs of the layers for which keep returns false.
+func (c *BlobCache) Prune(keep func(layer.DiffID) bool) error {
+	algorithms, err := ioutil.ReadDir(c.root)
+	if err != nil {
+		return err
+	}
+	for _, a := range algorithms {
+		if !a.IsDir() || a.Name() == "tmp" {
+			continue
+		}
+		blobs, err := ioutil.ReadDir(filepath.Join(c.root, a.Name()))
+		if err != nil {
+			return err
+		}
+		for _, b := range blobs {
+			diffID := layer.DiffID(digest.NewDigestFromHex(a.Name(), b.Name()))
+			if keep(diffID) {
+				continue
+			}
+			logrus.Debugf("Removing blob of layer %s from the blob cache", diffID)
+			if err := os.RemoveAll(filepath.Join(c.root, a.Name(), b.Name())); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// newWriter returns a writer storing the blob of the layer with the given
+// DiffID, compressed with the given media type and level. The blob is only
+// added to the cache when it is committed.
+func (c *BlobCache) newWriter(diffID layer.DiffID, mediaType string, level int) (*blobCacheWriter, error) {
+	ws, err := ioutils.NewAtomicWriteSet(filepath.Join(c.root, "tmp"))
+	if err != nil {
+		return nil, err
+	}
+	f, err := ws.FileWriter("blob", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		ws.Cancel()
+		return nil, err
+	}
+	return &blobCacheWriter{
+		WriteCloser: f,
+		ws:          ws,
+		target:      c.blobPath(diffID, mediaType, level),
+	}, nil
+}
+
+// blobCacheWriter writes a blob to the cache. The cache is best effort: the
+// writes never fail, so that the push goes on if the blob can't be stored,
+// and the error is returned when the blob is committed.
+type blobCacheWriter struct {
+	io.WriteCloser
+	ws     *ioutils.AtomicWriteSet
+	target string
+	size   int64
+	err    error
+	done   bool
+}
+
+func (w *blobCacheWriter) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return len(p), nil
+	}
+	n, err := w.WriteCloser.Write(p)
+	w.size += int64(n)
+	if err == nil && n < len(p) {
+		err = io.ErrShortWrite
+	}
+	if err != nil {
+		// Drop the blob now rather than when the push is done
+		w.err = err
+		w.WriteCloser.Close()
+		w.ws.Cancel()
+	}
+	return len(p), nil
+}
+
+// Commit adds the blob to the cache if its size is the size of desc. The
+// blob is kept if it is already in the cache.
+func (w *blobCacheWriter) Commit(desc distribution.Descriptor) (err error) {
+	w.done = true
+	defer func() {
+		if err != nil {
+			w.ws.Cancel()
+		}
+	}()
+
+	if w.err != nil {
+		return fmt.Errorf("error writing blob: %v", w.err)
+	}
+	if err := w.WriteCloser.Close(); err != nil {
+		return err
+	}
+	if w.size != desc.Size {
+		return fmt.Errorf("size of cached blob %d doesn't match the size of the pushed blob %d", w.size, desc.Size)
+	}
+	if err := w.ws.WriteFile("digest", []byte(desc.Digest.String()), 0600); err != nil {
+		return err
+	}
+	if _, err := os.Stat(w.target); err == nil {
+		// The layer was cached by another push
+		return w.ws.Cancel()
+	}
+	if err := os.MkdirAll(filepath.Dir(w.target), 0700); err != nil {
+		return err
+	}
+	return w.ws.Commit(w.target)
+}
+
+// Cancel removes the blob if it was not committed.
+func (w *blobCacheWriter) Cancel() {
+	if w.done {
+		return
+	}
+	w.done = true
+	w.WriteCloser.Close()
+	w.ws.Cancel()
+}
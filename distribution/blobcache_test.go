@@ -0,0 +1,180 @@
+package distribution
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/manifest/schema2"
+	"github.com/docker/docker/layer"
+	"github.com/opencontainers/go-digest"
+)
+
+func TestBlobCache(t *testing.T) {
+	root, err := ioutil.TempDir("", "blobcache-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	c, err := NewBlobCache(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diffID := layer.DiffID(digest.FromString("layer"))
+	if _, _, err := c.Get(diffID, schema2.MediaTypeLayer, 6); !os.IsNotExist(err) {
+		t.Fatalf("expected a not exist error, got %v", err)
+	}
+
+	blob := []byte("compressed layer")
+	blobDesc := distribution.Descriptor{
+		MediaType: schema2.MediaTypeLayer,
+		Size:      int64(len(blob)),
+		Digest:    digest.FromBytes(blob),
+	}
+
+	// A blob which doesn't have the size of the pushed blob is not cached
+	w, err := c.newWriter(diffID, schema2.MediaTypeLayer, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write(blob[:4]); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Commit(blobDesc); err == nil {
+		t.Fatal("expected an error committing a truncated blob")
+	}
+	if _, _, err := c.Get(diffID, schema2.MediaTypeLayer, 6); !os.IsNotExist(err) {
+		t.Fatalf("expected a not exist error, got %v", err)
+	}
+
+	w, err = c.newWriter(diffID, schema2.MediaTypeLayer, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write(blob); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Commit(blobDesc); err != nil {
+		t.Fatal(err)
+	}
+	w.Cancel()
+	checkCachedBlob(t, c, diffID, 6, blob, blobDesc)
+
+	// The blobs with another compression or level are cached separately
+	if _, _, err := c.Get(diffID, schema2.MediaTypeLayer, 9); !os.IsNotExist(err) {
+		t.Fatalf("expected a not exist error for another level, got %v", err)
+	}
+	zstdBlob := []byte("zstd compressed layer")
+	zstdDesc := distribution.Descriptor{
+		MediaType: MediaTypeZstdLayer,
+		Size:      int64(len(zstdBlob)),
+		Digest:    digest.FromBytes(zstdBlob),
+	}
+	w, err = c.newWriter(diffID, MediaTypeZstdLayer, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write(zstdBlob); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Commit(zstdDesc); err != nil {
+		t.Fatal(err)
+	}
+	checkCachedBlob(t, c, diffID, 6, zstdBlob, zstdDesc)
+	checkCachedBlob(t, c, diffID, 6, blob, blobDesc)
+
+	// Canceled writers don't leave data behind
+	w, err = c.newWriter(layer.DiffID(digest.FromString("other layer")), schema2.MediaTypeLayer, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Cancel()
+	tmp, err := ioutil.ReadDir(filepath.Join(root, "tmp"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tmp) != 0 {
+		t.Fatalf("expected no temporary blob, got %d", len(tmp))
+	}
+
+	if err := c.Prune(func(id layer.DiffID) bool { return id != diffID }); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := c.Get(diffID, schema2.MediaTypeLayer, 6); !os.IsNotExist(err) {
+		t.Fatalf("expected a not exist error after prune, got %v", err)
+	}
+}
+
+type failingWriteCloser struct{}
+
+func (failingWriteCloser) Write(p []byte) (int, error) {
+	return 0, errors.New("no space left on device")
+}
+
+func (failingWriteCloser) Close() error {
+	return nil
+}
+
+func TestBlobCacheWriteError(t *testing.T) {
+	root, err := ioutil.TempDir("", "blobcache-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	c, err := NewBlobCache(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diffID := layer.DiffID(digest.FromString("layer"))
+	w, err := c.newWriter(diffID, schema2.MediaTypeLayer, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.WriteCloser = failingWriteCloser{}
+
+	// The errors of the cache don't fail the push
+	blob := []byte("compressed layer")
+	if n, err := w.Write(blob); err != nil || n != len(blob) {
+		t.Fatalf("expected the write to succeed, got %d, %v", n, err)
+	}
+	err = w.Commit(distribution.Descriptor{
+		MediaType: schema2.MediaTypeLayer,
+		Size:      int64(len(blob)),
+		Digest:    digest.FromBytes(blob),
+	})
+	if err == nil {
+		t.Fatal("expected an error committing the blob")
+	}
+	if _, _, err := c.Get(diffID, schema2.MediaTypeLayer, 6); !os.IsNotExist(err) {
+		t.Fatalf("expected a not exist error, got %v", err)
+	}
+	tmp, err := ioutil.ReadDir(filepath.Join(root, "tmp"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tmp) != 0 {
+		t.Fatalf("expected no temporary blob, got %d", len(tmp))
+	}
+}
+
+func checkCachedBlob(t *testing.T, c *BlobCache, diffID layer.DiffID, level int, blob []byte, blobDesc distribution.Descriptor) {
+	rc, desc, err := c.Get(diffID, blobDesc.MediaType, level)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != string(blob) || desc.Size != blobDesc.Size || desc.Digest != blobDesc.Digest || desc.MediaType != blobDesc.MediaType {
+		t.Fatalf("unexpected cached blob %q, descriptor %+v", data, desc)
+	}
+}
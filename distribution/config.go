@@ -13,6 +13,7 @@ import (
 	"github.com/docker/docker/distribution/xfer"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/progress"
 	"github.com/docker/docker/reference"
 	"github.com/docker/docker/registry"
@@ -74,6 +75,22 @@ type ImagePushConfig struct {
 	TrustKey libtrust.PrivateKey
 	// UploadManager dispatches uploads.
 	UploadManager *xfer.LayerUploadManager
+	// Compression is the compression of the pushed layers, either
+	// archive.Gzip or archive.Zstd. Other values push gzip compressed
+	// layers.
+	Compression archive.Compression
+	// CompressionLevel is the gzip compression level of the layers. This
+	// value is optional, the default level is used if it is nil. For zstd,
+	// the level is mapped to the closest zstd encoder level.
+	CompressionLevel *int
+	// CompressionThreads is the number of goroutines compressing each
+	// layer. If it is more than one, the layers are compressed in blocks,
+	// which produces different blobs than compressing them on a single
+	// goroutine.
+	CompressionThreads int
+	// BlobCache stores the compressed layers, to push them to another
+	// registry without compressing them again. This value is optional.
+	BlobCache *BlobCache
 }
 
 // ImageConfigStore handles storing and getting image configurations
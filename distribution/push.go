@@ -8,9 +8,12 @@ import (
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/distribution/metadata"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/pgzip"
 	"github.com/docker/docker/pkg/progress"
 	"github.com/docker/docker/reference"
 	"github.com/docker/docker/registry"
+	"github.com/klauspost/compress/zstd"
 	"golang.org/x/net/context"
 )
 
@@ -146,8 +149,10 @@ func Push(ctx context.Context, ref reference.Named, imagePushConfig *ImagePushCo
 }
 
 // compress returns an io.ReadCloser which will supply a compressed version of
-// the provided Reader. The caller must close the ReadCloser after reading the
-// compressed data.
+// the provided Reader, with the given compression, archive.Gzip or
+// archive.Zstd, and gzip compression level. If threads is more than one, the
+// data is compressed in blocks on several goroutines. The caller must close
+// the ReadCloser after reading the compressed data.
 //
 // Note that this function returns a reader instead of taking a writer as an
 // argument so that it can be used with httpBlobWriter's ReadFrom method.
@@ -158,16 +163,32 @@ func Push(ctx context.Context, ref reference.Named, imagePushConfig *ImagePushCo
 // is finished. This allows the caller to make sure the goroutine finishes
 // before it releases any resources connected with the reader that was
 // passed in.
-func compress(in io.Reader) (io.ReadCloser, chan struct{}) {
+func compress(in io.Reader, compression archive.Compression, level, threads int) (io.ReadCloser, chan struct{}) {
 	compressionDone := make(chan struct{})
 
 	pipeReader, pipeWriter := io.Pipe()
 	// Use a bufio.Writer to avoid excessive chunking in HTTP request.
 	bufWriter := bufio.NewWriterSize(pipeWriter, compressionBufSize)
-	compressor := gzip.NewWriter(bufWriter)
 
 	go func() {
-		_, err := io.Copy(compressor, in)
+		var (
+			compressor io.WriteCloser
+			err        error
+		)
+		switch {
+		case compression == archive.Zstd:
+			if threads < 1 {
+				threads = 1
+			}
+			compressor, err = zstd.NewWriter(bufWriter, zstd.WithEncoderLevel(zstdEncoderLevel(level)), zstd.WithEncoderConcurrency(threads))
+		case threads > 1:
+			compressor, err = pgzip.NewWriterLevel(bufWriter, level, threads)
+		default:
+			compressor, err = gzip.NewWriterLevel(bufWriter, level)
+		}
+		if err == nil {
+			_, err = io.Copy(compressor, in)
+		}
 		if err == nil {
 			err = compressor.Close()
 		}
@@ -184,3 +205,20 @@ func compress(in io.Reader) (io.ReadCloser, chan struct{}) {
 
 	return pipeReader, compressionDone
 }
+
+// zstdEncoderLevel returns the zstd encoder level closest to the gzip
+// compression level.
+func zstdEncoderLevel(level int) zstd.EncoderLevel {
+	switch {
+	case level == gzip.DefaultCompression:
+		return zstd.SpeedDefault
+	case level <= 3:
+		return zstd.SpeedFastest
+	case level <= 6:
+		return zstd.SpeedDefault
+	case level <= 8:
+		return zstd.SpeedBetterCompression
+	default:
+		return zstd.SpeedBestCompression
+	}
+}
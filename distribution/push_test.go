@@ -0,0 +1,47 @@
+package distribution
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/docker/docker/pkg/archive"
+)
+
+func TestCompress(t *testing.T) {
+	content := bytes.Repeat([]byte("layer content\n"), 10000)
+
+	for _, tc := range []struct {
+		compression archive.Compression
+		threads     int
+	}{
+		{archive.Gzip, 1},
+		{archive.Gzip, 4},
+		{archive.Zstd, 1},
+		{archive.Zstd, 4},
+	} {
+		r, done := compress(bytes.NewReader(content), tc.compression, 6, tc.threads)
+		compressed, err := ioutil.ReadAll(r)
+		r.Close()
+		<-done
+		if err != nil {
+			t.Fatal(err)
+		}
+		if compression := archive.DetectCompression(compressed); compression != tc.compression {
+			t.Fatalf("expected %s compression with %d threads, got %s", tc.compression.Extension(), tc.threads, compression.Extension())
+		}
+
+		rc, err := archive.DecompressStream(bytes.NewReader(compressed))
+		if err != nil {
+			t.Fatal(err)
+		}
+		decompressed, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(decompressed, content) {
+			t.Fatalf("unexpected decompressed content with %s compression and %d threads", tc.compression.Extension(), tc.threads)
+		}
+	}
+}
@@ -1,9 +1,11 @@
 package distribution
 
 import (
+	"compress/gzip"
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"runtime"
 	"sort"
 	"strings"
@@ -21,6 +23,7 @@ import (
 	"github.com/docker/docker/distribution/metadata"
 	"github.com/docker/docker/distribution/xfer"
 	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/pkg/progress"
 	"github.com/docker/docker/pkg/stringid"
@@ -137,13 +140,26 @@ func (p *v2Pusher) pushV2Tag(ctx context.Context, ref reference.NamedTagged, id
 
 	var descriptors []xfer.UploadDescriptor
 
+	compression := archive.Gzip
+	if p.config.Compression == archive.Zstd {
+		compression = archive.Zstd
+	}
+	compressionLevel := gzip.DefaultCompression
+	if p.config.CompressionLevel != nil {
+		compressionLevel = *p.config.CompressionLevel
+	}
+
 	descriptorTemplate := v2PushDescriptor{
-		v2MetadataService: p.v2MetadataService,
-		hmacKey:           hmacKey,
-		repoInfo:          p.repoInfo,
-		ref:               p.ref,
-		repo:              p.repo,
-		pushState:         &p.pushState,
+		v2MetadataService:  p.v2MetadataService,
+		hmacKey:            hmacKey,
+		repoInfo:           p.repoInfo,
+		ref:                p.ref,
+		repo:               p.repo,
+		pushState:          &p.pushState,
+		compression:        compression,
+		compressionLevel:   compressionLevel,
+		compressionThreads: p.config.CompressionThreads,
+		blobCache:          p.config.BlobCache,
 	}
 
 	// Loop bounds condition is to avoid pushing the base layer on Windows.
@@ -245,6 +261,11 @@ type v2PushDescriptor struct {
 	remoteDescriptor  distribution.Descriptor
 	// a set of digests whose presence has been checked in a target repository
 	checkedDigests map[digest.Digest]struct{}
+
+	compression        archive.Compression
+	compressionLevel   int
+	compressionThreads int
+	blobCache          *BlobCache
 }
 
 func (pd *v2PushDescriptor) Key() string {
@@ -337,7 +358,7 @@ func (pd *v2PushDescriptor) Upload(ctx context.Context, progressOutput progress.
 		case distribution.ErrBlobMounted:
 			progress.Updatef(progressOutput, pd.ID(), "Mounted from %s", err.From.Name())
 
-			err.Descriptor.MediaType = schema2.MediaTypeLayer
+			err.Descriptor.MediaType = metadataLayerMediaType(mountCandidate)
 
 			pd.pushState.Lock()
 			pd.pushState.confirmedV2 = true
@@ -348,6 +369,7 @@ func (pd *v2PushDescriptor) Upload(ctx context.Context, progressOutput progress.
 			if err := pd.v2MetadataService.TagAndAdd(diffID, pd.hmacKey, metadata.V2Metadata{
 				Digest:           err.Descriptor.Digest,
 				SourceRepository: pd.repoInfo.FullName(),
+				MediaType:        mountCandidate.MediaType,
 			}); err != nil {
 				return distribution.Descriptor{}, xfer.DoNotRetry{Err: err}
 			}
@@ -414,22 +436,58 @@ func (pd *v2PushDescriptor) uploadUsingSession(
 	diffID layer.DiffID,
 	layerUpload distribution.BlobWriter,
 ) (distribution.Descriptor, error) {
-	var reader io.ReadCloser
+	var (
+		reader      io.ReadCloser
+		cachedBlob  digest.Digest
+		cacheWriter *blobCacheWriter
+	)
+
+	mediaType := pd.layer.MediaType()
+	compressedMediaType := compressedLayerMediaType(pd.compression)
+	if mediaType == schema2.MediaTypeUncompressedLayer && pd.blobCache != nil {
+		blob, blobDesc, err := pd.blobCache.Get(diffID, compressedMediaType, pd.compressionLevel)
+		if err == nil {
+			logrus.Debugf("Pushing layer %s from the blob cache", diffID)
+			reader = progress.NewProgressReader(ioutils.NewCancelReadCloser(ctx, blob), progressOutput, blobDesc.Size, pd.ID(), "Pushing")
+			cachedBlob = blobDesc.Digest
+			// The cached blob is already compressed
+			mediaType = compressedMediaType
+		} else if !os.IsNotExist(err) {
+			logrus.Warnf("Failed to get layer %s from the blob cache: %v", diffID, err)
+		}
+	}
 
-	contentReader, err := pd.layer.Open()
-	size, _ := pd.layer.Size()
+	if reader == nil {
+		contentReader, err := pd.layer.Open()
+		if err != nil {
+			return distribution.Descriptor{}, retryOnError(err)
+		}
+		size, _ := pd.layer.Size()
 
-	reader = progress.NewProgressReader(ioutils.NewCancelReadCloser(ctx, contentReader), progressOutput, size, pd.ID(), "Pushing")
+		reader = progress.NewProgressReader(ioutils.NewCancelReadCloser(ctx, contentReader), progressOutput, size, pd.ID(), "Pushing")
+	}
 
-	switch m := pd.layer.MediaType(); m {
+	switch m := mediaType; m {
 	case schema2.MediaTypeUncompressedLayer:
-		compressedReader, compressionDone := compress(reader)
+		compressedReader, compressionDone := compress(reader, pd.compression, pd.compressionLevel, pd.compressionThreads)
 		defer func(closer io.Closer) {
 			closer.Close()
 			<-compressionDone
 		}(reader)
 		reader = compressedReader
-	case schema2.MediaTypeLayer:
+
+		if pd.blobCache != nil {
+			w, err := pd.blobCache.newWriter(diffID, compressedMediaType, pd.compressionLevel)
+			if err != nil {
+				logrus.Warnf("Failed to add layer %s to the blob cache: %v", diffID, err)
+			} else {
+				cacheWriter = w
+				defer cacheWriter.Cancel()
+				reader = ioutils.NewReadCloserWrapper(io.TeeReader(reader, cacheWriter), reader.Close)
+			}
+		}
+		mediaType = compressedMediaType
+	case schema2.MediaTypeLayer, MediaTypeZstdLayer:
 	default:
 		reader.Close()
 		return distribution.Descriptor{}, fmt.Errorf("unsupported layer media type %s", m)
@@ -445,10 +503,27 @@ func (pd *v2PushDescriptor) uploadUsingSession(
 	}
 
 	pushDigest := digester.Digest()
+	if cachedBlob != "" && cachedBlob != pushDigest {
+		// Compress the layer again on the next attempt
+		pd.blobCache.Remove(diffID)
+		return distribution.Descriptor{}, retryOnError(fmt.Errorf("digest of cached blob %s doesn't match the expected digest %s", pushDigest, cachedBlob))
+	}
 	if _, err := layerUpload.Commit(ctx, distribution.Descriptor{Digest: pushDigest}); err != nil {
 		return distribution.Descriptor{}, retryOnError(err)
 	}
 
+	desc := distribution.Descriptor{
+		Digest:    pushDigest,
+		MediaType: mediaType,
+		Size:      nn,
+	}
+
+	if cacheWriter != nil {
+		if err := cacheWriter.Commit(desc); err != nil {
+			logrus.Warnf("Failed to add layer %s to the blob cache: %v", diffID, err)
+		}
+	}
+
 	logrus.Debugf("uploaded layer %s (%s), %d bytes", diffID, pushDigest, nn)
 	progress.Update(progressOutput, pd.ID(), "Pushed")
 
@@ -456,16 +531,11 @@ func (pd *v2PushDescriptor) uploadUsingSession(
 	if err := pd.v2MetadataService.TagAndAdd(diffID, pd.hmacKey, metadata.V2Metadata{
 		Digest:           pushDigest,
 		SourceRepository: pd.repoInfo.FullName(),
+		MediaType:        metadataMediaType(mediaType),
 	}); err != nil {
 		return distribution.Descriptor{}, xfer.DoNotRetry{Err: err}
 	}
 
-	desc := distribution.Descriptor{
-		Digest:    pushDigest,
-		MediaType: schema2.MediaTypeLayer,
-		Size:      nn,
-	}
-
 	pd.pushState.Lock()
 	// If Commit succeeded, that's an indication that the remote registry speaks the v2 protocol.
 	pd.pushState.confirmedV2 = true
@@ -531,11 +601,12 @@ attempts:
 				if err := pd.v2MetadataService.TagAndAdd(diffID, pd.hmacKey, metadata.V2Metadata{
 					Digest:           desc.Digest,
 					SourceRepository: pd.repoInfo.FullName(),
+					MediaType:        meta.MediaType,
 				}); err != nil {
 					return distribution.Descriptor{}, false, xfer.DoNotRetry{Err: err}
 				}
 			}
-			desc.MediaType = schema2.MediaTypeLayer
+			desc.MediaType = metadataLayerMediaType(*meta)
 			exists = true
 			break attempts
 		case distribution.ErrBlobUnknown:
@@ -13,7 +13,9 @@ import (
 	"github.com/docker/distribution/registry/client/auth"
 	"github.com/docker/distribution/registry/client/transport"
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/distribution/metadata"
 	"github.com/docker/docker/dockerversion"
+	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/registry"
 	"github.com/docker/go-connections/sockets"
 	"golang.org/x/net/context"
@@ -37,6 +39,43 @@ var PluginTypes = []string{
 	schema2.MediaTypePluginConfig,
 }
 
+const (
+	// MediaTypeZstdLayer is the media type of the zstd compressed layers.
+	MediaTypeZstdLayer = "application/vnd.docker.image.rootfs.diff.tar.zstd"
+	// MediaTypeOCIZstdLayer is the OCI media type of the zstd compressed
+	// layers.
+	MediaTypeOCIZstdLayer = "application/vnd.oci.image.layer.v1.tar+zstd"
+)
+
+// compressedLayerMediaType returns the media type of the layers pushed with
+// the given compression.
+func compressedLayerMediaType(compression archive.Compression) string {
+	if compression == archive.Zstd {
+		return MediaTypeZstdLayer
+	}
+	return schema2.MediaTypeLayer
+}
+
+// metadataMediaType returns the media type of a layer blob to store in its
+// v2 metadata. It is empty for gzip compressed layers, so that the metadata
+// stored before the media type was recorded keeps matching.
+func metadataMediaType(mediaType string) string {
+	switch mediaType {
+	case MediaTypeZstdLayer, MediaTypeOCIZstdLayer:
+		return MediaTypeZstdLayer
+	}
+	return ""
+}
+
+// metadataLayerMediaType returns the media type of the layer blob described
+// by meta.
+func metadataLayerMediaType(meta metadata.V2Metadata) string {
+	if meta.MediaType == "" {
+		return schema2.MediaTypeLayer
+	}
+	return meta.MediaType
+}
+
 var mediaTypeClasses map[string]string
 
 func init() {
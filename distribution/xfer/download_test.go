@@ -141,6 +141,14 @@ func (ls *mockLayerStore) GetMountID(string) (string, error) {
 	return "", errors.New("not implemented")
 }
 
+func (ls *mockLayerStore) Verify() (*layer.VerifyReport, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (ls *mockLayerStore) Repair(*layer.VerifyReport) error {
+	return errors.New("not implemented")
+}
+
 func (ls *mockLayerStore) Cleanup() error {
 	return nil
 }
@@ -4,6 +4,11 @@
 # For updating dependencies you should change `vendor.conf` file in root of the
 # project. Please refer to https://github.com/LK4D4/vndr/blob/master/README.md for
 # vndr usage.
+#
+# Changes to vendored packages which are not available upstream yet are kept
+# as patches in hack/vendor-patches/<package>/, and are applied in order once
+# vndr has vendored the package again. If the patches don't apply to a new
+# version of a package, they must be updated or removed in the same change.
 
 set -e
 
@@ -13,3 +18,17 @@ if ! hash vndr; then
 fi
 
 vndr "$@"
+
+cd "$(dirname "$BASH_SOURCE")/.."
+for dir in $(find hack/vendor-patches -name '*.patch' -exec dirname {} \; | sort -u); do
+	pkg="${dir#hack/vendor-patches/}"
+	# the package is unchanged, and so still patched, if vndr didn't vendor it again
+	if git diff --quiet HEAD -- "vendor/$pkg"; then
+		continue
+	fi
+	echo "Applying the patches of $pkg"
+	if ! git apply "$dir"/*.patch; then
+		echo >&2 "The patches in $dir don't apply to the vendored $pkg, please update them."
+		exit 1
+	fi
+done
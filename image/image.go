@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/libtrust"
 	"github.com/opencontainers/go-digest"
 )
 
@@ -128,7 +129,7 @@ type History struct {
 
 // Exporter provides interface for loading and saving images
 type Exporter interface {
-	Load(io.ReadCloser, io.Writer, bool) error
+	Load(io.ReadCloser, io.Writer, bool, libtrust.PublicKey) error
 	// TODO: Load(net.Context, io.ReadCloser, <- chan StatusMessage) error
 	Save([]string, io.Writer) error
 }
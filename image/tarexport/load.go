@@ -12,6 +12,7 @@ import (
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/distribution"
 	"github.com/docker/docker/image"
+	"github.com/docker/docker/image/tarexport/signature"
 	"github.com/docker/docker/image/v1"
 	"github.com/docker/docker/layer"
 	"github.com/docker/docker/pkg/archive"
@@ -22,10 +23,11 @@ import (
 	"github.com/docker/docker/pkg/symlink"
 	"github.com/docker/docker/pkg/system"
 	"github.com/docker/docker/reference"
+	"github.com/docker/libtrust"
 	"github.com/opencontainers/go-digest"
 )
 
-func (l *tarexporter) Load(inTar io.ReadCloser, outStream io.Writer, quiet bool) error {
+func (l *tarexporter) Load(inTar io.ReadCloser, outStream io.Writer, quiet bool, verifyKey libtrust.PublicKey) error {
 	var (
 		sf             = streamformatter.NewJSONStreamFormatter()
 		progressOutput progress.Output
@@ -49,20 +51,28 @@ func (l *tarexporter) Load(inTar io.ReadCloser, outStream io.Writer, quiet bool)
 	if err != nil {
 		return err
 	}
-	manifestFile, err := os.Open(manifestPath)
+	manifestData, err := ioutil.ReadFile(manifestPath)
 	if err != nil {
 		if os.IsNotExist(err) {
+			if verifyKey != nil {
+				return signature.ErrNotSigned
+			}
 			return l.legacyLoad(tmpDir, outStream, progressOutput)
 		}
 		return err
 	}
-	defer manifestFile.Close()
 
 	var manifest []manifestItem
-	if err := json.NewDecoder(manifestFile).Decode(&manifest); err != nil {
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
 		return err
 	}
 
+	if verifyKey != nil {
+		if err := verify(tmpDir, manifestData, manifest, verifyKey); err != nil {
+			return err
+		}
+	}
+
 	var parentLinks []parentLink
 	var imageIDsStr string
 	var imageRefCount int
@@ -149,6 +159,45 @@ func (l *tarexporter) Load(inTar io.ReadCloser, outStream io.Writer, quiet bool)
 	return nil
 }
 
+// verify checks that the manifest and the image configurations of the
+// archive in tmpDir are signed by verifyKey. The layers are verified
+// against the DiffIDs of the configurations when they are loaded.
+func verify(tmpDir string, manifestData []byte, manifest []manifestItem, verifyKey libtrust.PublicKey) error {
+	signaturePath, err := safePath(tmpDir, signature.FileName)
+	if err != nil {
+		return err
+	}
+	sig, err := ioutil.ReadFile(signaturePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return signature.ErrNotSigned
+		}
+		return err
+	}
+	payload, err := signature.Verify(sig, verifyKey)
+	if err != nil {
+		return err
+	}
+
+	if dgst := digest.FromBytes(manifestData); dgst != payload.Manifest {
+		return fmt.Errorf("invalid signature, manifest digest mismatch: expected %s, got %s", payload.Manifest, dgst)
+	}
+	for _, m := range manifest {
+		configPath, err := safePath(tmpDir, m.Config)
+		if err != nil {
+			return err
+		}
+		config, err := ioutil.ReadFile(configPath)
+		if err != nil {
+			return err
+		}
+		if dgst := digest.FromBytes(config); !payload.HasConfig(dgst) {
+			return fmt.Errorf("invalid signature, image configuration %s with digest %s is not signed", m.Config, dgst)
+		}
+	}
+	return nil
+}
+
 func (l *tarexporter) setParentID(id, parentID image.ID) error {
 	img, err := l.is.Get(id)
 	if err != nil {
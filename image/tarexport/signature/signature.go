@@ -0,0 +1,184 @@
+// Package signature signs and verifies the tar archives of images created by
+// docker save.
+//
+// The signature is a JWS over the digests of the manifest.json file and of
+// the image configurations it references, stored in the archive in the
+// signature.json file. The configurations contain the DiffIDs of the layers,
+// so every layer of a signed archive is verified when it is loaded.
+package signature
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"github.com/docker/libtrust"
+	"github.com/opencontainers/go-digest"
+)
+
+const (
+	// FileName is the name of the signature file in the archive.
+	FileName = "signature.json"
+
+	manifestFileName = "manifest.json"
+)
+
+// ErrNotSigned is returned when an archive which must be verified has no
+// signature.
+var ErrNotSigned = errors.New("archive is not signed")
+
+// Payload is the signed content of a signature.
+type Payload struct {
+	// Manifest is the digest of the manifest.json file.
+	Manifest digest.Digest
+	// Configs are the digests of the image configurations referenced by
+	// the manifest.
+	Configs []digest.Digest
+}
+
+// HasConfig returns true if dgst is the digest of one of the signed image
+// configurations.
+func (p *Payload) HasConfig(dgst digest.Digest) bool {
+	for _, c := range p.Configs {
+		if c == dgst {
+			return true
+		}
+	}
+	return false
+}
+
+// Sign copies the archive read from r to w, adding a signature by key of its
+// manifest and image configurations. An existing signature is replaced.
+func Sign(w io.Writer, r io.Reader, key libtrust.PrivateKey) error {
+	tr := tar.NewReader(r)
+	tw := tar.NewWriter(w)
+
+	var manifest []byte
+	digests := make(map[string]digest.Digest)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		name := path.Clean(hdr.Name)
+		if name == FileName {
+			continue
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+
+		// Only the files at the root of the archive are signed
+		if (hdr.Typeflag != tar.TypeReg && hdr.Typeflag != tar.TypeRegA) || strings.Contains(name, "/") {
+			if _, err := io.Copy(tw, tr); err != nil {
+				return err
+			}
+			continue
+		}
+
+		digester := digest.Canonical.Digester()
+		in := io.TeeReader(tr, digester.Hash())
+		if name == manifestFileName {
+			if manifest, err = ioutil.ReadAll(in); err != nil {
+				return err
+			}
+			if _, err := tw.Write(manifest); err != nil {
+				return err
+			}
+		} else if _, err := io.Copy(tw, in); err != nil {
+			return err
+		}
+		digests[name] = digester.Digest()
+	}
+
+	if manifest == nil {
+		return fmt.Errorf("invalid archive, %s not found", manifestFileName)
+	}
+	var items []struct{ Config string }
+	if err := json.Unmarshal(manifest, &items); err != nil {
+		return err
+	}
+
+	payload := Payload{Manifest: digests[manifestFileName]}
+	for _, item := range items {
+		dgst, ok := digests[path.Clean(item.Config)]
+		if !ok {
+			return fmt.Errorf("invalid archive, image configuration %s not found", item.Config)
+		}
+		payload.Configs = append(payload.Configs, dgst)
+	}
+
+	sig, err := sign(payload, key)
+	if err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     FileName,
+		Mode:     0644,
+		Size:     int64(len(sig)),
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return err
+	}
+	if _, err := tw.Write(sig); err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+func sign(payload Payload, key libtrust.PrivateKey) ([]byte, error) {
+	content, err := json.MarshalIndent(payload, "", "   ")
+	if err != nil {
+		return nil, err
+	}
+	js, err := libtrust.NewJSONSignature(content)
+	if err != nil {
+		return nil, err
+	}
+	if err := js.Sign(key); err != nil {
+		return nil, err
+	}
+	return js.JWS()
+}
+
+// Verify checks that sig, the content of a signature file, is a valid
+// signature by key, and returns its payload.
+func Verify(sig []byte, key libtrust.PublicKey) (*Payload, error) {
+	js, err := libtrust.ParseJWS(sig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %v", err)
+	}
+	keys, err := js.Verify()
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %v", err)
+	}
+
+	var signed bool
+	for _, k := range keys {
+		if k.KeyID() == key.KeyID() {
+			signed = true
+			break
+		}
+	}
+	if !signed {
+		return nil, fmt.Errorf("archive is not signed by key %s", key.KeyID())
+	}
+
+	content, err := js.Payload()
+	if err != nil {
+		return nil, err
+	}
+	var payload Payload
+	if err := json.Unmarshal(content, &payload); err != nil {
+		return nil, fmt.Errorf("invalid signature: %v", err)
+	}
+	return &payload, nil
+}
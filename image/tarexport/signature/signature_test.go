@@ -0,0 +1,155 @@
+package signature
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/docker/libtrust"
+	"github.com/opencontainers/go-digest"
+)
+
+const (
+	testConfig   = `{"rootfs":{"type":"layers","diff_ids":[]}}`
+	testManifest = `[{"Config":"config.json","RepoTags":["foo:latest"],"Layers":[]}]`
+)
+
+func makeArchive(t *testing.T, files map[string]string) []byte {
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	for _, name := range []string{"abc/", "abc/layer.tar", "config.json", "manifest.json", FileName} {
+		content, ok := files[name]
+		if !ok {
+			continue
+		}
+		hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}
+		if strings.HasSuffix(name, "/") {
+			hdr.Mode = 0755
+			hdr.Typeflag = tar.TypeDir
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func readArchive(t *testing.T, archive []byte) map[string]string {
+	files := make(map[string]string)
+	tr := tar.NewReader(bytes.NewReader(archive))
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := ioutil.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[hdr.Name] = string(content)
+	}
+	return files
+}
+
+func TestSignVerify(t *testing.T) {
+	key, err := libtrust.GenerateECP256PrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := libtrust.GenerateECP256PrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"abc/":          "",
+		"abc/layer.tar": "layer",
+		"config.json":   testConfig,
+		"manifest.json": testManifest,
+		FileName:        "previous signature",
+	}
+	signed := &bytes.Buffer{}
+	if err := Sign(signed, bytes.NewReader(makeArchive(t, files)), key); err != nil {
+		t.Fatal(err)
+	}
+
+	signedFiles := readArchive(t, signed.Bytes())
+	if len(signedFiles) != len(files) {
+		t.Fatalf("expected %d files in the signed archive, got %d", len(files), len(signedFiles))
+	}
+	for name, content := range files {
+		if name != FileName && signedFiles[name] != content {
+			t.Fatalf("unexpected content of %s in the signed archive: %q", name, signedFiles[name])
+		}
+	}
+
+	payload, err := Verify([]byte(signedFiles[FileName]), key.PublicKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := digest.FromString(testManifest); payload.Manifest != expected {
+		t.Fatalf("expected manifest digest %s, got %s", expected, payload.Manifest)
+	}
+	if !payload.HasConfig(digest.FromString(testConfig)) || len(payload.Configs) != 1 {
+		t.Fatalf("unexpected config digests %v", payload.Configs)
+	}
+
+	if _, err := Verify([]byte(signedFiles[FileName]), otherKey.PublicKey()); err == nil || !strings.Contains(err.Error(), "not signed by key") {
+		t.Fatalf("expected an error with another key, got %v", err)
+	}
+}
+
+func TestVerifyTamperedSignature(t *testing.T) {
+	key, err := libtrust.GenerateECP256PrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := sign(Payload{Manifest: digest.FromString(testManifest)}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	js, err := libtrust.ParseJWS(sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signatures, err := js.Signatures()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered, err := libtrust.NewJSONSignature([]byte(`{"Manifest": "`+digest.FromString("other").String()+`"}`), signatures...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tamperedSig, err := tampered.JWS()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Verify(tamperedSig, key.PublicKey()); err == nil || !strings.Contains(err.Error(), "invalid signature") {
+		t.Fatalf("expected an invalid signature error, got %v", err)
+	}
+}
+
+func TestSignWithoutManifest(t *testing.T) {
+	key, err := libtrust.GenerateECP256PrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	archive := makeArchive(t, map[string]string{"config.json": testConfig})
+	if err := Sign(ioutil.Discard, bytes.NewReader(archive), key); err == nil {
+		t.Fatal("expected an error signing an archive without manifest")
+	}
+}
@@ -49,3 +49,29 @@ func (s *DockerSuite) TestExportContainerWithOutputAndImportImage(c *check.C) {
 	cleanedImageID := strings.TrimSpace(result.Combined())
 	c.Assert(cleanedImageID, checker.Not(checker.Equals), "", check.Commentf("output should have been an image id"))
 }
+
+// export a container with its volumes and import it as a new container
+func (s *DockerSuite) TestExportFullAndImportContainer(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	containerID := "testexportfullandimportcontainer"
+
+	dockerCmd(c, "run", "--name", containerID, "--label", "foo=bar", "-v", "testexportfullvolume:/data", "busybox", "sh", "-c", "echo changed > /changed && echo data > /data/file && rm /etc/group")
+	dockerCmd(c, "container", "export", "--full", "--volumes", "--output=testexpfull.tar", containerID)
+	defer os.Remove("testexpfull.tar")
+
+	dockerCmd(c, "rm", containerID)
+	dockerCmd(c, "volume", "rm", "testexportfullvolume")
+
+	out, _ := dockerCmd(c, "container", "import", "--input=testexpfull.tar", "--name", "testimportedcontainer")
+	c.Assert(strings.TrimSpace(out), checker.Not(checker.Equals), "", check.Commentf("output should have been a container id"))
+
+	out = inspectField(c, "testimportedcontainer", "Config.Labels.foo")
+	c.Assert(out, checker.Equals, "bar")
+
+	out, _ = dockerCmd(c, "run", "--rm", "--volumes-from", "testimportedcontainer", "busybox", "cat", "/data/file")
+	c.Assert(strings.TrimSpace(out), checker.Equals, "data")
+
+	out, _ = dockerCmd(c, "container", "diff", "testimportedcontainer")
+	c.Assert(out, checker.Contains, "A /changed")
+	c.Assert(out, checker.Contains, "D /etc/group")
+}
@@ -92,6 +92,36 @@ func (s *DockerDaemonSuite) TestPruneImageDangling(c *check.C) {
 	c.Assert(strings.TrimSpace(out), checker.Not(checker.Contains), id)
 }
 
+func (s *DockerDaemonSuite) TestPruneImageDryRun(c *check.C) {
+	s.d.StartWithBusybox(c)
+
+	out, _, err := s.d.BuildImageWithOut("test",
+		`FROM busybox
+                 RUN echo hello > /hello`, true, "-q")
+	c.Assert(err, checker.IsNil)
+	id := strings.TrimSpace(out)
+
+	out, err = s.d.Cmd("image", "prune", "--all", "--dry-run")
+	c.Assert(err, checker.IsNil)
+	c.Assert(out, checker.Contains, "delete: "+id)
+	c.Assert(out, checker.Not(checker.Contains), "Total reclaimable space: 0 B")
+
+	out, err = s.d.Cmd("images", "-q", "--no-trunc")
+	c.Assert(err, checker.IsNil)
+	c.Assert(strings.TrimSpace(out), checker.Contains, id)
+
+	// The layers of busybox are used by the test image, only the layer
+	// of the test image is freed
+	out, err = s.d.Cmd("rmi", "--dry-run", "test")
+	c.Assert(err, checker.IsNil)
+	c.Assert(out, checker.Contains, "Would untag: test:latest")
+	c.Assert(out, checker.Contains, "Would delete: "+id)
+
+	out, err = s.d.Cmd("images", "-q", "--no-trunc")
+	c.Assert(err, checker.IsNil)
+	c.Assert(strings.TrimSpace(out), checker.Contains, id)
+}
+
 func (s *DockerSuite) TestPruneContainerUntil(c *check.C) {
 	out, _ := dockerCmd(c, "run", "-d", "busybox")
 	id1 := strings.TrimSpace(out)
@@ -16,6 +16,7 @@ import (
 	"github.com/docker/docker/integration-cli/checker"
 	"github.com/docker/docker/pkg/testutil"
 	icmd "github.com/docker/docker/pkg/testutil/cmd"
+	"github.com/docker/libtrust"
 	"github.com/go-check/check"
 	"github.com/opencontainers/go-digest"
 )
@@ -382,3 +383,61 @@ func (s *DockerSuite) TestSaveLoadNoTag(c *check.C) {
 	c.Assert(out, checker.Contains, "Loaded image: "+name+":latest")
 	c.Assert(out, checker.Not(checker.Contains), "Loaded image ID:")
 }
+
+func (s *DockerSuite) TestSaveSignAndLoadVerify(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	name := "saveloadsigned"
+	buildImageSuccessfully(c, name, withDockerfile("FROM busybox\nENV foo=bar"))
+
+	tmpDir, err := ioutil.TempDir("", "save-sign-load-verify")
+	c.Assert(err, checker.IsNil)
+	defer os.RemoveAll(tmpDir)
+
+	key, err := libtrust.GenerateECP256PrivateKey()
+	c.Assert(err, checker.IsNil)
+	otherKey, err := libtrust.GenerateECP256PrivateKey()
+	c.Assert(err, checker.IsNil)
+	keyFile := filepath.Join(tmpDir, "key.pem")
+	c.Assert(libtrust.SaveKey(keyFile, key), checker.IsNil)
+	pubKeyFile := filepath.Join(tmpDir, "key.pub.pem")
+	c.Assert(libtrust.SavePublicKey(pubKeyFile, key.PublicKey()), checker.IsNil)
+	otherPubKeyFile := filepath.Join(tmpDir, "other.pub.pem")
+	c.Assert(libtrust.SavePublicKey(otherPubKeyFile, otherKey.PublicKey()), checker.IsNil)
+
+	unsigned := filepath.Join(tmpDir, "unsigned.tar")
+	dockerCmd(c, "save", "-o", unsigned, name)
+	signed := filepath.Join(tmpDir, "signed.tar")
+	dockerCmd(c, "save", "--sign-key", keyFile, "-o", signed, name)
+
+	out, _ := dockerCmd(c, "load", "--verify-key", pubKeyFile, "-i", signed)
+	c.Assert(out, checker.Contains, "Loaded image: "+name+":latest")
+
+	// an archive signed with the key can be loaded without verification
+	out, _ = dockerCmd(c, "load", "-i", signed)
+	c.Assert(out, checker.Contains, "Loaded image: "+name+":latest")
+
+	out, _, err = dockerCmdWithError("load", "--verify-key", otherPubKeyFile, "-i", signed)
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "archive is not signed by key "+otherKey.KeyID())
+
+	out, _, err = dockerCmdWithError("load", "--verify-key", pubKeyFile, "-i", unsigned)
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "archive is not signed")
+
+	// rename the image in the manifest of the signed archive
+	extractDir := filepath.Join(tmpDir, "extract")
+	c.Assert(os.Mkdir(extractDir, 0755), checker.IsNil)
+	icmd.RunCommand("tar", "-xf", signed, "-C", extractDir).Assert(c, icmd.Success)
+	manifest, err := ioutil.ReadFile(filepath.Join(extractDir, "manifest.json"))
+	c.Assert(err, checker.IsNil)
+	manifest = []byte(strings.Replace(string(manifest), name+":latest", name+":tampered", 1))
+	c.Assert(ioutil.WriteFile(filepath.Join(extractDir, "manifest.json"), manifest, 0644), checker.IsNil)
+	tampered := filepath.Join(tmpDir, "tampered.tar")
+	icmd.RunCommand("tar", "-cf", tampered, "-C", extractDir, ".").Assert(c, icmd.Success)
+
+	out, _, err = dockerCmdWithError("load", "--verify-key", pubKeyFile, "-i", tampered)
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "manifest digest mismatch")
+	out, _ = dockerCmd(c, "images", "-q", name+":tampered")
+	c.Assert(strings.TrimSpace(out), checker.Equals, "")
+}
@@ -0,0 +1,132 @@
+package layer
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Sirupsen/logrus"
+)
+
+// MigrateLayers registers each layer of the store src in the store dst, from
+// the tar stream reassembled from the graph driver of src, so that the layers
+// keep their ChainID. The layers which already exist in dst are not registered
+// again. The returned layers hold a reference in dst, which must be released
+// once the layers are referenced otherwise, for example by images.
+func MigrateLayers(src, dst Store) ([]Layer, error) {
+	var layers []Layer
+	for _, l := range src.Map() {
+		layers = append(layers, l)
+	}
+	// Parents are registered before their children
+	sort.Sort(byLayerDepth(layers))
+
+	var migrated []Layer
+	release := func() {
+		for _, l := range migrated {
+			dst.Release(l)
+		}
+	}
+
+	for i, l := range layers {
+		if existing, err := dst.Get(l.ChainID()); err == nil {
+			migrated = append(migrated, existing)
+			continue
+		}
+
+		logrus.Infof("Migrating layer %s (%d/%d)", l.ChainID(), i+1, len(layers))
+		var parent ChainID
+		if p := l.Parent(); p != nil {
+			parent = p.ChainID()
+		}
+
+		ts, err := l.TarStream()
+		if err != nil {
+			release()
+			return nil, fmt.Errorf("error reading layer %s: %v", l.ChainID(), err)
+		}
+		newLayer, err := dst.Register(ts, parent)
+		ts.Close()
+		if err != nil {
+			release()
+			return nil, fmt.Errorf("error registering layer %s: %v", l.ChainID(), err)
+		}
+		migrated = append(migrated, newLayer)
+
+		if newLayer.ChainID() != l.ChainID() {
+			release()
+			return nil, fmt.Errorf("layer %s was registered as %s", l.ChainID(), newLayer.ChainID())
+		}
+	}
+
+	return migrated, nil
+}
+
+// MigrateRWLayer creates the RW layer name in the store dst, on top of the
+// same parent as the RW layer with that name in the store src, and applies
+// the changes of the RW layer of src to it. An existing RW layer with that
+// name in dst, left by an interrupted migration, is replaced. The stores must
+// not be in use, as the RW layers are not referenced by their containers
+// during the migration.
+func MigrateRWLayer(src, dst Store, name string, opts *CreateRWLayerOpts) error {
+	ls, ok := dst.(*layerStore)
+	if !ok {
+		return fmt.Errorf("migration of RW layers is not supported by the layer store")
+	}
+
+	// The reference of the RW layer in src is not released, as releasing the
+	// last reference removes the RW layer
+	srcLayer, err := src.GetRWLayer(name)
+	if err != nil {
+		return err
+	}
+
+	ls.mountL.Lock()
+	if existing, ok := ls.mounts[name]; ok {
+		if err := ls.removeMount(existing); err != nil {
+			ls.mountL.Unlock()
+			return fmt.Errorf("error removing RW layer %s of a previous migration: %v", name, err)
+		}
+	}
+	ls.mountL.Unlock()
+
+	var parent ChainID
+	if p := srcLayer.Parent(); p != nil {
+		parent = p.ChainID()
+	}
+	if _, err := ls.CreateRWLayer(name, parent, opts); err != nil {
+		return err
+	}
+
+	ls.mountL.Lock()
+	m := ls.mounts[name]
+	ls.mountL.Unlock()
+
+	changes, err := srcLayer.TarStream()
+	if err != nil {
+		return err
+	}
+	defer changes.Close()
+
+	if _, err := ls.driver.ApplyDiff(m.mountID, m.cacheParent(), changes); err != nil {
+		return fmt.Errorf("error applying the changes of RW layer %s: %v", name, err)
+	}
+	return nil
+}
+
+// byLayerDepth is a temporary type used to sort layers by their number of
+// parents, in increasing order.
+type byLayerDepth []Layer
+
+func (r byLayerDepth) Len() int      { return len(r) }
+func (r byLayerDepth) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r byLayerDepth) Less(i, j int) bool {
+	return layerDepth(r[i]) < layerDepth(r[j])
+}
+
+func layerDepth(l Layer) int {
+	depth := 0
+	for p := l.Parent(); p != nil; p = p.Parent() {
+		depth++
+	}
+	return depth
+}
@@ -0,0 +1,109 @@
+package layer
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestMigrateLayersAndRWLayer(t *testing.T) {
+	// TODO Windows: the test relies on the vfs driver applying diffs
+	if runtime.GOOS == "windows" {
+		t.Skip("Failing on Windows")
+	}
+	src, _, srcCleanup := newTestStore(t)
+	defer srcCleanup()
+	dst, _, dstCleanup := newTestStore(t)
+	defer dstCleanup()
+
+	layer1, err := createLayer(src, "", initWithFiles(newTestFile("layer1.txt", []byte("layer 1 file"), 0644)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	layer2, err := createLayer(src, layer1.ChainID(), initWithFiles(
+		newTestFile("layer2.txt", []byte("layer 2 file"), 0644),
+		newTestFile("dir/layer2.txt", []byte("layer 2 file in dir"), 0600)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rwLayer, err := src.CreateRWLayer("migrated-mount", layer2.ChainID(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := rwLayer.Mount("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(p, "rw.txt"), []byte("rw file"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(p, "layer1.txt")); err != nil {
+		t.Fatal(err)
+	}
+	if err := rwLayer.Unmount(); err != nil {
+		t.Fatal(err)
+	}
+
+	migrated, err := MigrateLayers(src, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrated) != 2 {
+		t.Fatalf("Expected 2 migrated layers, got %d", len(migrated))
+	}
+	migrated2, err := dst.Get(layer2.ChainID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if migrated2.DiffID() != layer2.DiffID() {
+		t.Fatalf("Expected DiffID %s, got %s", layer2.DiffID(), migrated2.DiffID())
+	}
+
+	// A second migration reuses the existing layers
+	again, err := MigrateLayers(src, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again) != 2 {
+		t.Fatalf("Expected 2 migrated layers, got %d", len(again))
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := MigrateRWLayer(src, dst, "migrated-mount", nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dstRWLayer, err := dst.GetRWLayer("migrated-mount")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parent := dstRWLayer.Parent(); parent == nil || parent.ChainID() != layer2.ChainID() {
+		t.Fatalf("Expected parent %s, got %v", layer2.ChainID(), parent)
+	}
+	p, err = dstRWLayer.Mount("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dstRWLayer.Unmount()
+
+	for name, expected := range map[string]string{
+		"rw.txt":         "rw file",
+		"layer2.txt":     "layer 2 file",
+		"dir/layer2.txt": "layer 2 file in dir",
+	} {
+		content, err := ioutil.ReadFile(filepath.Join(p, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(content) != expected {
+			t.Fatalf("Unexpected content of %s: %q", name, content)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(p, "layer1.txt")); !os.IsNotExist(err) {
+		t.Fatalf("Expected layer1.txt to be removed, got %v", err)
+	}
+}
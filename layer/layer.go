@@ -189,6 +189,9 @@ type Store interface {
 	GetMountID(id string) (string, error)
 	ReleaseRWLayer(RWLayer) ([]Metadata, error)
 
+	Verify() (*VerifyReport, error)
+	Repair(*VerifyReport) error
+
 	Cleanup() error
 	DriverStatus() [][2]string
 	DriverName() string
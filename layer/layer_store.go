@@ -30,7 +30,10 @@ type layerStore struct {
 	driver graphdriver.Driver
 
 	layerMap map[ChainID]*roLayer
-	layerL   sync.Mutex
+	// registering holds the cache IDs of the layers which are being
+	// registered, and are not in layerMap yet
+	registering map[string]struct{}
+	layerL      sync.Mutex
 
 	mounts map[string]*mountedLayer
 	mountL sync.Mutex
@@ -75,10 +78,11 @@ func NewStoreFromOptions(options StoreOptions) (Store, error) {
 // the Store.
 func NewStoreFromGraphDriver(store MetadataStore, driver graphdriver.Driver) (Store, error) {
 	ls := &layerStore{
-		store:    store,
-		driver:   driver,
-		layerMap: map[ChainID]*roLayer{},
-		mounts:   map[string]*mountedLayer{},
+		store:       store,
+		driver:      driver,
+		layerMap:    map[ChainID]*roLayer{},
+		registering: map[string]struct{}{},
+		mounts:      map[string]*mountedLayer{},
 	}
 
 	ids, mounts, err := store.List()
@@ -278,6 +282,15 @@ func (ls *layerStore) registerWithDescriptor(ts io.Reader, parent ChainID, descr
 		descriptor:     descriptor,
 	}
 
+	ls.layerL.Lock()
+	ls.registering[layer.cacheID] = struct{}{}
+	ls.layerL.Unlock()
+	defer func() {
+		ls.layerL.Lock()
+		delete(ls.registering, layer.cacheID)
+		ls.layerL.Unlock()
+	}()
+
 	if err = ls.driver.Create(layer.cacheID, pid, nil); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,200 @@
+package layer
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/daemon/graphdriver"
+	"github.com/opencontainers/go-digest"
+)
+
+// CorruptLayer describes a layer whose content in the graph driver can't be
+// reassembled into the tar stream it was registered with.
+type CorruptLayer struct {
+	ChainID ChainID
+	DiffID  DiffID
+	Err     error
+}
+
+// VerifyReport is the result of the verification of a layer store.
+type VerifyReport struct {
+	// CorruptLayers are the layers whose content doesn't match their DiffID.
+	CorruptLayers []CorruptLayer
+	// DanglingCacheIDs are the IDs of the layers of the graph driver that are
+	// not used by any layer or RW layer of the store. They are only reported
+	// if the graph driver can list its layers.
+	DanglingCacheIDs []string
+	// OrphanedRWLayers are the names of the RW layers that are not
+	// referenced, for example by a container.
+	OrphanedRWLayers []string
+}
+
+// Verify checks that the content of each layer in the graph driver still
+// matches its DiffID, by reassembling its tar stream with the tar-split
+// metadata of the layer. It also finds the layers of the graph driver and
+// the RW layers that are not used anymore.
+func (ls *layerStore) Verify() (*VerifyReport, error) {
+	report := &VerifyReport{}
+
+	// The driver is listed first, so that the layers created while
+	// verifying are not reported as dangling
+	var driverIDs []string
+	if lister, ok := ls.driver.(graphdriver.LayerLister); ok {
+		ids, err := lister.ListLayers()
+		if err != nil && err != graphdriver.ErrNotSupported {
+			return nil, fmt.Errorf("error listing the layers of the graph driver: %v", err)
+		}
+		driverIDs = ids
+	}
+
+	used := map[string]bool{}
+	ls.mountL.Lock()
+	for name, m := range ls.mounts {
+		used[m.mountID] = true
+		if m.initID != "" {
+			used[m.initID] = true
+		}
+		if !m.hasReferences() {
+			report.OrphanedRWLayers = append(report.OrphanedRWLayers, name)
+		}
+	}
+	ls.mountL.Unlock()
+
+	// Hold a reference on the layers, so that they are not removed while
+	// they are verified. The layers being registered are already in the
+	// graph driver, but not in layerMap yet.
+	var layers []Layer
+	ls.layerL.Lock()
+	for chainID, l := range ls.layerMap {
+		used[l.cacheID] = true
+		if rl := ls.getWithoutLock(chainID); rl != nil {
+			layers = append(layers, rl.getReference())
+		}
+	}
+	for id := range ls.registering {
+		used[id] = true
+	}
+	ls.layerL.Unlock()
+
+	for _, l := range layers {
+		if err := ls.verifyLayer(l.ChainID()); err != nil {
+			logrus.Warnf("Layer %s is corrupt: %v", l.ChainID(), err)
+			report.CorruptLayers = append(report.CorruptLayers, CorruptLayer{
+				ChainID: l.ChainID(),
+				DiffID:  l.DiffID(),
+				Err:     err,
+			})
+		}
+		if _, err := ls.Release(l); err != nil {
+			logrus.Errorf("Error releasing layer %s: %v", l.ChainID(), err)
+		}
+	}
+
+	for _, id := range driverIDs {
+		if !used[id] {
+			report.DanglingCacheIDs = append(report.DanglingCacheIDs, id)
+		}
+	}
+
+	return report, nil
+}
+
+// verifyLayer reassembles the tar stream of a layer from the graph driver,
+// and compares its digest with the DiffID of the layer.
+func (ls *layerStore) verifyLayer(chainID ChainID) error {
+	ls.layerL.Lock()
+	l, ok := ls.layerMap[chainID]
+	ls.layerL.Unlock()
+	if !ok {
+		return ErrLayerDoesNotExist
+	}
+
+	metadata, err := ls.store.TarSplitReader(l.chainID)
+	if err != nil {
+		return fmt.Errorf("error reading tar-split metadata: %v", err)
+	}
+
+	digester := digest.Canonical.Digester()
+	if err := ls.assembleTarTo(l.cacheID, metadata, nil, digester.Hash()); err != nil {
+		return fmt.Errorf("error reassembling tar stream: %v", err)
+	}
+
+	if diffID := DiffID(digester.Digest()); diffID != l.diffID {
+		return fmt.Errorf("digest of the content %s does not match the DiffID", diffID)
+	}
+	return nil
+}
+
+// Repair removes the dangling layers of the graph driver and the orphaned RW
+// layers of a report, if they are still unused. The corrupt layers are not
+// repaired, because they are used by images: they are removed when all the
+// images using them are removed.
+func (ls *layerStore) Repair(report *VerifyReport) error {
+	ls.mountL.Lock()
+	defer ls.mountL.Unlock()
+
+	for _, name := range report.OrphanedRWLayers {
+		m, ok := ls.mounts[name]
+		if !ok || m.hasReferences() {
+			continue
+		}
+		if err := ls.removeMount(m); err != nil {
+			return fmt.Errorf("error removing RW layer %s: %v", name, err)
+		}
+	}
+
+	used := map[string]bool{}
+	for _, m := range ls.mounts {
+		used[m.mountID] = true
+		if m.initID != "" {
+			used[m.initID] = true
+		}
+	}
+	ls.layerL.Lock()
+	for _, l := range ls.layerMap {
+		used[l.cacheID] = true
+	}
+	for id := range ls.registering {
+		used[id] = true
+	}
+	ls.layerL.Unlock()
+
+	for _, id := range report.DanglingCacheIDs {
+		if used[id] {
+			continue
+		}
+		logrus.Infof("Removing dangling layer %s of the graph driver", id)
+		if err := ls.driver.Remove(id); err != nil {
+			return fmt.Errorf("error removing dangling layer %s: %v", id, err)
+		}
+	}
+
+	return nil
+}
+
+// removeMount removes an unreferenced RW layer from the graph driver and
+// from the metadata store, and releases its parent. It must be called with
+// mountL held.
+func (ls *layerStore) removeMount(m *mountedLayer) error {
+	if err := ls.driver.Remove(m.mountID); err != nil {
+		return err
+	}
+	if m.initID != "" {
+		if err := ls.driver.Remove(m.initID); err != nil {
+			return err
+		}
+	}
+	if err := ls.store.RemoveMount(m.name); err != nil {
+		return err
+	}
+	delete(ls.mounts, m.name)
+
+	if m.parent != nil {
+		ls.layerL.Lock()
+		defer ls.layerL.Unlock()
+		if _, err := ls.releaseLayer(m.parent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
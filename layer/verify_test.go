@@ -0,0 +1,134 @@
+package layer
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestVerifyAndRepair(t *testing.T) {
+	// TODO Windows: the test creates layers directly in the graph driver
+	if runtime.GOOS == "windows" {
+		t.Skip("Failing on Windows")
+	}
+	ls, _, cleanup := newTestStore(t)
+	defer cleanup()
+	driver := ls.(*layerStore).driver
+
+	layer1, err := createLayer(ls, "", initWithFiles(newTestFile("layer1.txt", []byte("layer 1 file"), 0644)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	layer2, err := createLayer(ls, layer1.ChainID(), initWithFiles(newTestFile("layer2.txt", []byte("layer 2 file"), 0644)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rwLayer, err := ls.CreateRWLayer("verify-mount", layer2.ChainID(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := ls.Verify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.CorruptLayers) != 0 || len(report.DanglingCacheIDs) != 0 || len(report.OrphanedRWLayers) != 0 {
+		t.Fatalf("Expected an empty report, got %+v", report)
+	}
+
+	// Corrupt the second layer, create a dangling layer in the graph driver,
+	// and drop the reference on the RW layer
+	p, err := driver.Get(cacheID(layer2), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(p, "layer2.txt"), []byte("layer 2 fil3"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := driver.Put(cacheID(layer2)); err != nil {
+		t.Fatal(err)
+	}
+	if err := driver.Create("dangling-layer", "", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := getMountLayer(rwLayer).deleteReference(rwLayer); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err = ls.Verify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.CorruptLayers) != 1 || report.CorruptLayers[0].ChainID != layer2.ChainID() {
+		t.Fatalf("Expected layer %s to be corrupt, got %+v", layer2.ChainID(), report.CorruptLayers)
+	}
+	if len(report.DanglingCacheIDs) != 1 || report.DanglingCacheIDs[0] != "dangling-layer" {
+		t.Fatalf("Expected dangling-layer to be dangling, got %v", report.DanglingCacheIDs)
+	}
+	if len(report.OrphanedRWLayers) != 1 || report.OrphanedRWLayers[0] != "verify-mount" {
+		t.Fatalf("Expected verify-mount to be orphaned, got %v", report.OrphanedRWLayers)
+	}
+
+	if err := ls.Repair(report); err != nil {
+		t.Fatal(err)
+	}
+	if driver.Exists("dangling-layer") {
+		t.Fatal("Expected dangling-layer to be removed")
+	}
+	if _, err := ls.GetRWLayer("verify-mount"); err != ErrMountDoesNotExist {
+		t.Fatalf("Expected verify-mount to be removed, got %v", err)
+	}
+
+	// The corrupt layer is still in use, so it is not removed
+	l, err := ls.Get(layer2.ChainID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ls.Release(l); err != nil {
+		t.Fatal(err)
+	}
+	report, err = ls.Verify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.CorruptLayers) != 1 || len(report.DanglingCacheIDs) != 0 || len(report.OrphanedRWLayers) != 0 {
+		t.Fatalf("Expected only the corrupt layer in the report, got %+v", report)
+	}
+}
+
+func TestVerifyAndRepairRegistering(t *testing.T) {
+	// TODO Windows: the test creates layers directly in the graph driver
+	if runtime.GOOS == "windows" {
+		t.Skip("Failing on Windows")
+	}
+	ls, _, cleanup := newTestStore(t)
+	defer cleanup()
+	store := ls.(*layerStore)
+
+	// A layer being registered is in the graph driver before being added
+	// to the layer store
+	store.layerL.Lock()
+	store.registering["registering-layer"] = struct{}{}
+	store.layerL.Unlock()
+	if err := store.driver.Create("registering-layer", "", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := ls.Verify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.DanglingCacheIDs) != 0 {
+		t.Fatalf("Expected no dangling layer, got %v", report.DanglingCacheIDs)
+	}
+
+	// Repair checks again that the layer is not being registered
+	report.DanglingCacheIDs = []string{"registering-layer"}
+	if err := ls.Repair(report); err != nil {
+		t.Fatal(err)
+	}
+	if !store.driver.Exists("registering-layer") {
+		t.Fatal("Expected registering-layer not to be removed")
+	}
+}
@@ -0,0 +1,57 @@
+package opts
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/docker/docker/pkg/authorization"
+)
+
+// AuthorizationPolicyOpt defines an authorization policy loaded from a file
+type AuthorizationPolicyOpt struct {
+	name  string
+	path  string
+	value **authorization.Policy
+}
+
+// NewNamedAuthorizationPolicyOpt creates a new AuthorizationPolicyOpt
+func NewNamedAuthorizationPolicyOpt(name string, ref **authorization.Policy) *AuthorizationPolicyOpt {
+	return &AuthorizationPolicyOpt{name: name, value: ref}
+}
+
+// Name returns the name of the AuthorizationPolicyOpt in the configuration.
+func (o *AuthorizationPolicyOpt) Name() string {
+	return o.name
+}
+
+// Set reads and validates the policy in the file at path.
+func (o *AuthorizationPolicyOpt) Set(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var policy authorization.Policy
+	if err := json.NewDecoder(f).Decode(&policy); err != nil {
+		return fmt.Errorf("invalid authorization policy %s: %v", path, err)
+	}
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+
+	o.path = path
+	*o.value = &policy
+	return nil
+}
+
+// String returns the path of the policy file.
+func (o *AuthorizationPolicyOpt) String() string {
+	return o.path
+}
+
+// Type returns the type of the option
+func (o *AuthorizationPolicyOpt) Type() string {
+	return "file"
+}
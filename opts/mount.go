@@ -52,6 +52,13 @@ func (m *MountOpt) Set(value string) error {
 		return mount.TmpfsOptions
 	}
 
+	imageOptions := func() *mounttypes.ImageOptions {
+		if mount.ImageOptions == nil {
+			mount.ImageOptions = new(mounttypes.ImageOptions)
+		}
+		return mount.ImageOptions
+	}
+
 	setValueOnMap := func(target map[string]string, value string) {
 		parts := strings.SplitN(value, "=", 2)
 		if len(parts) == 1 {
@@ -123,6 +130,8 @@ func (m *MountOpt) Set(value string) error {
 				return fmt.Errorf("invalid value for %s: %s", key, value)
 			}
 			tmpfsOptions().Mode = os.FileMode(ui64)
+		case "subpath", "image-subpath":
+			imageOptions().Subpath = value
 		default:
 			return fmt.Errorf("unexpected key '%s' in '%s'", key, field)
 		}
@@ -145,6 +154,9 @@ func (m *MountOpt) Set(value string) error {
 	if mount.TmpfsOptions != nil && mount.Type != mounttypes.TypeTmpfs {
 		return fmt.Errorf("cannot mix 'tmpfs-*' options with mount type '%s'", mount.Type)
 	}
+	if mount.ImageOptions != nil && mount.Type != mounttypes.TypeImage {
+		return fmt.Errorf("cannot mix 'subpath' option with mount type '%s'", mount.Type)
+	}
 
 	m.values = append(m.values, mount)
 	return nil
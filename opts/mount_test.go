@@ -151,6 +151,7 @@ func TestMountOptTypeConflict(t *testing.T) {
 	var m MountOpt
 	assert.Error(t, m.Set("type=bind,target=/foo,source=/foo,volume-nocopy=true"), "cannot mix")
 	assert.Error(t, m.Set("type=volume,target=/foo,source=/foo,bind-propagation=rprivate"), "cannot mix")
+	assert.Error(t, m.Set("type=volume,target=/foo,source=foo,subpath=data"), "cannot mix")
 }
 
 func TestMountOptSetTmpfsNoError(t *testing.T) {
@@ -182,3 +183,26 @@ func TestMountOptSetTmpfsError(t *testing.T) {
 	assert.Error(t, m.Set("type=tmpfs,target=/foo,tmpfs-mode=foo"), "invalid value for tmpfs-mode")
 	assert.Error(t, m.Set("type=tmpfs"), "target is required")
 }
+
+func TestMountOptSetImageNoError(t *testing.T) {
+	for _, testcase := range []string{
+		// tests several aliases that should have same result.
+		"type=image,source=models:latest,target=/data,subpath=models/v1",
+		"type=image,src=models:latest,dst=/data,image-subpath=models/v1",
+	} {
+		var mount MountOpt
+
+		assert.NilError(t, mount.Set(testcase))
+
+		mounts := mount.Value()
+		assert.Equal(t, len(mounts), 1)
+		assert.DeepEqual(t, mounts[0], mounttypes.Mount{
+			Type:   mounttypes.TypeImage,
+			Source: "models:latest",
+			Target: "/data",
+			ImageOptions: &mounttypes.ImageOptions{
+				Subpath: "models/v1",
+			},
+		})
+	}
+}
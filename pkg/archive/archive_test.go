@@ -142,6 +142,40 @@ func TestDecompressStreamXz(t *testing.T) {
 	testDecompressStream(t, "xz", "xz -f")
 }
 
+func TestCompressDecompressStreamZstd(t *testing.T) {
+	content := bytes.Repeat([]byte("zstd compressed content\n"), 1000)
+
+	var compressed bytes.Buffer
+	w, err := CompressStream(&compressed, Zstd)
+	if err != nil {
+		t.Fatalf("Failed to create the zstd compressor: %v", err)
+	}
+	if _, err := w.Write(content); err != nil {
+		t.Fatalf("Failed to write the compressed stream: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Failed to close the compressed stream: %v", err)
+	}
+	if compression := DetectCompression(compressed.Bytes()); compression != Zstd {
+		t.Fatalf("Expected the compression to be detected as zstd, got %s", compression.Extension())
+	}
+
+	r, err := DecompressStream(&compressed)
+	if err != nil {
+		t.Fatalf("Failed to decompress the zstd stream: %v", err)
+	}
+	decompressed, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatalf("Failed to read the decompressed stream: %v ", err)
+	}
+	if err = r.Close(); err != nil {
+		t.Fatalf("Failed to close the decompressed stream: %v ", err)
+	}
+	if !bytes.Equal(decompressed, content) {
+		t.Fatalf("The decompressed content does not match the original content")
+	}
+}
+
 func TestCompressStreamXzUnsupported(t *testing.T) {
 	dest, err := os.Create(tmp + "dest")
 	if err != nil {
@@ -217,6 +251,13 @@ func TestExtensionXz(t *testing.T) {
 		t.Fatalf("The extension of a bzip2 archive should be 'tar.xz'")
 	}
 }
+func TestExtensionZstd(t *testing.T) {
+	compression := Zstd
+	output := compression.Extension()
+	if output != "tar.zst" {
+		t.Fatalf("The extension of a zstd archive should be 'tar.zst'")
+	}
+}
 
 func TestCmdStreamLargeStderr(t *testing.T) {
 	cmd := exec.Command("sh", "-c", "dd if=/dev/zero bs=1k count=1000 of=/dev/stderr; echo hello")
@@ -381,6 +381,15 @@ func CopyResource(srcPath, dstPath string, followLink bool) error {
 // CopyTo handles extracting the given content whose
 // entries should be sourced from srcInfo to dstPath.
 func CopyTo(content io.Reader, srcInfo CopyInfo, dstPath string) error {
+	return CopyToWithOptions(content, srcInfo, dstPath, &TarOptions{
+		NoLchown:             true,
+		NoOverwriteDirNonDir: true,
+	})
+}
+
+// CopyToWithOptions is like CopyTo, but extracts the content with the given
+// options, for example to preserve the ownership of its entries.
+func CopyToWithOptions(content io.Reader, srcInfo CopyInfo, dstPath string, options *TarOptions) error {
 	// The destination path need not exist, but CopyInfoDestinationPath will
 	// ensure that at least the parent directory exists.
 	dstInfo, err := CopyInfoDestinationPath(normalizePath(dstPath))
@@ -394,11 +403,6 @@ func CopyTo(content io.Reader, srcInfo CopyInfo, dstPath string) error {
 	}
 	defer copyArchive.Close()
 
-	options := &TarOptions{
-		NoLchown:             true,
-		NoOverwriteDirNonDir: true,
-	}
-
 	return Untar(copyArchive, dstDir, options)
 }
 
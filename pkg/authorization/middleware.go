@@ -14,6 +14,7 @@ import (
 type Middleware struct {
 	mu      sync.Mutex
 	plugins []Plugin
+	policy  Plugin
 }
 
 // NewMiddleware creates a new Middleware
@@ -32,12 +33,23 @@ func (m *Middleware) SetPlugins(names []string) {
 	m.mu.Unlock()
 }
 
+// SetPolicyPlugin sets the built-in plugin consulted before the
+// authorization plugins. A nil plugin disables it.
+func (m *Middleware) SetPolicyPlugin(p Plugin) {
+	m.mu.Lock()
+	m.policy = p
+	m.mu.Unlock()
+}
+
 // WrapHandler returns a new handler function wrapping the previous one in the request chain.
 func (m *Middleware) WrapHandler(handler func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error) func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 
 		m.mu.Lock()
 		plugins := m.plugins
+		if m.policy != nil {
+			plugins = append([]Plugin{m.policy}, plugins...)
+		}
 		m.mu.Unlock()
 		if len(plugins) == 0 {
 			return handler(ctx, w, r, vars)
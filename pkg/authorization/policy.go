@@ -0,0 +1,560 @@
+package authorization
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/docker/docker/api/types/versions"
+)
+
+// PolicyPluginName is the name of the built-in authorization plugin that
+// enforces a Policy.
+const PolicyPluginName = "authorization-policy"
+
+// Policy is a role based access control policy for the Engine API. A request
+// is allowed if one of the roles bound to the user that sent it has a rule
+// matching the request.
+type Policy struct {
+	// Roles maps role names to roles.
+	Roles map[string]Role `json:"roles,omitempty"`
+
+	// Bindings binds users and groups to roles.
+	Bindings []Binding `json:"bindings,omitempty"`
+
+	// DefaultRole is the role of the users that are not bound to any role,
+	// including the users of requests that do not carry a TLS client
+	// certificate. Requests of such users are denied if it is empty.
+	DefaultRole string `json:"default-role,omitempty"`
+}
+
+// Role is a named set of rules.
+type Role struct {
+	// Rules are the requests allowed by the role.
+	Rules []Rule `json:"rules,omitempty"`
+
+	// AllowPrivileged allows the role to create privileged exec instances,
+	// and containers with access to the host: privileged containers, and
+	// containers with added capabilities, devices, bind mounts, the host's
+	// pid, ipc, network or user namespace, or unconfined security options.
+	// Such requests are denied otherwise, and so are the requests to create
+	// or start containers and exec instances whose body is not available to
+	// check them.
+	AllowPrivileged bool `json:"allow-privileged,omitempty"`
+}
+
+// Rule matches requests by method, route and labels of the resource the
+// request is made on.
+type Rule struct {
+	// Methods are the HTTP methods matched by the rule. All methods are
+	// matched if it is empty or contains "*".
+	Methods []string `json:"methods,omitempty"`
+
+	// Routes are patterns of the API routes matched by the rule, without
+	// the API version prefix, for example "/containers/*/logs". A segment
+	// of a pattern is matched like a file name pattern, and a trailing "**"
+	// segment matches any number of segments.
+	Routes []string `json:"routes,omitempty"`
+
+	// Labels restricts the rule to containers, volumes and networks that
+	// have all these labels. The labels of a resource being created are
+	// taken from the request.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// Binding binds users and groups to a role. Users are identified by the
+// CommonName of their TLS client certificate, and groups by its
+// OrganizationalUnits.
+type Binding struct {
+	Role   string   `json:"role"`
+	Users  []string `json:"users,omitempty"`
+	Groups []string `json:"groups,omitempty"`
+}
+
+// Validate checks that the policy is well formed.
+func (p *Policy) Validate() error {
+	for name, role := range p.Roles {
+		for _, rule := range role.Rules {
+			if len(rule.Routes) == 0 {
+				return fmt.Errorf("invalid authorization policy: a rule of role %q has no routes", name)
+			}
+			for _, route := range rule.Routes {
+				if err := validateRoutePattern(route); err != nil {
+					return fmt.Errorf("invalid authorization policy: role %q: %v", name, err)
+				}
+			}
+		}
+	}
+	for _, binding := range p.Bindings {
+		if _, ok := p.Roles[binding.Role]; !ok {
+			return fmt.Errorf("invalid authorization policy: binding to unknown role %q", binding.Role)
+		}
+	}
+	if _, ok := p.Roles[p.DefaultRole]; p.DefaultRole != "" && !ok {
+		return fmt.Errorf("invalid authorization policy: unknown default role %q", p.DefaultRole)
+	}
+	return nil
+}
+
+func validateRoutePattern(pattern string) error {
+	segments := routeSegments(pattern)
+	for i, segment := range segments {
+		if segment == "**" {
+			if i != len(segments)-1 {
+				return fmt.Errorf("invalid route %q: ** must be the last segment", pattern)
+			}
+			continue
+		}
+		if _, err := path.Match(segment, ""); err != nil {
+			return fmt.Errorf("invalid route %q: %v", pattern, err)
+		}
+	}
+	return nil
+}
+
+// ResourceLabelsFunc returns the labels of the resource of the given type
+// ("container", "volume" or "network") and name or ID.
+type ResourceLabelsFunc func(typ, name string) (map[string]string, error)
+
+// DenyFunc is called with the request and the reason of every request denied
+// by the policy plugin.
+type DenyFunc func(req *Request, reason string)
+
+// policyPlugin is the built-in authorization plugin enforcing a Policy.
+type policyPlugin struct {
+	policy *Policy
+	labels ResourceLabelsFunc
+	onDeny DenyFunc
+}
+
+// NewPolicyPlugin returns a plugin that enforces policy. labels is used to
+// look up the labels of the resources requests are made on, and onDeny, if
+// not nil, is called for every denied request.
+func NewPolicyPlugin(policy *Policy, labels ResourceLabelsFunc, onDeny DenyFunc) Plugin {
+	return &policyPlugin{
+		policy: policy,
+		labels: labels,
+		onDeny: onDeny,
+	}
+}
+
+func (p *policyPlugin) Name() string {
+	return PolicyPluginName
+}
+
+func (p *policyPlugin) AuthZRequest(authReq *Request) (*Response, error) {
+	route, err := requestRoute(authReq.RequestURI)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := p.rolesOf(authReq)
+	if len(roles) == 0 {
+		return p.deny(authReq, fmt.Sprintf("user %q is not bound to any role", authReq.User)), nil
+	}
+
+	req := &policyRequest{Request: authReq, route: route, labelsOf: p.labels}
+
+	matched := false
+	for _, role := range roles {
+		ok, err := role.allows(req)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		matched = true
+
+		if role.AllowPrivileged {
+			return &Response{Allow: true}, nil
+		}
+		privileged, err := req.privileged()
+		if err != nil {
+			return nil, err
+		}
+		if privileged == "" {
+			return &Response{Allow: true}, nil
+		}
+	}
+
+	if matched {
+		return p.deny(authReq, fmt.Sprintf("user %q is not allowed to %s", authReq.User, req.privilegedReason)), nil
+	}
+	return p.deny(authReq, fmt.Sprintf("user %q is not allowed to %s %s", authReq.User, authReq.RequestMethod, route)), nil
+}
+
+func (p *policyPlugin) AuthZResponse(authReq *Request) (*Response, error) {
+	return &Response{Allow: true}, nil
+}
+
+func (p *policyPlugin) deny(authReq *Request, reason string) *Response {
+	if p.onDeny != nil {
+		p.onDeny(authReq, reason)
+	}
+	return &Response{Allow: false, Msg: reason}
+}
+
+// rolesOf returns the roles bound to the user that sent authReq.
+func (p *policyPlugin) rolesOf(authReq *Request) []Role {
+	var groups []string
+	if len(authReq.RequestPeerCertificates) > 0 {
+		groups = authReq.RequestPeerCertificates[0].Subject.OrganizationalUnit
+	}
+
+	var roles []Role
+	for _, binding := range p.policy.Bindings {
+		if (authReq.User != "" && contains(binding.Users, authReq.User)) || containsAny(binding.Groups, groups) {
+			roles = append(roles, p.policy.Roles[binding.Role])
+		}
+	}
+	if len(roles) == 0 && p.policy.DefaultRole != "" {
+		roles = append(roles, p.policy.Roles[p.policy.DefaultRole])
+	}
+	return roles
+}
+
+func (r Role) allows(req *policyRequest) (bool, error) {
+	for _, rule := range r.Rules {
+		ok, err := rule.matches(req)
+		if ok || err != nil {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+func (r Rule) matches(req *policyRequest) (bool, error) {
+	if !r.matchesMethod(req.RequestMethod) || !r.matchesRoute(req.route) {
+		return false, nil
+	}
+	if len(r.Labels) == 0 {
+		return true, nil
+	}
+
+	labels, err := req.resourceLabels()
+	if err != nil {
+		return false, err
+	}
+	if !r.matchesLabels(labels) {
+		return false, nil
+	}
+	// copying between containers reads from the source container, so its
+	// labels must match too
+	if labels, ok := req.sourceLabels(); ok && !r.matchesLabels(labels) {
+		return false, nil
+	}
+	return true, nil
+}
+
+func (r Rule) matchesLabels(labels map[string]string) bool {
+	for k, v := range r.Labels {
+		if value, ok := labels[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
+
+func (r Rule) matchesMethod(method string) bool {
+	if len(r.Methods) == 0 {
+		return true
+	}
+	for _, m := range r.Methods {
+		if m == "*" || strings.EqualFold(m, method) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rule) matchesRoute(route string) bool {
+	for _, pattern := range r.Routes {
+		if matchRoute(pattern, route) {
+			return true
+		}
+	}
+	return false
+}
+
+// policyRequest caches the information the rules of a policy need about a
+// request.
+type policyRequest struct {
+	*Request
+	route    string
+	labelsOf ResourceLabelsFunc
+
+	labels       map[string]string
+	labelsLoaded bool
+
+	source       map[string]string
+	sourceLoaded bool
+
+	privilegedReason string
+	privilegedLoaded bool
+}
+
+// resourceLabels returns the labels of the container, volume or network the
+// request is made on.
+func (r *policyRequest) resourceLabels() (map[string]string, error) {
+	if r.labelsLoaded {
+		return r.labels, nil
+	}
+	r.labelsLoaded = true
+
+	segments := routeSegments(r.route)
+	if len(segments) < 2 {
+		return nil, nil
+	}
+
+	var typ string
+	switch segments[0] {
+	case "containers":
+		typ = "container"
+	case "volumes":
+		typ = "volume"
+	case "networks":
+		typ = "network"
+	default:
+		return nil, nil
+	}
+
+	switch segments[1] {
+	case "create":
+		// the body is missing if it was too large or chunked, so rules with
+		// labels don't match the request
+		if len(r.RequestBody) == 0 {
+			return nil, nil
+		}
+		var body struct {
+			Labels map[string]string
+		}
+		if err := json.Unmarshal(r.RequestBody, &body); err != nil {
+			return nil, err
+		}
+		r.labels = body.Labels
+	case "json", "prune":
+	default:
+		if r.labelsOf == nil {
+			return nil, nil
+		}
+		labels, err := r.labelsOf(typ, segments[1])
+		if err != nil {
+			// let the request fail in the handler, the rule doesn't match
+			return nil, nil
+		}
+		r.labels = labels
+	}
+	return r.labels, nil
+}
+
+// sourceLabels returns the labels of the container a request copies from,
+// and false if the request doesn't copy from another container.
+func (r *policyRequest) sourceLabels() (map[string]string, bool) {
+	segments := routeSegments(r.route)
+	if len(segments) != 3 || segments[0] != "containers" || segments[2] != "copy-from" {
+		return nil, false
+	}
+	if r.sourceLoaded {
+		return r.source, true
+	}
+	r.sourceLoaded = true
+
+	u, err := url.ParseRequestURI(r.RequestURI)
+	if err != nil || r.labelsOf == nil {
+		return nil, true
+	}
+	if src := u.Query().Get("srcContainer"); src != "" {
+		// the rule doesn't match if the container doesn't exist
+		r.source, _ = r.labelsOf("container", src)
+	}
+	return r.source, true
+}
+
+// privileged returns what the request does that requires AllowPrivileged, or
+// an empty string if it doesn't need it.
+func (r *policyRequest) privileged() (string, error) {
+	if r.privilegedLoaded {
+		return r.privilegedReason, nil
+	}
+	r.privilegedLoaded = true
+
+	if !strings.EqualFold(r.RequestMethod, "POST") {
+		return "", nil
+	}
+
+	var err error
+	switch {
+	case matchRoute("/containers/create", r.route):
+		if len(r.RequestBody) == 0 {
+			r.privilegedReason = "create containers without a request body to check"
+			break
+		}
+		var body struct {
+			HostConfig *hostConfig
+		}
+		if err = json.Unmarshal(r.RequestBody, &body); err == nil && body.HostConfig != nil {
+			r.privilegedReason = body.HostConfig.privileged()
+		}
+	case matchRoute("/containers/*/start", r.route):
+		// the body of a start request is only used, and so only accepted,
+		// before API 1.24
+		if version := requestVersion(r.RequestURI); version == "" || versions.GreaterThanOrEqualTo(version, "1.24") {
+			break
+		}
+		if len(r.RequestBody) == 0 {
+			r.privilegedReason = "start containers without a request body to check"
+			break
+		}
+		var body hostConfig
+		if err = json.Unmarshal(r.RequestBody, &body); err == nil {
+			r.privilegedReason = body.privileged()
+		}
+	case matchRoute("/containers/*/exec", r.route):
+		if len(r.RequestBody) == 0 {
+			r.privilegedReason = "create exec instances without a request body to check"
+			break
+		}
+		var body struct {
+			Privileged bool
+		}
+		if err = json.Unmarshal(r.RequestBody, &body); err == nil && body.Privileged {
+			r.privilegedReason = "create privileged exec instances"
+		}
+	}
+	return r.privilegedReason, err
+}
+
+// hostConfig is the part of the host configuration of a container that gives
+// it access to the host.
+type hostConfig struct {
+	Privileged  bool
+	CapAdd      []string
+	Devices     []json.RawMessage
+	Binds       []string
+	Mounts      []struct{ Type string }
+	PidMode     string
+	IpcMode     string
+	NetworkMode string
+	UsernsMode  string
+	SecurityOpt []string
+}
+
+// privileged returns the access to the host the configuration gives, or an
+// empty string if it gives none.
+func (c *hostConfig) privileged() string {
+	switch {
+	case c.Privileged:
+		return "create privileged containers"
+	case len(c.CapAdd) > 0:
+		return "create containers with added capabilities"
+	case len(c.Devices) > 0:
+		return "create containers with devices"
+	}
+	for _, bind := range c.Binds {
+		if isHostBind(bind) {
+			return "create containers with bind mounts"
+		}
+	}
+	for _, m := range c.Mounts {
+		if m.Type == "bind" {
+			return "create containers with bind mounts"
+		}
+	}
+	for _, mode := range []string{c.PidMode, c.IpcMode, c.NetworkMode, c.UsernsMode} {
+		if mode == "host" {
+			return "create containers in the host's namespaces"
+		}
+	}
+	for _, opt := range c.SecurityOpt {
+		opt = strings.Replace(opt, ":", "=", 1)
+		if strings.HasSuffix(opt, "=unconfined") || opt == "label=disable" {
+			return "create containers with unconfined security options"
+		}
+	}
+	return ""
+}
+
+// isHostBind returns whether bind, in the `source:destination[:mode]` format
+// of HostConfig.Binds, mounts a path of the host rather than a volume.
+func isHostBind(bind string) bool {
+	offset := 0
+	if len(bind) > 1 && bind[1] == ':' && unicode.IsLetter(rune(bind[0])) {
+		// skip the drive letter of a Windows path
+		offset = 2
+	}
+	i := strings.Index(bind[offset:], ":")
+	if i < 0 {
+		// a single path is an anonymous volume
+		return false
+	}
+	// volume names can't contain path separators
+	return strings.ContainsAny(bind[:offset+i], `/\`)
+}
+
+var versionPrefix = regexp.MustCompile(`^/v([0-9.]+)`)
+
+// requestRoute returns the route of a request URI, without the API version
+// prefix and the query.
+func requestRoute(requestURI string) (string, error) {
+	u, err := url.ParseRequestURI(requestURI)
+	if err != nil {
+		return "", err
+	}
+	return versionPrefix.ReplaceAllString(path.Clean(u.Path), ""), nil
+}
+
+// requestVersion returns the API version of a request URI, or an empty string
+// if it has no version prefix.
+func requestVersion(requestURI string) string {
+	if m := versionPrefix.FindStringSubmatch(requestURI); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+func routeSegments(route string) []string {
+	route = strings.Trim(route, "/")
+	if route == "" {
+		return nil
+	}
+	return strings.Split(route, "/")
+}
+
+func matchRoute(pattern, route string) bool {
+	patterns, segments := routeSegments(pattern), routeSegments(route)
+	for i, p := range patterns {
+		if p == "**" {
+			return true
+		}
+		if i >= len(segments) {
+			return false
+		}
+		if ok, _ := path.Match(p, segments[i]); !ok {
+			return false
+		}
+	}
+	return len(patterns) == len(segments)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(values, candidates []string) bool {
+	for _, c := range candidates {
+		if contains(values, c) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,288 @@
+package authorization
+
+import (
+	"crypto/x509/pkix"
+	"errors"
+	"strings"
+	"testing"
+)
+
+var testPolicy = &Policy{
+	Roles: map[string]Role{
+		"viewer": {
+			Rules: []Rule{
+				{Methods: []string{"GET"}, Routes: []string{"/**"}},
+			},
+		},
+		"web-operator": {
+			Rules: []Rule{
+				{Methods: []string{"POST"}, Routes: []string{"/containers/create", "/containers/*/start"}, Labels: map[string]string{"team": "web"}},
+			},
+		},
+		"admin": {
+			Rules: []Rule{
+				{Routes: []string{"**"}},
+			},
+			AllowPrivileged: true,
+		},
+	},
+	Bindings: []Binding{
+		{Role: "admin", Users: []string{"alice"}},
+		{Role: "web-operator", Groups: []string{"web"}},
+	},
+	DefaultRole: "viewer",
+}
+
+func testLabels(typ, name string) (map[string]string, error) {
+	if typ == "container" {
+		switch name {
+		case "frontend", "cache":
+			return map[string]string{"team": "web"}, nil
+		case "database":
+			return map[string]string{"team": "db"}, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func newTestRequest(user string, groups []string, method, uri, body string) *Request {
+	req := &Request{
+		User:          user,
+		RequestMethod: method,
+		RequestURI:    uri,
+		RequestBody:   []byte(body),
+	}
+	if user != "" {
+		req.RequestPeerCertificates = []*PeerCertificate{
+			{Subject: pkix.Name{CommonName: user, OrganizationalUnit: groups}},
+		}
+	}
+	return req
+}
+
+func TestPolicyPlugin(t *testing.T) {
+	var denied []string
+	plugin := NewPolicyPlugin(testPolicy, testLabels, func(req *Request, reason string) {
+		denied = append(denied, reason)
+	})
+
+	cases := []struct {
+		req   *Request
+		allow bool
+	}{
+		{newTestRequest("", nil, "GET", "/v1.26/containers/json?all=1", ""), true},
+		{newTestRequest("", nil, "POST", "/v1.26/containers/frontend/stop", ""), false},
+		{newTestRequest("bob", []string{"web"}, "POST", "/v1.26/containers/frontend/start", ""), true},
+		{newTestRequest("bob", []string{"web"}, "POST", "/v1.26/containers/backend/start", ""), false},
+		{newTestRequest("bob", []string{"web"}, "GET", "/v1.26/containers/backend/json", ""), false},
+		{newTestRequest("bob", []string{"web"}, "POST", "/containers/create", `{"Labels":{"team":"web"}}`), true},
+		{newTestRequest("bob", []string{"web"}, "POST", "/containers/create", `{"Labels":{"team":"db"}}`), false},
+		{newTestRequest("bob", []string{"web"}, "POST", "/containers/create", `{"Labels":{"team":"web"},"HostConfig":{"Privileged":true}}`), false},
+		{newTestRequest("alice", nil, "POST", "/containers/create", `{"HostConfig":{"Privileged":true}}`), true},
+		{newTestRequest("alice", nil, "DELETE", "/v1.26/images/busybox", ""), true},
+	}
+
+	for _, c := range cases {
+		res, err := plugin.AuthZRequest(c.req)
+		if err != nil {
+			t.Fatalf("%s %s: %v", c.req.RequestMethod, c.req.RequestURI, err)
+		}
+		if res.Allow != c.allow {
+			t.Fatalf("%s %s by %q: expected allow=%v, got %v (%s)", c.req.RequestMethod, c.req.RequestURI, c.req.User, c.allow, res.Allow, res.Msg)
+		}
+	}
+
+	if len(denied) != 5 {
+		t.Fatalf("expected 5 denials to be reported, got %d", len(denied))
+	}
+	if expected := `user "" is not allowed to POST /containers/frontend/stop`; denied[0] != expected {
+		t.Fatalf("expected %q, got %q", expected, denied[0])
+	}
+}
+
+func TestPolicyPluginCopyFrom(t *testing.T) {
+	policy := &Policy{
+		Roles: map[string]Role{
+			"web-operator": {
+				Rules: []Rule{
+					{Methods: []string{"POST"}, Routes: []string{"/containers/*/copy-from"}, Labels: map[string]string{"team": "web"}},
+				},
+			},
+		},
+		DefaultRole: "web-operator",
+	}
+	plugin := NewPolicyPlugin(policy, testLabels, nil)
+
+	cases := []struct {
+		uri   string
+		allow bool
+	}{
+		{"/v1.26/containers/frontend/copy-from?path=/dst&srcContainer=cache&srcPath=/src", true},
+		{"/v1.26/containers/frontend/copy-from?path=/dst&srcContainer=database&srcPath=/src", false},
+		{"/v1.26/containers/frontend/copy-from?path=/dst&srcContainer=unknown&srcPath=/src", false},
+		{"/v1.26/containers/frontend/copy-from?path=/dst&srcPath=/src", false},
+		{"/v1.26/containers/database/copy-from?path=/dst&srcContainer=cache&srcPath=/src", false},
+	}
+	for _, c := range cases {
+		res, err := plugin.AuthZRequest(newTestRequest("bob", nil, "POST", c.uri, ""))
+		if err != nil {
+			t.Fatalf("%s: %v", c.uri, err)
+		}
+		if res.Allow != c.allow {
+			t.Fatalf("%s: expected allow=%v, got %v (%s)", c.uri, c.allow, res.Allow, res.Msg)
+		}
+	}
+}
+
+func TestPolicyPluginHostAccess(t *testing.T) {
+	plugin := NewPolicyPlugin(testPolicy, testLabels, nil)
+
+	cases := []struct {
+		uri, body string
+		allow     bool
+	}{
+		{"/containers/create", `{"Labels":{"team":"web"},"HostConfig":{"Binds":["data:/data","/cache"]}}`, true},
+		{"/containers/create", `{"Labels":{"team":"web"},"HostConfig":{"CapAdd":["SYS_ADMIN"]}}`, false},
+		{"/containers/create", `{"Labels":{"team":"web"},"HostConfig":{"Devices":[{"PathOnHost":"/dev/sda"}]}}`, false},
+		{"/containers/create", `{"Labels":{"team":"web"},"HostConfig":{"Binds":["/etc:/host/etc:ro"]}}`, false},
+		{"/containers/create", `{"Labels":{"team":"web"},"HostConfig":{"Mounts":[{"Type":"bind","Source":"/","Target":"/host"}]}}`, false},
+		{"/containers/create", `{"Labels":{"team":"web"},"HostConfig":{"Mounts":[{"Type":"volume","Source":"data","Target":"/data"}]}}`, true},
+		{"/containers/create", `{"Labels":{"team":"web"},"HostConfig":{"PidMode":"host"}}`, false},
+		{"/containers/create", `{"Labels":{"team":"web"},"HostConfig":{"IpcMode":"host"}}`, false},
+		{"/containers/create", `{"Labels":{"team":"web"},"HostConfig":{"NetworkMode":"host"}}`, false},
+		{"/containers/create", `{"Labels":{"team":"web"},"HostConfig":{"SecurityOpt":["seccomp=unconfined"]}}`, false},
+		{"/containers/create", `{"Labels":{"team":"web"},"HostConfig":{"SecurityOpt":["apparmor:unconfined"]}}`, false},
+		{"/containers/create", `{"Labels":{"team":"web"},"HostConfig":{"SecurityOpt":["label=disable"]}}`, false},
+		{"/containers/create", `{"Labels":{"team":"web"},"HostConfig":{"SecurityOpt":["no-new-privileges"]}}`, true},
+		// the body is missing if it is too large or chunked
+		{"/containers/create", "", false},
+		// the body of start requests is only used before API 1.24
+		{"/v1.23/containers/frontend/start", `{"Privileged":true}`, false},
+		{"/v1.23/containers/frontend/start", `{"Binds":["/:/host"]}`, false},
+		{"/v1.23/containers/frontend/start", `{}`, true},
+		{"/v1.23/containers/frontend/start", "", false},
+		{"/v1.24/containers/frontend/start", "", true},
+		{"/containers/frontend/start", "", true},
+	}
+	for _, c := range cases {
+		res, err := plugin.AuthZRequest(newTestRequest("bob", []string{"web"}, "POST", c.uri, c.body))
+		if err != nil {
+			t.Fatalf("%s %s: %v", c.uri, c.body, err)
+		}
+		if res.Allow != c.allow {
+			t.Fatalf("%s %s: expected allow=%v, got %v (%s)", c.uri, c.body, c.allow, res.Allow, res.Msg)
+		}
+	}
+
+	// exec instances
+	execPolicy := &Policy{
+		Roles: map[string]Role{
+			"exec": {Rules: []Rule{{Routes: []string{"/containers/*/exec"}}}},
+		},
+		DefaultRole: "exec",
+	}
+	plugin = NewPolicyPlugin(execPolicy, testLabels, nil)
+	for _, c := range []struct {
+		body  string
+		allow bool
+	}{
+		{`{"Cmd":["ls"]}`, true},
+		{`{"Cmd":["ls"],"Privileged":true}`, false},
+		{"", false},
+	} {
+		res, err := plugin.AuthZRequest(newTestRequest("", nil, "POST", "/containers/frontend/exec", c.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Allow != c.allow {
+			t.Fatalf("exec %q: expected allow=%v, got %v (%s)", c.body, c.allow, res.Allow, res.Msg)
+		}
+	}
+
+	// roles allowed to create privileged containers don't need the body
+	res, err := NewPolicyPlugin(testPolicy, testLabels, nil).AuthZRequest(newTestRequest("alice", nil, "POST", "/containers/create", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Allow {
+		t.Fatalf("expected create without body by a privileged role to be allowed, got %s", res.Msg)
+	}
+}
+
+func TestIsHostBind(t *testing.T) {
+	cases := []struct {
+		bind string
+		host bool
+	}{
+		{"/data", false},
+		{"data:/data", false},
+		{"data:/data:ro", false},
+		{"/srv/data:/data", true},
+		{"/srv/data:/data:ro", true},
+		{`c:\data`, false},
+		{`c:\srv\data:c:\data`, true},
+		{`data:c:\data`, false},
+	}
+	for _, c := range cases {
+		if host := isHostBind(c.bind); host != c.host {
+			t.Fatalf("isHostBind(%q): expected %v, got %v", c.bind, c.host, host)
+		}
+	}
+}
+
+func TestPolicyPluginNoDefaultRole(t *testing.T) {
+	policy := &Policy{Roles: testPolicy.Roles, Bindings: testPolicy.Bindings}
+	plugin := NewPolicyPlugin(policy, testLabels, nil)
+
+	res, err := plugin.AuthZRequest(newTestRequest("carol", nil, "GET", "/_ping", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Allow {
+		t.Fatal("expected request of unbound user to be denied")
+	}
+}
+
+func TestPolicyValidate(t *testing.T) {
+	if err := testPolicy.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	invalid := []struct {
+		policy *Policy
+		err    string
+	}{
+		{&Policy{DefaultRole: "viewer"}, "unknown default role"},
+		{&Policy{Bindings: []Binding{{Role: "viewer"}}}, "unknown role"},
+		{&Policy{Roles: map[string]Role{"viewer": {Rules: []Rule{{Methods: []string{"GET"}}}}}}, "no routes"},
+		{&Policy{Roles: map[string]Role{"viewer": {Rules: []Rule{{Routes: []string{"/**/json"}}}}}}, "must be the last segment"},
+		{&Policy{Roles: map[string]Role{"viewer": {Rules: []Rule{{Routes: []string{"/containers/["}}}}}}, "syntax error"},
+	}
+	for _, c := range invalid {
+		err := c.policy.Validate()
+		if err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Fatalf("expected error containing %q, got %v", c.err, err)
+		}
+	}
+}
+
+func TestMatchRoute(t *testing.T) {
+	cases := []struct {
+		pattern, route string
+		match          bool
+	}{
+		{"/containers/json", "/containers/json", true},
+		{"/containers/*/logs", "/containers/web/logs", true},
+		{"/containers/*/logs", "/containers/web/top", false},
+		{"/containers/*", "/containers/web/top", false},
+		{"/containers/**", "/containers/web/top", true},
+		{"/containers/**", "/containers", true},
+		{"**", "/info", true},
+		{"/images/*", "/images", false},
+	}
+	for _, c := range cases {
+		if match := matchRoute(c.pattern, c.route); match != c.match {
+			t.Fatalf("matchRoute(%q, %q): expected %v, got %v", c.pattern, c.route, c.match, match)
+		}
+	}
+}
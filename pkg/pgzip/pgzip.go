@@ -0,0 +1,198 @@
+// Package pgzip provides a gzip writer which compresses the data on several
+// goroutines.
+//
+// The data is split in blocks which are compressed independently, and the
+// compressed blocks are written in order as a single gzip member, so that the
+// output can be read by any gzip reader. As the blocks don't share their
+// dictionary, the output is slightly larger than the output of compress/gzip,
+// and it differs from it. It only depends on the data and on the compression
+// level, and not on the number of goroutines.
+package pgzip
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+// BlockSize is the size of the blocks of data which are compressed
+// independently.
+const BlockSize = 1 << 20
+
+type block struct {
+	data []byte
+	out  bytes.Buffer
+	done chan error
+}
+
+// Writer is an io.WriteCloser which compresses the data written to it on
+// several goroutines.
+type Writer struct {
+	w         io.Writer
+	level     int
+	threads   int
+	blockSize int
+
+	buf         []byte
+	pending     []*block
+	wroteHeader bool
+	digest      uint32
+	size        uint32
+	closed      bool
+	err         error
+}
+
+// NewWriterLevel returns a Writer which compresses the data written to it
+// with the given gzip compression level on up to threads goroutines, and
+// writes it to w. The compression level is one of the levels of
+// compress/gzip.
+func NewWriterLevel(w io.Writer, level, threads int) (*Writer, error) {
+	return newWriter(w, level, threads, BlockSize)
+}
+
+func newWriter(w io.Writer, level, threads, blockSize int) (*Writer, error) {
+	if level < flate.HuffmanOnly || level > flate.BestCompression {
+		return nil, fmt.Errorf("pgzip: invalid compression level: %d", level)
+	}
+	if threads < 1 {
+		threads = 1
+	}
+	return &Writer{
+		w:         w,
+		level:     level,
+		threads:   threads,
+		blockSize: blockSize,
+	}, nil
+}
+
+// Write compresses p. The data is buffered until a block is full, and the
+// compressed data is written to the underlying writer when the compression
+// of the previous blocks is done.
+func (z *Writer) Write(p []byte) (int, error) {
+	if z.err != nil {
+		return 0, z.err
+	}
+	if z.closed {
+		return 0, fmt.Errorf("pgzip: write to closed writer")
+	}
+
+	var n int
+	for len(p) > 0 {
+		if z.buf == nil {
+			z.buf = make([]byte, 0, z.blockSize)
+		}
+		c := copy(z.buf[len(z.buf):cap(z.buf)], p)
+		z.buf = z.buf[:len(z.buf)+c]
+		p = p[c:]
+		n += c
+
+		if len(z.buf) == cap(z.buf) {
+			if err := z.compressBlock(false); err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// Close compresses the remaining data, waits for the compression of all the
+// blocks and writes the gzip footer. It does not close the underlying writer.
+func (z *Writer) Close() error {
+	if z.closed {
+		return z.err
+	}
+	z.closed = true
+	if z.err != nil {
+		return z.err
+	}
+
+	if err := z.compressBlock(true); err != nil {
+		return err
+	}
+	for len(z.pending) > 0 {
+		if err := z.writeBlock(); err != nil {
+			return err
+		}
+	}
+
+	var footer [8]byte
+	binary.LittleEndian.PutUint32(footer[0:4], z.digest)
+	binary.LittleEndian.PutUint32(footer[4:8], z.size)
+	_, z.err = z.w.Write(footer[:])
+	return z.err
+}
+
+// compressBlock starts the compression of the buffered data. All the blocks
+// but the last one end with a sync flush, so that the compressed blocks can
+// be concatenated as a single deflate stream.
+func (z *Writer) compressBlock(last bool) error {
+	b := &block{
+		data: z.buf,
+		done: make(chan error, 1),
+	}
+	z.buf = nil
+	z.digest = crc32.Update(z.digest, crc32.IEEETable, b.data)
+	z.size += uint32(len(b.data))
+
+	go func(level int) {
+		fw, err := flate.NewWriter(&b.out, level)
+		if err == nil {
+			_, err = fw.Write(b.data)
+		}
+		if err == nil {
+			if last {
+				err = fw.Close()
+			} else {
+				err = fw.Flush()
+			}
+		}
+		b.data = nil
+		b.done <- err
+	}(z.level)
+
+	z.pending = append(z.pending, b)
+	if len(z.pending) < z.threads {
+		return nil
+	}
+	return z.writeBlock()
+}
+
+// writeBlock waits for the compression of the oldest pending block and
+// writes it to the underlying writer.
+func (z *Writer) writeBlock() error {
+	b := z.pending[0]
+	z.pending = z.pending[1:]
+
+	if err := <-b.done; err != nil {
+		z.err = err
+		return err
+	}
+	if !z.wroteHeader {
+		z.wroteHeader = true
+		if _, err := z.w.Write(z.header()); err != nil {
+			z.err = err
+			return err
+		}
+	}
+	if _, err := z.w.Write(b.out.Bytes()); err != nil {
+		z.err = err
+		return err
+	}
+	return nil
+}
+
+// header returns the gzip header written by compress/gzip for an empty
+// gzip.Header.
+func (z *Writer) header() []byte {
+	header := []byte{0x1f, 0x8b, 8, 0, 0, 0, 0, 0, 0, 255}
+	switch z.level {
+	case flate.BestCompression:
+		header[8] = 2
+	case flate.BestSpeed:
+		header[8] = 4
+	}
+	return header
+}
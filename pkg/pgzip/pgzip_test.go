@@ -0,0 +1,83 @@
+package pgzip
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"math/rand"
+	"testing"
+)
+
+func compressData(t *testing.T, data []byte, level, threads, blockSize int) []byte {
+	var buf bytes.Buffer
+	w, err := newWriter(&buf, level, threads, blockSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Write in small chunks to fill the blocks in several writes
+	for p := data; len(p) > 0; {
+		n := 1000
+		if n > len(p) {
+			n = len(p)
+		}
+		if _, err := w.Write(p[:n]); err != nil {
+			t.Fatal(err)
+		}
+		p = p[n:]
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestWriterRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	text := []byte("The quick brown fox jumps over the lazy dog. ")
+
+	for _, size := range []int{0, 1, 4096, 3 * 4096, 5*4096 + 17} {
+		data := make([]byte, size)
+		for i := range data {
+			if r.Intn(4) == 0 {
+				data[i] = byte(r.Intn(256))
+			} else {
+				data[i] = text[i%len(text)]
+			}
+		}
+
+		for _, level := range []int{gzip.HuffmanOnly, gzip.NoCompression, gzip.BestSpeed, gzip.DefaultCompression, gzip.BestCompression} {
+			compressed := compressData(t, data, level, 3, 4096)
+
+			zr, err := gzip.NewReader(bytes.NewReader(compressed))
+			if err != nil {
+				t.Fatalf("size %d, level %d: %v", size, level, err)
+			}
+			out, err := ioutil.ReadAll(zr)
+			if err != nil {
+				t.Fatalf("size %d, level %d: %v", size, level, err)
+			}
+			if !bytes.Equal(out, data) {
+				t.Fatalf("size %d, level %d: decompressed data differs", size, level)
+			}
+		}
+	}
+}
+
+func TestWriterOutputDoesNotDependOnThreads(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789abcdef"), 4096)
+
+	expected := compressData(t, data, gzip.DefaultCompression, 1, 4096)
+	for _, threads := range []int{2, 4, 16} {
+		if out := compressData(t, data, gzip.DefaultCompression, threads, 4096); !bytes.Equal(out, expected) {
+			t.Fatalf("output with %d threads differs from the output with 1 thread", threads)
+		}
+	}
+}
+
+func TestNewWriterLevelInvalidLevel(t *testing.T) {
+	for _, level := range []int{-3, 10} {
+		if _, err := NewWriterLevel(ioutil.Discard, level, 2); err == nil {
+			t.Fatalf("expected an error for level %d", level)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/docker/docker/api/types/mount"
 )
@@ -36,6 +37,10 @@ func validateMountConfig(mnt *mount.Mount, options ...func(*validateOpts)) error
 		}
 	}
 
+	if mnt.ImageOptions != nil && mnt.Type != mount.TypeImage {
+		return &errMountConfig{mnt, errExtraField("ImageOptions")}
+	}
+
 	switch mnt.Type {
 	case mount.TypeBind:
 		if len(mnt.Source) == 0 {
@@ -97,6 +102,24 @@ func validateMountConfig(mnt *mount.Mount, options ...func(*validateOpts)) error
 		if _, err := ConvertTmpfsOptions(mnt.TmpfsOptions, mnt.ReadOnly); err != nil {
 			return &errMountConfig{mnt, err}
 		}
+	case mount.TypeImage:
+		if len(mnt.Source) == 0 {
+			return &errMountConfig{mnt, errMissingField("Source")}
+		}
+		if mnt.BindOptions != nil {
+			return &errMountConfig{mnt, errExtraField("BindOptions")}
+		}
+		if mnt.VolumeOptions != nil {
+			return &errMountConfig{mnt, errExtraField("VolumeOptions")}
+		}
+		if mnt.TmpfsOptions != nil {
+			return &errMountConfig{mnt, errExtraField("TmpfsOptions")}
+		}
+		if opts := mnt.ImageOptions; opts != nil && len(opts.Subpath) > 0 {
+			if err := validateSubpath(opts.Subpath); err != nil {
+				return &errMountConfig{mnt, err}
+			}
+		}
 	default:
 		return &errMountConfig{mnt, errors.New("mount type unknown")}
 	}
@@ -138,3 +161,13 @@ func ValidateTmpfsMountDestination(dest string) error {
 	}
 	return validateAbsolute(dest)
 }
+
+// validateSubpath validates the subpath of an image mount, which must be a
+// relative path that stays within the filesystem of the image.
+func validateSubpath(p string) error {
+	p = filepath.Clean(convertSlash(p))
+	if filepath.IsAbs(p) || p == ".." || strings.HasPrefix(p, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("invalid subpath: '%s' must be a relative path within the image", p)
+	}
+	return nil
+}
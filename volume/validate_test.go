@@ -29,6 +29,12 @@ func TestValidateMount(t *testing.T) {
 		{mount.Mount{Type: mount.TypeBind, Target: testDestinationPath, Source: testSourcePath, VolumeOptions: &mount.VolumeOptions{}}, errExtraField("VolumeOptions")},
 		{mount.Mount{Type: mount.TypeBind, Source: testSourcePath, Target: testDestinationPath}, errBindNotExist},
 		{mount.Mount{Type: mount.TypeBind, Source: testDir, Target: testDestinationPath}, nil},
+		{mount.Mount{Type: mount.TypeImage, Target: testDestinationPath}, errMissingField("Source")},
+		{mount.Mount{Type: mount.TypeImage, Target: testDestinationPath, Source: "busybox"}, nil},
+		{mount.Mount{Type: mount.TypeImage, Target: testDestinationPath, Source: "busybox", ImageOptions: &mount.ImageOptions{Subpath: "data/models"}}, nil},
+		{mount.Mount{Type: mount.TypeImage, Target: testDestinationPath, Source: "busybox", ImageOptions: &mount.ImageOptions{Subpath: "../data"}}, errors.New("invalid subpath")},
+		{mount.Mount{Type: mount.TypeImage, Target: testDestinationPath, Source: "busybox", VolumeOptions: &mount.VolumeOptions{}}, errExtraField("VolumeOptions")},
+		{mount.Mount{Type: mount.TypeVolume, Target: testDestinationPath, ImageOptions: &mount.ImageOptions{}}, errExtraField("ImageOptions")},
 		{mount.Mount{Type: "invalid", Target: testDestinationPath}, errors.New("mount type unknown")},
 	}
 	for i, x := range cases {
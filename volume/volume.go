@@ -76,6 +76,16 @@ type DetailedVolume interface {
 	Volume
 }
 
+// ImageMounter mounts the filesystem of an image for a mount point of type
+// image. The image is mounted once for each call to Mount, and Unmount
+// undoes the last Mount with the given id.
+type ImageMounter interface {
+	// Mount mounts the image and returns the path to mount in the container.
+	Mount(id string) (string, error)
+	// Unmount unmounts the image.
+	Unmount(id string) error
+}
+
 // MountPoint is the intersection point between a volume and a container. It
 // specifies which volume is to be used and where inside a container it should
 // be mounted.
@@ -98,6 +108,9 @@ type MountPoint struct {
 	// Volume is the volume providing data to this mountpoint.
 	// This is nil unless `Type` is set to `TypeVolume`
 	Volume Volume `json:"-"`
+	// Image mounts the image providing data to this mountpoint.
+	// This is nil unless `Type` is set to `TypeImage`
+	Image ImageMounter `json:"-"`
 
 	// Mode is the comma separated list of options supplied by the user when creating
 	// the bind/volume mount.
@@ -150,6 +163,18 @@ func (m *MountPoint) Setup(mountLabel string, rootUID, rootGID int) (path string
 		m.ID = id
 		return path, nil
 	}
+	if m.Image != nil {
+		id := m.ID
+		if id == "" {
+			id = stringid.GenerateNonCryptoID()
+		}
+		path, err := m.Image.Mount(id)
+		if err != nil {
+			return "", errors.Wrapf(err, "error while mounting image '%s'", m.Spec.Source)
+		}
+		m.ID = id
+		return path, nil
+	}
 	if len(m.Source) == 0 {
 		return "", fmt.Errorf("Unable to setup mount point, neither source nor volume defined")
 	}
@@ -318,6 +343,9 @@ func ParseMountSpec(cfg mounttypes.Mount, options ...func(*validateOpts)) (*Moun
 		}
 	case mounttypes.TypeTmpfs:
 		// NOP
+	case mounttypes.TypeImage:
+		// The image is mounted read-only
+		mp.RW = false
 	}
 	return mp, nil
 }